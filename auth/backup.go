@@ -0,0 +1,234 @@
+package auth
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// BackupVersion is the format version written by ExportStore.
+const BackupVersion = 1
+
+// How the secrets in a backup are protected.
+const (
+	// BackupSecretsEncrypted means client secrets, opaque tokens, and Webex tokens
+	// are AES-256-GCM ciphertexts under a key derived from a passphrase.
+	BackupSecretsEncrypted = "encrypted"
+	// BackupSecretsRedacted means the backup holds no secrets: client secrets are
+	// blank and tokens are left out, so every user signs in again after import.
+	BackupSecretsRedacted = "redacted"
+)
+
+// backupKDFIterations is the PBKDF2-SHA256 work factor for new backups.
+const backupKDFIterations = 600000
+
+// ErrBackupPassphrase is returned when an encrypted backup cannot be decrypted,
+// either because the passphrase is wrong or because the file was altered.
+var ErrBackupPassphrase = errors.New("cannot decrypt backup: wrong passphrase or corrupted file")
+
+// StoreBackup is the portable form of a store's registered clients and tokens,
+// used to move them between store backends.
+type StoreBackup struct {
+	Version    int                 `json:"version"`
+	ExportedAt time.Time           `json:"exported_at"`
+	Secrets    string              `json:"secrets"`
+	Encryption *BackupEncryption   `json:"encryption,omitempty"`
+	Clients    []*RegisteredClient `json:"clients"`
+	Tokens     []*TokenRecord      `json:"tokens"`
+}
+
+// BackupEncryption describes how the secrets of an encrypted backup were sealed.
+type BackupEncryption struct {
+	Cipher     string `json:"cipher"`
+	KDF        string `json:"kdf"`
+	Iterations int    `json:"iterations"`
+	Salt       string `json:"salt"`
+}
+
+// BackupCounts reports how many records an import wrote.
+type BackupCounts struct {
+	Clients int `json:"clients"`
+	Tokens  int `json:"tokens"`
+}
+
+// ExportStore reads every registered client and token from store. With a
+// passphrase the secrets are encrypted; without one they are redacted.
+// Authorization codes and pending authorizations are short-lived and not exported.
+func ExportStore(store Store, passphrase string) (*StoreBackup, error) {
+	clients, err := store.ListClients()
+	if err != nil {
+		return nil, err
+	}
+	tokens, err := store.ListTokens()
+	if err != nil {
+		return nil, err
+	}
+
+	backup := &StoreBackup{
+		Version:    BackupVersion,
+		ExportedAt: time.Now().UTC(),
+		Clients:    clients,
+		Tokens:     tokens,
+	}
+	if backup.Clients == nil {
+		backup.Clients = []*RegisteredClient{}
+	}
+	if backup.Tokens == nil {
+		backup.Tokens = []*TokenRecord{}
+	}
+
+	if passphrase == "" {
+		backup.Secrets = BackupSecretsRedacted
+		for _, c := range backup.Clients {
+			c.ClientSecret = ""
+		}
+		backup.Tokens = []*TokenRecord{}
+		return backup, nil
+	}
+
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+	backup.Secrets = BackupSecretsEncrypted
+	backup.Encryption = &BackupEncryption{
+		Cipher:     "AES-256-GCM",
+		KDF:        "PBKDF2-SHA256",
+		Iterations: backupKDFIterations,
+		Salt:       base64.StdEncoding.EncodeToString(salt),
+	}
+	aead, err := backupCipher(passphrase, backup.Encryption)
+	if err != nil {
+		return nil, err
+	}
+
+	seal := func(plaintext string) (string, error) {
+		if plaintext == "" {
+			return "", nil
+		}
+		nonce := make([]byte, aead.NonceSize())
+		if _, err := rand.Read(nonce); err != nil {
+			return "", fmt.Errorf("failed to generate nonce: %w", err)
+		}
+		return base64.StdEncoding.EncodeToString(aead.Seal(nonce, nonce, []byte(plaintext), nil)), nil
+	}
+	if err := transformBackupSecrets(backup, seal); err != nil {
+		return nil, err
+	}
+	return backup, nil
+}
+
+// ImportStore writes the clients and tokens of backup into store, replacing
+// records with the same client_id or opaque token. Every secret is decrypted
+// before anything is written, so a wrong passphrase leaves store untouched.
+func ImportStore(store Store, backup *StoreBackup, passphrase string) (BackupCounts, error) {
+	var counts BackupCounts
+	if backup.Version != BackupVersion {
+		return counts, fmt.Errorf("unsupported backup version %d (this server reads version %d)", backup.Version, BackupVersion)
+	}
+
+	switch backup.Secrets {
+	case BackupSecretsRedacted:
+	case BackupSecretsEncrypted:
+		if passphrase == "" {
+			return counts, errors.New("backup is encrypted; a passphrase is required to import it")
+		}
+		if backup.Encryption == nil {
+			return counts, errors.New("backup is marked encrypted but has no encryption parameters")
+		}
+		aead, err := backupCipher(passphrase, backup.Encryption)
+		if err != nil {
+			return counts, err
+		}
+		open := func(ciphertext string) (string, error) {
+			if ciphertext == "" {
+				return "", nil
+			}
+			data, err := base64.StdEncoding.DecodeString(ciphertext)
+			if err != nil || len(data) < aead.NonceSize() {
+				return "", ErrBackupPassphrase
+			}
+			plaintext, err := aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], nil)
+			if err != nil {
+				return "", ErrBackupPassphrase
+			}
+			return string(plaintext), nil
+		}
+		if err := transformBackupSecrets(backup, open); err != nil {
+			return counts, err
+		}
+	default:
+		return counts, fmt.Errorf("unknown backup secrets mode %q", backup.Secrets)
+	}
+
+	for _, c := range backup.Clients {
+		if c.ClientID == "" {
+			return counts, errors.New("backup contains a client without client_id")
+		}
+		if err := store.ImportClient(c); err != nil {
+			return counts, err
+		}
+		counts.Clients++
+	}
+	for _, t := range backup.Tokens {
+		if t.OpaqueToken == "" {
+			return counts, errors.New("backup contains a token without opaque_token")
+		}
+		if err := store.ImportToken(t); err != nil {
+			return counts, err
+		}
+		counts.Tokens++
+	}
+	return counts, nil
+}
+
+// backupCipher derives the AES-256-GCM cipher for a backup from the passphrase.
+func backupCipher(passphrase string, enc *BackupEncryption) (cipher.AEAD, error) {
+	if enc.Cipher != "AES-256-GCM" || enc.KDF != "PBKDF2-SHA256" {
+		return nil, fmt.Errorf("unsupported backup encryption %s with %s", enc.Cipher, enc.KDF)
+	}
+	if enc.Iterations < 1 || enc.Iterations > 10*backupKDFIterations {
+		return nil, fmt.Errorf("backup has an invalid KDF iteration count %d", enc.Iterations)
+	}
+	salt, err := base64.StdEncoding.DecodeString(enc.Salt)
+	if err != nil || len(salt) == 0 {
+		return nil, errors.New("backup has an invalid encryption salt")
+	}
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, enc.Iterations, 32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive backup key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// transformBackupSecrets replaces every secret field of backup with fn applied to it.
+func transformBackupSecrets(backup *StoreBackup, fn func(string) (string, error)) error {
+	var err error
+	for _, c := range backup.Clients {
+		if c.ClientSecret, err = fn(c.ClientSecret); err != nil {
+			return err
+		}
+	}
+	for _, t := range backup.Tokens {
+		if t.OpaqueToken, err = fn(t.OpaqueToken); err != nil {
+			return err
+		}
+		if t.WebexAccessToken, err = fn(t.WebexAccessToken); err != nil {
+			return err
+		}
+		if t.WebexRefreshToken, err = fn(t.WebexRefreshToken); err != nil {
+			return err
+		}
+	}
+	return nil
+}
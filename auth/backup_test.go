@@ -0,0 +1,131 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newBackupSource returns a memory store holding one confidential client and one token.
+func newBackupSource(t *testing.T) (*MemoryStore, *RegisteredClient, string) {
+	t.Helper()
+	src := NewMemoryStore(time.Minute)
+	client, err := src.RegisterClient(&RegistrationRequest{
+		RedirectURIs:            []string{"http://localhost/cb"},
+		ClientName:              "CLI",
+		TokenEndpointAuthMethod: "client_secret_post",
+	})
+	if err != nil {
+		t.Fatalf("RegisterClient: %v", err)
+	}
+	opaque, err := src.StoreToken("webex-at", "webex-rt", 3600, TokenOwner{UserID: "u1", ClientID: client.ClientID, ClientName: "CLI"})
+	if err != nil {
+		t.Fatalf("StoreToken: %v", err)
+	}
+	return src, client, opaque
+}
+
+func TestStoreBackupEncryptedRoundTrip(t *testing.T) {
+	src, client, opaque := newBackupSource(t)
+	defer src.Close()
+
+	backup, err := ExportStore(src, "correct horse")
+	if err != nil {
+		t.Fatalf("ExportStore: %v", err)
+	}
+	data, _ := json.Marshal(backup)
+	for _, secret := range []string{client.ClientSecret, opaque, "webex-at", "webex-rt"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("export contains plaintext secret %q", secret)
+		}
+	}
+
+	// Decode from JSON as the CLI does, once for a failed and once for a good import
+	var wrong, right StoreBackup
+	json.Unmarshal(data, &wrong)
+	json.Unmarshal(data, &right)
+
+	dst, err := NewSQLiteStore(":memory:", time.Minute, PoolConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dst.Close()
+
+	if _, err := ImportStore(dst, &wrong, "wrong"); !errors.Is(err, ErrBackupPassphrase) {
+		t.Fatalf("ImportStore with wrong passphrase: err = %v, want ErrBackupPassphrase", err)
+	}
+	if clients, _ := dst.ListClients(); len(clients) != 0 {
+		t.Fatalf("failed import wrote %d clients", len(clients))
+	}
+
+	counts, err := ImportStore(dst, &right, "correct horse")
+	if err != nil {
+		t.Fatalf("ImportStore: %v", err)
+	}
+	if counts != (BackupCounts{Clients: 1, Tokens: 1}) {
+		t.Errorf("counts = %+v", counts)
+	}
+
+	got, ok := dst.LookupClient(client.ClientID)
+	if !ok || got.ClientSecret != client.ClientSecret || got.ClientName != "CLI" || !dst.ValidateRedirectURI(client.ClientID, "http://localhost/cb") {
+		t.Errorf("imported client = %+v, %v", got, ok)
+	}
+	record, ok := dst.LookupToken(opaque)
+	if !ok || record.WebexAccessToken != "webex-at" || record.WebexRefreshToken != "webex-rt" || record.UserID != "u1" {
+		t.Errorf("imported token = %+v, %v", record, ok)
+	}
+
+	// Importing again replaces rather than duplicates
+	again, _ := ExportStore(src, "pw")
+	if _, err := ImportStore(dst, again, "pw"); err != nil {
+		t.Fatalf("second ImportStore: %v", err)
+	}
+	if tokens, _ := dst.ListTokens(); len(tokens) != 1 {
+		t.Errorf("tokens after re-import = %d, want 1", len(tokens))
+	}
+}
+
+func TestStoreBackupRedacted(t *testing.T) {
+	src, client, _ := newBackupSource(t)
+	defer src.Close()
+
+	backup, err := ExportStore(src, "")
+	if err != nil {
+		t.Fatalf("ExportStore: %v", err)
+	}
+	if backup.Secrets != BackupSecretsRedacted || len(backup.Tokens) != 0 || backup.Clients[0].ClientSecret != "" {
+		t.Fatalf("redacted backup = %+v", backup)
+	}
+	if c, _ := src.LookupClient(client.ClientID); c.ClientSecret == "" {
+		t.Error("redacting the export cleared the source client's secret")
+	}
+
+	dst := NewMemoryStore(time.Minute)
+	defer dst.Close()
+	counts, err := ImportStore(dst, backup, "")
+	if err != nil || counts != (BackupCounts{Clients: 1}) {
+		t.Fatalf("ImportStore = %+v, %v", counts, err)
+	}
+	if _, ok := dst.LookupClient(client.ClientID); !ok {
+		t.Error("redacted import lost the client")
+	}
+}
+
+func TestImportStoreRejectsBadBackups(t *testing.T) {
+	dst := NewMemoryStore(time.Minute)
+	defer dst.Close()
+	tests := map[string]*StoreBackup{
+		"future version":       {Version: BackupVersion + 1, Secrets: BackupSecretsRedacted},
+		"unknown secrets mode": {Version: BackupVersion, Secrets: "plaintext"},
+		"encrypted, no params": {Version: BackupVersion, Secrets: BackupSecretsEncrypted},
+		"client without id":    {Version: BackupVersion, Secrets: BackupSecretsRedacted, Clients: []*RegisteredClient{{}}},
+		"token without opaque": {Version: BackupVersion, Secrets: BackupSecretsRedacted, Tokens: []*TokenRecord{{}}},
+	}
+	for name, backup := range tests {
+		if _, err := ImportStore(dst, backup, "pw"); err == nil {
+			t.Errorf("%s: ImportStore succeeded, want error", name)
+		}
+	}
+}
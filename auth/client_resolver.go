@@ -1,10 +1,13 @@
 package auth
 
 import (
+	"container/list"
 	"context"
 	"crypto/sha256"
+	"encoding/json"
 	"fmt"
 	"log"
+	"net/http"
 	"sync"
 	"time"
 
@@ -25,6 +28,8 @@ const (
 	webexClientKey contextKey = iota
 	// webexTokenKey is the context key for the raw Webex access token string.
 	webexTokenKey
+	// tokenRecordKey is the context key for the caller's opaque token record.
+	tokenRecordKey
 )
 
 // ContextWithWebexClient returns a new context carrying the Webex client.
@@ -49,6 +54,18 @@ func WebexTokenFromContext(ctx context.Context) (string, bool) {
 	return token, ok
 }
 
+// ContextWithTokenRecord returns a new context carrying the caller's opaque token record.
+func ContextWithTokenRecord(ctx context.Context, record *TokenRecord) context.Context {
+	return context.WithValue(ctx, tokenRecordKey, record)
+}
+
+// TokenRecordFromContext extracts the caller's opaque token record from the context.
+// It is absent in STDIO mode and for dev-insecure raw tokens.
+func TokenRecordFromContext(ctx context.Context) (*TokenRecord, bool) {
+	record, ok := ctx.Value(tokenRecordKey).(*TokenRecord)
+	return record, ok && record != nil
+}
+
 // NewStaticClientResolver returns a ClientResolver that always returns the same client.
 // Used in STDIO mode where a single WEBEX_ACCESS_TOKEN is shared.
 func NewStaticClientResolver(client *webex.WebexClient) ClientResolver {
@@ -70,26 +87,39 @@ func NewHTTPClientResolver() ClientResolver {
 	}
 }
 
-// cachedClient holds a cached Webex client with expiry.
+// cachedClient holds a cached Webex client with its expiry.
 type cachedClient struct {
+	key       string
 	client    *webex.WebexClient
-	expiresAt time.Time
+	expiresAt time.Time // absolute, set when the client is created
+	lastUsed  time.Time
 }
 
 // ClientCache caches *webex.WebexClient instances keyed by token hash.
+// Entries expire ttl after they are created regardless of use, and optionally
+// earlier after idleTimeout without use. When maxEntries is reached the
+// least-recently-used entry is evicted to make room.
 type ClientCache struct {
-	mu          sync.RWMutex
-	entries     map[string]*cachedClient
+	mu          sync.Mutex
+	entries     map[string]*list.Element // key -> element holding *cachedClient
+	lru         *list.List               // front = most recently used
 	ttl         time.Duration
+	idleTimeout time.Duration // 0 = no idle timeout
+	maxEntries  int           // 0 = unbounded
+	evictions   int64         // clients evicted because the cache was full
 	config      *webexsdk.Config
+	onCreate    func(*webex.WebexClient)
 	stopCleanup chan struct{}
 }
 
-// NewClientCache creates a new client cache with the given TTL and SDK config.
-func NewClientCache(ttl time.Duration, config *webexsdk.Config) *ClientCache {
+// NewClientCache creates a new client cache with the given TTL, maximum
+// number of entries (0 for no limit), and SDK config.
+func NewClientCache(ttl time.Duration, maxEntries int, config *webexsdk.Config) *ClientCache {
 	cc := &ClientCache{
-		entries:     make(map[string]*cachedClient),
+		entries:     make(map[string]*list.Element),
+		lru:         list.New(),
 		ttl:         ttl,
+		maxEntries:  maxEntries,
 		config:      config,
 		stopCleanup: make(chan struct{}),
 	}
@@ -97,29 +127,60 @@ func NewClientCache(ttl time.Duration, config *webexsdk.Config) *ClientCache {
 	return cc
 }
 
+// SetOnCreate registers fn to be called on every newly created client before it is
+// cached or used (e.g. to instrument its HTTP client). Call it before serving requests.
+func (cc *ClientCache) SetOnCreate(fn func(*webex.WebexClient)) {
+	cc.onCreate = fn
+}
+
+// SetIdleTimeout makes entries expire after d without use, in addition to the
+// TTL. Zero disables the idle timeout. Call it before serving requests.
+func (cc *ClientCache) SetIdleTimeout(d time.Duration) {
+	cc.idleTimeout = d
+}
+
 // GetOrCreate returns a cached client for the given token, or creates a new one.
+// A cache hit marks the entry as recently used; it does not extend the TTL.
 func (cc *ClientCache) GetOrCreate(accessToken string) (*webex.WebexClient, error) {
 	key := tokenHash(accessToken)
 
-	cc.mu.RLock()
-	if entry, ok := cc.entries[key]; ok && time.Now().Before(entry.expiresAt) {
-		cc.mu.RUnlock()
-		return entry.client, nil
+	cc.mu.Lock()
+	if elem, ok := cc.entries[key]; ok {
+		entry := elem.Value.(*cachedClient)
+		if now := time.Now(); !cc.expired(entry, now) {
+			entry.lastUsed = now
+			cc.lru.MoveToFront(elem)
+			cc.mu.Unlock()
+			return entry.client, nil
+		}
 	}
-	cc.mu.RUnlock()
+	cc.mu.Unlock()
 
 	// Create new client
 	client, err := webex.NewClient(accessToken, cc.config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Webex client: %w", err)
 	}
+	if cc.onCreate != nil {
+		cc.onCreate(client)
+	}
 
 	cc.mu.Lock()
-	cc.entries[key] = &cachedClient{
+	defer cc.mu.Unlock()
+	if elem, ok := cc.entries[key]; ok {
+		cc.removeElement(elem)
+	}
+	now := time.Now()
+	cc.entries[key] = cc.lru.PushFront(&cachedClient{
+		key:       key,
 		client:    client,
-		expiresAt: time.Now().Add(cc.ttl),
+		expiresAt: now.Add(cc.ttl),
+		lastUsed:  now,
+	})
+	for cc.maxEntries > 0 && cc.lru.Len() > cc.maxEntries {
+		cc.removeElement(cc.lru.Back())
+		cc.evictions++
 	}
-	cc.mu.Unlock()
 
 	return client, nil
 }
@@ -128,15 +189,45 @@ func (cc *ClientCache) GetOrCreate(accessToken string) (*webex.WebexClient, erro
 func (cc *ClientCache) Evict(accessToken string) {
 	key := tokenHash(accessToken)
 	cc.mu.Lock()
-	delete(cc.entries, key)
+	if elem, ok := cc.entries[key]; ok {
+		cc.removeElement(elem)
+	}
 	cc.mu.Unlock()
 }
 
+// Len returns the number of cached clients.
+func (cc *ClientCache) Len() int {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	return cc.lru.Len()
+}
+
+// HandleStats serves /debug/vars with the cache size and the number of LRU
+// evictions. It deliberately publishes nothing else: the stock expvar handler
+// would also expose the command line, which carries secrets.
+func (cc *ClientCache) HandleStats(w http.ResponseWriter, r *http.Request) {
+	cc.mu.Lock()
+	stats := map[string]int64{
+		"webex_client_cache_size":          int64(cc.lru.Len()),
+		"webex_client_cache_lru_evictions": cc.evictions,
+	}
+	cc.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(stats)
+}
+
 // Close stops the background cleanup goroutine.
 func (cc *ClientCache) Close() {
 	close(cc.stopCleanup)
 }
 
+// removeElement drops an entry from both the map and the LRU list. Caller must hold cc.mu.
+func (cc *ClientCache) removeElement(elem *list.Element) {
+	entry := cc.lru.Remove(elem).(*cachedClient)
+	delete(cc.entries, entry.key)
+}
+
 // cleanup periodically removes expired entries.
 func (cc *ClientCache) cleanup() {
 	ticker := time.NewTicker(1 * time.Minute)
@@ -146,15 +237,29 @@ func (cc *ClientCache) cleanup() {
 		case <-cc.stopCleanup:
 			return
 		case <-ticker.C:
-			cc.mu.Lock()
-			now := time.Now()
-			for k, v := range cc.entries {
-				if now.After(v.expiresAt) {
-					delete(cc.entries, k)
-				}
-			}
-			cc.mu.Unlock()
+			cc.removeExpired(time.Now())
+		}
+	}
+}
+
+// expired reports whether entry has passed its TTL or idle timeout at now.
+func (cc *ClientCache) expired(entry *cachedClient, now time.Time) bool {
+	if now.After(entry.expiresAt) {
+		return true
+	}
+	return cc.idleTimeout > 0 && now.Sub(entry.lastUsed) > cc.idleTimeout
+}
+
+// removeExpired drops every entry that has expired at now.
+func (cc *ClientCache) removeExpired(now time.Time) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	for elem := cc.lru.Back(); elem != nil; {
+		prev := elem.Prev()
+		if cc.expired(elem.Value.(*cachedClient), now) {
+			cc.removeElement(elem)
 		}
+		elem = prev
 	}
 }
 
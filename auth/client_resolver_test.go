@@ -2,6 +2,10 @@ package auth
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
 	"testing"
 	"time"
 
@@ -61,7 +65,7 @@ func TestNewHTTPClientResolver(t *testing.T) {
 
 func TestClientCacheGetOrCreate(t *testing.T) {
 	t.Helper()
-	cc := NewClientCache(time.Minute, &webexsdk.Config{BaseURL: "https://example.com"})
+	cc := NewClientCache(time.Minute, 0, &webexsdk.Config{BaseURL: "https://example.com"})
 	defer cc.Close()
 
 	// Invalid token - GetOrCreate will fail (webex.NewClient may still create a client
@@ -81,7 +85,7 @@ func TestClientCacheGetOrCreate(t *testing.T) {
 }
 
 func TestClientCacheClose(t *testing.T) {
-	cc := NewClientCache(time.Minute, &webexsdk.Config{BaseURL: "https://example.com"})
+	cc := NewClientCache(time.Minute, 0, &webexsdk.Config{BaseURL: "https://example.com"})
 	cc.Close() // must not panic
 }
 
@@ -102,3 +106,95 @@ func TestTokenHash(t *testing.T) {
 		t.Error("tokenHash should produce different output for different input")
 	}
 }
+
+func TestClientCacheLRUEviction(t *testing.T) {
+	cc := NewClientCache(time.Minute, 2, &webexsdk.Config{BaseURL: "https://example.com"})
+	defer cc.Close()
+
+	a, err := cc.GetOrCreate("token-a")
+	if err != nil {
+		t.Fatalf("GetOrCreate(a): %v", err)
+	}
+	if _, err := cc.GetOrCreate("token-b"); err != nil {
+		t.Fatalf("GetOrCreate(b): %v", err)
+	}
+
+	// Touch a so b becomes least recently used, then add c to trigger eviction.
+	if got, _ := cc.GetOrCreate("token-a"); got != a {
+		t.Error("expected cached client for token-a")
+	}
+	if _, err := cc.GetOrCreate("token-c"); err != nil {
+		t.Fatalf("GetOrCreate(c): %v", err)
+	}
+
+	if cc.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", cc.Len())
+	}
+	if got, _ := cc.GetOrCreate("token-a"); got != a {
+		t.Error("token-a was evicted, want token-b evicted")
+	}
+
+	rec := httptest.NewRecorder()
+	cc.HandleStats(rec, httptest.NewRequest(http.MethodGet, "/debug/vars", nil))
+	var stats map[string]int64
+	if err := json.Unmarshal(rec.Body.Bytes(), &stats); err != nil {
+		t.Fatalf("HandleStats body: %v", err)
+	}
+	want := map[string]int64{"webex_client_cache_size": 2, "webex_client_cache_lru_evictions": 1}
+	if !reflect.DeepEqual(stats, want) {
+		t.Errorf("HandleStats = %v, want %v", stats, want)
+	}
+}
+
+func TestClientCacheAbsoluteExpiry(t *testing.T) {
+	cc := NewClientCache(time.Minute, 0, &webexsdk.Config{BaseURL: "https://example.com"})
+	defer cc.Close()
+
+	a, err := cc.GetOrCreate("token-a")
+	if err != nil {
+		t.Fatalf("GetOrCreate(a): %v", err)
+	}
+	// Use the client right up to the TTL; use must not extend it.
+	elem := cc.entries[tokenHash("token-a")]
+	elem.Value.(*cachedClient).lastUsed = time.Now().Add(50 * time.Second)
+	if got, _ := cc.GetOrCreate("token-a"); got != a {
+		t.Fatal("expected cached client for token-a")
+	}
+
+	cc.removeExpired(time.Now().Add(30 * time.Second))
+	if cc.Len() != 1 {
+		t.Fatalf("Len() = %d after 30s, want 1", cc.Len())
+	}
+	cc.removeExpired(time.Now().Add(2 * time.Minute))
+	if cc.Len() != 0 {
+		t.Errorf("Len() = %d after 2m in constant use, want 0", cc.Len())
+	}
+}
+
+func TestClientCacheIdleExpiry(t *testing.T) {
+	cc := NewClientCache(time.Hour, 0, &webexsdk.Config{BaseURL: "https://example.com"})
+	cc.SetIdleTimeout(time.Minute)
+	defer cc.Close()
+
+	if _, err := cc.GetOrCreate("token-a"); err != nil {
+		t.Fatalf("GetOrCreate(a): %v", err)
+	}
+	if _, err := cc.GetOrCreate("token-b"); err != nil {
+		t.Fatalf("GetOrCreate(b): %v", err)
+	}
+
+	cc.removeExpired(time.Now().Add(30 * time.Second))
+	if cc.Len() != 2 {
+		t.Fatalf("Len() = %d after 30s idle, want 2", cc.Len())
+	}
+
+	// token-a stays in use, token-b goes idle
+	cc.entries[tokenHash("token-a")].Value.(*cachedClient).lastUsed = time.Now().Add(90 * time.Second)
+	cc.removeExpired(time.Now().Add(2 * time.Minute))
+	if cc.Len() != 1 {
+		t.Errorf("Len() = %d after 2m, want 1 (only the idle client evicted)", cc.Len())
+	}
+	if _, ok := cc.entries[tokenHash("token-a")]; !ok {
+		t.Error("token-a was evicted while in use")
+	}
+}
@@ -1,11 +1,18 @@
 package auth
 
 import (
+	"fmt"
 	"log"
+	"net"
 	"net/http"
+	"strings"
 	"time"
 )
 
+// DevTokenHeader is the request header that carries a raw Webex access token
+// when dev-insecure mode is enabled. It bypasses the OAuth flow entirely.
+const DevTokenHeader = "X-Dev-Webex-Token"
+
 // AuthMiddleware validates Bearer tokens on MCP requests and injects
 // the corresponding Webex client into the request context.
 type AuthMiddleware struct {
@@ -13,6 +20,7 @@ type AuthMiddleware struct {
 	clientCache  *ClientCache
 	oauthHandler *OAuthHandler
 	serverURL    string
+	devInsecure  bool
 }
 
 // NewAuthMiddleware creates a new auth middleware.
@@ -25,9 +33,22 @@ func NewAuthMiddleware(store Store, clientCache *ClientCache, oauthHandler *OAut
 	}
 }
 
+// EnableDevTokenHeader allows requests to authenticate with a raw Webex access token
+// in the X-Dev-Webex-Token header. For local development only -- never enable in production.
+func (am *AuthMiddleware) EnableDevTokenHeader() {
+	am.devInsecure = true
+}
+
 // Wrap returns an http.Handler that enforces Bearer token authentication.
 func (am *AuthMiddleware) Wrap(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if am.devInsecure {
+			if devToken := r.Header.Get(DevTokenHeader); devToken != "" {
+				am.serveDevToken(w, r, devToken, next)
+				return
+			}
+		}
+
 		authHeader := r.Header.Get("Authorization")
 		if authHeader == "" {
 			log.Printf("[AuthMW] %s %s: no Authorization header", r.Method, r.URL.Path)
@@ -83,10 +104,46 @@ func (am *AuthMiddleware) Wrap(next http.Handler) http.Handler {
 		// Inject the client and token into the context
 		ctx := ContextWithWebexClient(r.Context(), client)
 		ctx = ContextWithWebexToken(ctx, webexAccessToken)
+		ctx = ContextWithTokenRecord(ctx, record)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
 
+// serveDevToken builds a client directly from a raw Webex token (dev-insecure mode only).
+func (am *AuthMiddleware) serveDevToken(w http.ResponseWriter, r *http.Request, devToken string, next http.Handler) {
+	log.Printf("[AuthMW] %s %s: WARNING: authenticating via %s (dev-insecure mode), OAuth bypassed", r.Method, r.URL.Path, DevTokenHeader)
+
+	client, err := am.clientCache.GetOrCreate(devToken)
+	if err != nil {
+		log.Printf("[AuthMW] %s %s: FAILED to create Webex client from dev token: %v", r.Method, r.URL.Path, err)
+		writeJSONError(w, http.StatusInternalServerError, "server_error", "Failed to create API client")
+		return
+	}
+
+	ctx := ContextWithWebexClient(r.Context(), client)
+	ctx = ContextWithWebexToken(ctx, devToken)
+	next.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ValidateDevInsecure checks that dev-insecure mode is only used in a safe setup:
+// without TLS, the server must be bound to a loopback host.
+func ValidateDevInsecure(host string, tlsEnabled bool) error {
+	if tlsEnabled || isLoopbackHost(host) {
+		return nil
+	}
+	return fmt.Errorf("--dev-insecure requires TLS or a loopback bind host (localhost, 127.0.0.1, ::1); got host %q without TLS", host)
+}
+
+// isLoopbackHost reports whether host is "localhost" or a loopback IP address.
+func isLoopbackHost(host string) bool {
+	host = strings.Trim(host, "[]")
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
 // sendUnauthorized sends a 401 response with the WWW-Authenticate header.
 func (am *AuthMiddleware) sendUnauthorized(w http.ResponseWriter) {
 	w.Header().Set("WWW-Authenticate", BuildWWWAuthenticate(am.serverURL))
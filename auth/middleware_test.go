@@ -12,7 +12,7 @@ import (
 func TestAuthMiddleware_MissingAuthorization(t *testing.T) {
 	store := NewMemoryStore(time.Minute)
 	defer store.Close()
-	cc := NewClientCache(time.Minute, &webexsdk.Config{BaseURL: "https://example.com"})
+	cc := NewClientCache(time.Minute, 0, &webexsdk.Config{BaseURL: "https://example.com"})
 	defer cc.Close()
 	oauthHandler := NewOAuthHandler(&OAuthConfig{ServerURL: "https://example.com"}, store)
 
@@ -37,7 +37,7 @@ func TestAuthMiddleware_MissingAuthorization(t *testing.T) {
 func TestAuthMiddleware_MalformedBearer(t *testing.T) {
 	store := NewMemoryStore(time.Minute)
 	defer store.Close()
-	cc := NewClientCache(time.Minute, &webexsdk.Config{BaseURL: "https://example.com"})
+	cc := NewClientCache(time.Minute, 0, &webexsdk.Config{BaseURL: "https://example.com"})
 	defer cc.Close()
 	oauthHandler := NewOAuthHandler(&OAuthConfig{ServerURL: "https://example.com"}, store)
 
@@ -60,7 +60,7 @@ func TestAuthMiddleware_MalformedBearer(t *testing.T) {
 func TestAuthMiddleware_UnknownToken(t *testing.T) {
 	store := NewMemoryStore(time.Minute)
 	defer store.Close()
-	cc := NewClientCache(time.Minute, &webexsdk.Config{BaseURL: "https://example.com"})
+	cc := NewClientCache(time.Minute, 0, &webexsdk.Config{BaseURL: "https://example.com"})
 	defer cc.Close()
 	oauthHandler := NewOAuthHandler(&OAuthConfig{ServerURL: "https://example.com"}, store)
 
@@ -79,3 +79,63 @@ func TestAuthMiddleware_UnknownToken(t *testing.T) {
 		t.Errorf("status = %d, want 401", rec.Code)
 	}
 }
+
+func TestAuthMiddleware_DevTokenHeader(t *testing.T) {
+	store := NewMemoryStore(time.Minute)
+	defer store.Close()
+	cc := NewClientCache(time.Minute, 0, &webexsdk.Config{BaseURL: "https://example.com"})
+	defer cc.Close()
+	oauthHandler := NewOAuthHandler(&OAuthConfig{ServerURL: "https://example.com"}, store)
+
+	var gotToken string
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotToken, _ = WebexTokenFromContext(r.Context())
+		if _, ok := WebexClientFromContext(r.Context()); !ok {
+			t.Error("expected Webex client in context")
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	// Disabled by default: header is ignored
+	am := NewAuthMiddleware(store, cc, oauthHandler, "https://example.com")
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(DevTokenHeader, "raw-webex-token")
+	rec := httptest.NewRecorder()
+	am.Wrap(next).ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("disabled: status = %d, want 401", rec.Code)
+	}
+
+	// Enabled: header is used directly
+	am.EnableDevTokenHeader()
+	rec = httptest.NewRecorder()
+	am.Wrap(next).ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Errorf("enabled: status = %d, want 200", rec.Code)
+	}
+	if gotToken != "raw-webex-token" {
+		t.Errorf("token in context = %q, want raw-webex-token", gotToken)
+	}
+}
+
+func TestValidateDevInsecure(t *testing.T) {
+	tests := []struct {
+		host    string
+		tls     bool
+		wantErr bool
+	}{
+		{"localhost", false, false},
+		{"127.0.0.1", false, false},
+		{"::1", false, false},
+		{"[::1]", false, false},
+		{"0.0.0.0", false, true},
+		{"example.com", false, true},
+		{"0.0.0.0", true, false},
+	}
+	for _, tt := range tests {
+		err := ValidateDevInsecure(tt.host, tt.tls)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ValidateDevInsecure(%q, %v) error = %v, wantErr %v", tt.host, tt.tls, err, tt.wantErr)
+		}
+	}
+}
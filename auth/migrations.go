@@ -0,0 +1,147 @@
+package auth
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+)
+
+// schemaMigration is one versioned step of a SQL store's schema. Migrations run in
+// version order, each in its own transaction, and are recorded in schema_migrations
+// so that every step is applied exactly once per database.
+//
+// Databases created before versioning existed have no schema_migrations table and
+// replay every step, so steps must tolerate objects that already exist.
+type schemaMigration struct {
+	version int
+	name    string
+	apply   func(tx *sql.Tx) error
+}
+
+// execStatements returns a migration step that runs the statements in order.
+func execStatements(statements ...string) func(tx *sql.Tx) error {
+	return func(tx *sql.Tx) error {
+		for _, stmt := range statements {
+			if _, err := tx.Exec(stmt); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// schemaDialect holds the SQL that differs between the stores that run migrations.
+type schemaDialect struct {
+	// timestampType is the column type of schema_migrations.applied_at.
+	timestampType string
+	// lock, if set, runs first in every migration transaction to serialize
+	// servers that start against the same database at the same time.
+	lock string
+	// placeholder returns the bind parameter for the nth (1-based) argument.
+	placeholder func(n int) string
+}
+
+var sqliteDialect = schemaDialect{
+	timestampType: "DATETIME",
+	placeholder:   func(int) string { return "?" },
+}
+
+var postgresDialect = schemaDialect{
+	timestampType: "TIMESTAMPTZ",
+	// Arbitrary application-wide key; transaction-level so it is released on commit.
+	lock:        `SELECT pg_advisory_xact_lock(4947001)`,
+	placeholder: func(n int) string { return fmt.Sprintf("$%d", n) },
+}
+
+// latestSchemaVersion returns the highest version in migrations.
+func latestSchemaVersion(migrations []schemaMigration) int {
+	latest := 0
+	for _, m := range migrations {
+		if m.version > latest {
+			latest = m.version
+		}
+	}
+	return latest
+}
+
+// schemaVersion returns the highest migration version recorded in db, or 0 for a
+// database that has never been migrated.
+func schemaVersion(db *sql.DB) (int, error) {
+	var version sql.NullInt64
+	if err := db.QueryRow(`SELECT MAX(version) FROM schema_migrations`).Scan(&version); err != nil {
+		return 0, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	return int(version.Int64), nil
+}
+
+// migrateSchema brings db up to the latest version in migrations. It refuses to
+// touch a database whose schema is newer than this binary knows, so downgrading
+// the server cannot write rows an older schema does not expect.
+func migrateSchema(db *sql.DB, dialect schemaDialect, migrations []schemaMigration) error {
+	if _, err := db.Exec(fmt.Sprintf(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version INTEGER PRIMARY KEY,
+		name TEXT NOT NULL,
+		applied_at %s NOT NULL
+	)`, dialect.timestampType)); err != nil {
+		return fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+
+	current, err := schemaVersion(db)
+	if err != nil {
+		return err
+	}
+	if latest := latestSchemaVersion(migrations); current > latest {
+		return fmt.Errorf("store schema is at version %d but this server only supports up to version %d; upgrade webex-go-mcp", current, latest)
+	}
+
+	for _, m := range migrations {
+		if m.version <= current {
+			continue
+		}
+		applied, err := applyMigration(db, dialect, m)
+		if err != nil {
+			return fmt.Errorf("schema migration %d (%s) failed: %w", m.version, m.name, err)
+		}
+		if applied {
+			log.Printf("[Store] Applied schema migration %d: %s", m.version, m.name)
+		}
+	}
+	return nil
+}
+
+// applyMigration runs one migration and records it in the same transaction. It
+// reports false if another server recorded the migration first.
+func applyMigration(db *sql.DB, dialect schemaDialect, m schemaMigration) (bool, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	if dialect.lock != "" {
+		if _, err := tx.Exec(dialect.lock); err != nil {
+			return false, fmt.Errorf("failed to lock schema_migrations: %w", err)
+		}
+	}
+
+	var count int
+	if err := tx.QueryRow(`SELECT COUNT(*) FROM schema_migrations WHERE version = `+dialect.placeholder(1), m.version).Scan(&count); err != nil {
+		return false, err
+	}
+	if count > 0 {
+		return false, nil
+	}
+
+	if err := m.apply(tx); err != nil {
+		return false, err
+	}
+	if _, err := tx.Exec(
+		fmt.Sprintf(`INSERT INTO schema_migrations (version, name, applied_at) VALUES (%s, %s, %s)`,
+			dialect.placeholder(1), dialect.placeholder(2), dialect.placeholder(3)),
+		m.version, m.name, time.Now(),
+	); err != nil {
+		return false, fmt.Errorf("failed to record migration: %w", err)
+	}
+	return true, tx.Commit()
+}
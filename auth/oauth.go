@@ -16,6 +16,7 @@ import (
 const (
 	webexAuthorizeURL   = "https://webexapis.com/v1/authorize"
 	webexAccessTokenURL = "https://webexapis.com/v1/access_token"
+	webexPeopleMeURL    = "https://webexapis.com/v1/people/me"
 )
 
 // WebexTokenResponse is the JSON response from Webex's /v1/access_token endpoint.
@@ -29,15 +30,17 @@ type WebexTokenResponse struct {
 
 // OAuthHandler handles the OAuth 2.1 authorization flow, proxying to Webex.
 type OAuthHandler struct {
-	config *OAuthConfig
-	store  Store
+	config      *OAuthConfig
+	store       Store
+	peopleMeURL string // overridden in tests
 }
 
 // NewOAuthHandler creates a new OAuth handler.
 func NewOAuthHandler(config *OAuthConfig, store Store) *OAuthHandler {
 	return &OAuthHandler{
-		config: config,
-		store:  store,
+		config:      config,
+		store:       store,
+		peopleMeURL: webexPeopleMeURL,
 	}
 }
 
@@ -323,11 +326,24 @@ func (oh *OAuthHandler) handleAuthCodeExchange(w http.ResponseWriter, r *http.Re
 		log.Printf("[OAuth] /token auth_code: PKCE verification passed (method=%s)", record.CodeChallengeMethod)
 	}
 
+	// Bind the token to the Webex user and client so the user can manage their sessions.
+	// A failed lookup still issues the token; it just won't be listed.
+	owner := TokenOwner{ClientID: record.ClientID}
+	if client, ok := oh.store.LookupClient(record.ClientID); ok {
+		owner.ClientName = client.ClientName
+	}
+	if userID, err := oh.lookupWebexUserID(record.WebexAccessToken); err != nil {
+		log.Printf("[OAuth] /token auth_code: could not resolve Webex user, session will not be listed: %v", err)
+	} else {
+		owner.UserID = userID
+	}
+
 	// Store the Webex tokens and issue our opaque token
 	opaqueToken, err := oh.store.StoreToken(
 		record.WebexAccessToken,
 		record.WebexRefreshToken,
 		record.WebexExpiresIn,
+		owner,
 	)
 	if err != nil {
 		writeJSONError(w, http.StatusInternalServerError, "server_error", "Failed to store token")
@@ -424,6 +440,40 @@ func (oh *OAuthHandler) exchangeWebexCode(code, codeVerifier string) (*WebexToke
 	return &tokenResp, nil
 }
 
+// lookupWebexUserID returns the Webex person ID that owns the access token.
+func (oh *OAuthHandler) lookupWebexUserID(accessToken string) (string, error) {
+	req, err := http.NewRequest(http.MethodGet, oh.peopleMeURL, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("HTTP request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("Webex people/me failed (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	var me struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(body, &me); err != nil {
+		return "", fmt.Errorf("failed to parse people/me response: %w", err)
+	}
+	if me.ID == "" {
+		return "", fmt.Errorf("no user ID in people/me response")
+	}
+	return me.ID, nil
+}
+
 // refreshWebexToken uses a Webex refresh token to get new access/refresh tokens.
 func (oh *OAuthHandler) refreshWebexToken(refreshToken string) (*WebexTokenResponse, error) {
 	data := url.Values{
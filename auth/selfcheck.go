@@ -0,0 +1,291 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// selfCheckProbeCode is the deliberately invalid authorization code sent to the
+// Webex token endpoint to test the client credentials without a real login.
+const selfCheckProbeCode = "webex-mcp-oauth-self-check"
+
+// selfCheckRecheckInterval limits how often /debug/oauth-config?recheck=true may
+// re-run the checks, since each run makes outbound requests.
+const selfCheckRecheckInterval = time.Minute
+
+// Self-check statuses.
+const (
+	CheckOK      = "ok"
+	CheckWarn    = "warn"
+	CheckFail    = "fail"
+	CheckSkipped = "skipped"
+)
+
+// OAuthCheck is the outcome of a single OAuth configuration check.
+type OAuthCheck struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Detail string `json:"detail"`
+}
+
+// OAuthSelfCheckReport is the result of a full OAuth configuration self-check.
+type OAuthSelfCheckReport struct {
+	OK        bool         `json:"ok"`
+	Checks    []OAuthCheck `json:"checks"`
+	CheckedAt time.Time    `json:"checked_at"`
+}
+
+// Failures returns a one-line summary of the failed checks.
+func (r *OAuthSelfCheckReport) Failures() string {
+	var parts []string
+	for _, c := range r.Checks {
+		if c.Status == CheckFail {
+			parts = append(parts, fmt.Sprintf("%s: %s", c.Name, c.Detail))
+		}
+	}
+	return strings.Join(parts, "; ")
+}
+
+// OAuthSelfChecker validates the OAuth configuration against Webex and this server,
+// and remembers the last report for the /debug/oauth-config endpoint.
+type OAuthSelfChecker struct {
+	config         *OAuthConfig
+	httpClient     *http.Client
+	tokenURL       string
+	strictRedirect bool // an unreachable redirect URI fails instead of warns
+
+	mu          sync.RWMutex
+	last        *OAuthSelfCheckReport
+	lastRecheck time.Time
+}
+
+// NewOAuthSelfChecker creates a self-checker for the given OAuth configuration.
+func NewOAuthSelfChecker(config *OAuthConfig) *OAuthSelfChecker {
+	return &OAuthSelfChecker{
+		config:     config,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		tokenURL:   webexAccessTokenURL,
+	}
+}
+
+// SetStrictRedirect makes an unreachable redirect URI a failure rather than a
+// warning. By default it only warns, because the redirect URI is often reachable
+// from users' browsers but not from the server host itself.
+func (sc *OAuthSelfChecker) SetStrictRedirect(strict bool) {
+	sc.strictRedirect = strict
+}
+
+// Run performs all checks, logs each outcome, and stores the report.
+// The redirect URI probe expects this server to already be accepting connections.
+func (sc *OAuthSelfChecker) Run(ctx context.Context) *OAuthSelfCheckReport {
+	report := &OAuthSelfCheckReport{OK: true, CheckedAt: time.Now()}
+
+	report.Checks = append(report.Checks, sc.checkRequired())
+	report.Checks = append(report.Checks, sc.checkRedirectURI(ctx)...)
+	report.Checks = append(report.Checks, sc.checkCredentials(ctx))
+
+	for _, c := range report.Checks {
+		if c.Status == CheckFail {
+			report.OK = false
+		}
+		log.Printf("[OAuth] self-check %-20s %-7s %s", c.Name, c.Status, c.Detail)
+	}
+
+	sc.mu.Lock()
+	sc.last = report
+	sc.mu.Unlock()
+	return report
+}
+
+// LastReport returns the most recent self-check report, or nil if none has run.
+func (sc *OAuthSelfChecker) LastReport() *OAuthSelfCheckReport {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.last
+}
+
+// checkRequired verifies the settings needed for the login flow are present.
+func (sc *OAuthSelfChecker) checkRequired() OAuthCheck {
+	var missing []string
+	if sc.config.ClientID == "" {
+		missing = append(missing, "client-id")
+	}
+	if sc.config.ClientSecret == "" {
+		missing = append(missing, "client-secret")
+	}
+	if sc.config.RedirectURI == "" {
+		missing = append(missing, "redirect-uri")
+	}
+	if sc.config.Scopes == "" {
+		missing = append(missing, "oauth-scopes")
+	}
+	if len(missing) > 0 {
+		return OAuthCheck{Name: "required_settings", Status: CheckFail, Detail: "missing " + strings.Join(missing, ", ")}
+	}
+	return OAuthCheck{Name: "required_settings", Status: CheckOK, Detail: "client id, secret, redirect URI and scopes are set"}
+}
+
+// checkRedirectURI verifies the redirect URI is well-formed, points at this server's
+// /callback handler, and answers HTTP requests.
+func (sc *OAuthSelfChecker) checkRedirectURI(ctx context.Context) []OAuthCheck {
+	if sc.config.RedirectURI == "" {
+		return []OAuthCheck{{Name: "redirect_uri", Status: CheckSkipped, Detail: "no redirect URI configured"}}
+	}
+
+	u, err := url.Parse(sc.config.RedirectURI)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return []OAuthCheck{{Name: "redirect_uri", Status: CheckFail, Detail: fmt.Sprintf("%q is not an absolute http(s) URL", sc.config.RedirectURI)}}
+	}
+
+	var checks []OAuthCheck
+	expected := strings.TrimSuffix(sc.config.ServerURL, "/") + "/callback"
+	if sc.config.RedirectURI == expected {
+		checks = append(checks, OAuthCheck{Name: "redirect_uri", Status: CheckOK, Detail: "matches " + expected})
+	} else if !strings.HasSuffix(u.Path, "/callback") {
+		checks = append(checks, OAuthCheck{Name: "redirect_uri", Status: CheckWarn, Detail: fmt.Sprintf("path %q is not /callback; Webex will redirect users to a URL this server does not handle unless a proxy rewrites it (expected %s)", u.Path, expected)})
+	} else {
+		checks = append(checks, OAuthCheck{Name: "redirect_uri", Status: CheckWarn, Detail: fmt.Sprintf("differs from %s; fine behind a proxy, otherwise check --server-url", expected)})
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, sc.config.RedirectURI, nil)
+	if err != nil {
+		return append(checks, OAuthCheck{Name: "redirect_reachable", Status: CheckFail, Detail: err.Error()})
+	}
+	resp, err := sc.httpClient.Do(req)
+	if err != nil {
+		status := CheckWarn
+		if sc.strictRedirect {
+			status = CheckFail
+		}
+		return append(checks, OAuthCheck{Name: "redirect_reachable", Status: status, Detail: fmt.Sprintf("redirect URI is not reachable from this host: %v", err)})
+	}
+	resp.Body.Close()
+	return append(checks, OAuthCheck{Name: "redirect_reachable", Status: CheckOK, Detail: fmt.Sprintf("responded with HTTP %d", resp.StatusCode)})
+}
+
+// checkCredentials sends a token exchange with a dummy code. Webex rejects the code
+// either way, but the error tells us whether the client id/secret were accepted.
+func (sc *OAuthSelfChecker) checkCredentials(ctx context.Context) OAuthCheck {
+	if sc.config.ClientID == "" || sc.config.ClientSecret == "" {
+		return OAuthCheck{Name: "client_credentials", Status: CheckSkipped, Detail: "client id or secret not configured"}
+	}
+
+	data := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {selfCheckProbeCode},
+		"redirect_uri":  {sc.config.RedirectURI},
+		"client_id":     {sc.config.ClientID},
+		"client_secret": {sc.config.ClientSecret},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sc.tokenURL, strings.NewReader(data.Encode()))
+	if err != nil {
+		return OAuthCheck{Name: "client_credentials", Status: CheckFail, Detail: err.Error()}
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := sc.httpClient.Do(req)
+	if err != nil {
+		return OAuthCheck{Name: "client_credentials", Status: CheckFail, Detail: fmt.Sprintf("could not reach Webex token endpoint: %v", err)}
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+
+	return interpretCredentialProbe(resp.StatusCode, body)
+}
+
+// interpretCredentialProbe classifies the token endpoint's answer to the dummy code.
+func interpretCredentialProbe(status int, body []byte) OAuthCheck {
+	var parsed struct {
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+		Message          string `json:"message"`
+	}
+	_ = json.Unmarshal(body, &parsed)
+	reason := strings.TrimSpace(strings.Join([]string{parsed.Error, parsed.ErrorDescription, parsed.Message}, " "))
+	if reason == "" {
+		reason = strings.TrimSpace(string(body))
+	}
+	lower := strings.ToLower(reason)
+
+	switch {
+	case status == http.StatusOK:
+		return OAuthCheck{Name: "client_credentials", Status: CheckOK, Detail: "token endpoint accepted the request"}
+	case status == http.StatusUnauthorized || strings.Contains(lower, "invalid_client") || strings.Contains(lower, "unauthorized_client"):
+		return OAuthCheck{Name: "client_credentials", Status: CheckFail, Detail: fmt.Sprintf("Webex rejected the client id/secret (HTTP %d): %s", status, reason)}
+	case strings.Contains(lower, "redirect_uri") || strings.Contains(lower, "redirect uri"):
+		return OAuthCheck{Name: "client_credentials", Status: CheckFail, Detail: fmt.Sprintf("Webex rejected the redirect URI; it must exactly match one registered on the integration (HTTP %d): %s", status, reason)}
+	case status >= 500:
+		return OAuthCheck{Name: "client_credentials", Status: CheckWarn, Detail: fmt.Sprintf("Webex token endpoint returned HTTP %d; could not verify credentials", status)}
+	default:
+		return OAuthCheck{Name: "client_credentials", Status: CheckOK, Detail: fmt.Sprintf("credentials accepted (probe code rejected as expected, HTTP %d)", status)}
+	}
+}
+
+// HandleDebugConfig serves GET /debug/oauth-config with the effective, redacted
+// OAuth configuration and the last self-check report. Pass ?recheck=true to re-run
+// the checks, at most once per selfCheckRecheckInterval across all callers.
+func (sc *OAuthSelfChecker) HandleDebugConfig(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	report := sc.LastReport()
+	if r.URL.Query().Get("recheck") == "true" {
+		if wait := sc.reserveRecheck(time.Now()); wait > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int(wait.Seconds())+1))
+			http.Error(w, fmt.Sprintf("The self-check can be re-run once per %s; try again in %s", selfCheckRecheckInterval, wait.Round(time.Second)), http.StatusTooManyRequests)
+			return
+		}
+		report = sc.Run(r.Context())
+	}
+
+	resp := map[string]interface{}{
+		"client_id":             redactSecret(sc.config.ClientID, 6),
+		"client_secret":         redactSecret(sc.config.ClientSecret, 0),
+		"scopes":                sc.config.Scopes,
+		"redirect_uri":          sc.config.RedirectURI,
+		"server_url":            sc.config.ServerURL,
+		"expected_redirect_uri": strings.TrimSuffix(sc.config.ServerURL, "/") + "/callback",
+		"webex_authorize_url":   webexAuthorizeURL,
+		"webex_token_url":       sc.tokenURL,
+		"self_check":            report,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// reserveRecheck claims the next recheck slot at now. It returns how long to wait
+// if a recheck already ran within selfCheckRecheckInterval, or 0 if the caller may run one.
+func (sc *OAuthSelfChecker) reserveRecheck(now time.Time) time.Duration {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	if elapsed := now.Sub(sc.lastRecheck); !sc.lastRecheck.IsZero() && elapsed < selfCheckRecheckInterval {
+		return selfCheckRecheckInterval - elapsed
+	}
+	sc.lastRecheck = now
+	return 0
+}
+
+// redactSecret keeps at most the first visible characters of a value and masks the rest.
+// Empty values are reported as "(not set)".
+func redactSecret(value string, visible int) string {
+	if value == "" {
+		return "(not set)"
+	}
+	if visible <= 0 || len(value) <= visible*2 {
+		return "[redacted]"
+	}
+	return value[:visible] + "...[redacted]"
+}
@@ -0,0 +1,156 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOAuthSelfChecker_Run(t *testing.T) {
+	webex := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("parse form: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if r.PostForm.Get("client_secret") != "good-secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error":"invalid_client","error_description":"bad client credentials"}`))
+			return
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":"invalid_grant","error_description":"code is invalid"}`))
+	}))
+	defer webex.Close()
+
+	self := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Missing code or state parameter", http.StatusBadRequest)
+	}))
+	defer self.Close()
+
+	tests := []struct {
+		name        string
+		secret      string
+		redirectURI string
+		strict      bool
+		wantOK      bool
+		wantFailure string
+	}{
+		{"valid config", "good-secret", self.URL + "/callback", false, true, ""},
+		{"bad secret", "wrong-secret", self.URL + "/callback", false, false, "client_credentials"},
+		{"unreachable redirect warns", "good-secret", "http://127.0.0.1:1/callback", false, true, ""},
+		{"unreachable redirect, strict", "good-secret", "http://127.0.0.1:1/callback", true, false, "redirect_reachable"},
+		{"relative redirect", "good-secret", "/callback", false, false, "redirect_uri"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sc := NewOAuthSelfChecker(&OAuthConfig{
+				ClientID:     "client-123",
+				ClientSecret: tt.secret,
+				Scopes:       "spark:all",
+				RedirectURI:  tt.redirectURI,
+				ServerURL:    self.URL,
+			})
+			sc.tokenURL = webex.URL
+			sc.SetStrictRedirect(tt.strict)
+
+			report := sc.Run(context.Background())
+			if report.OK != tt.wantOK {
+				t.Errorf("OK = %v, want %v (checks: %+v)", report.OK, tt.wantOK, report.Checks)
+			}
+			if tt.wantFailure != "" && !strings.Contains(report.Failures(), tt.wantFailure) {
+				t.Errorf("Failures() = %q, want it to mention %q", report.Failures(), tt.wantFailure)
+			}
+			if sc.LastReport() != report {
+				t.Error("LastReport() did not return the latest report")
+			}
+		})
+	}
+}
+
+func TestInterpretCredentialProbe(t *testing.T) {
+	tests := []struct {
+		status int
+		body   string
+		want   string
+	}{
+		{http.StatusBadRequest, `{"error":"invalid_grant"}`, CheckOK},
+		{http.StatusUnauthorized, `{"message":"Unauthorized"}`, CheckFail},
+		{http.StatusBadRequest, `{"error":"invalid_client"}`, CheckFail},
+		{http.StatusBadRequest, `{"error":"invalid_request","error_description":"redirect_uri mismatch"}`, CheckFail},
+		{http.StatusBadGateway, `upstream error`, CheckWarn},
+	}
+	for _, tt := range tests {
+		if got := interpretCredentialProbe(tt.status, []byte(tt.body)); got.Status != tt.want {
+			t.Errorf("interpretCredentialProbe(%d, %s) = %s (%s), want %s", tt.status, tt.body, got.Status, got.Detail, tt.want)
+		}
+	}
+}
+
+func TestHandleDebugConfig_Redacts(t *testing.T) {
+	sc := NewOAuthSelfChecker(&OAuthConfig{
+		ClientID:     "C0123456789abcdef",
+		ClientSecret: "super-secret-value",
+		Scopes:       "spark:all",
+		RedirectURI:  "https://mcp.example.com/callback",
+		ServerURL:    "https://mcp.example.com",
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/debug/oauth-config", nil)
+	rec := httptest.NewRecorder()
+	sc.HandleDebugConfig(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	body := rec.Body.String()
+	if strings.Contains(body, "super-secret-value") || strings.Contains(body, "C0123456789abcdef") {
+		t.Errorf("response leaks credentials: %s", body)
+	}
+
+	var resp map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode JSON: %v", err)
+	}
+	if resp["client_id"] != "C01234...[redacted]" {
+		t.Errorf("client_id = %v", resp["client_id"])
+	}
+	if resp["expected_redirect_uri"] != "https://mcp.example.com/callback" {
+		t.Errorf("expected_redirect_uri = %v", resp["expected_redirect_uri"])
+	}
+	if resp["self_check"] != nil {
+		t.Errorf("self_check = %v, want null before any run", resp["self_check"])
+	}
+}
+
+func TestHandleDebugConfig_RecheckRateLimited(t *testing.T) {
+	sc := NewOAuthSelfChecker(&OAuthConfig{ServerURL: "https://mcp.example.com"})
+
+	recheck := func() *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		sc.HandleDebugConfig(rec, httptest.NewRequest(http.MethodGet, "/debug/oauth-config?recheck=true", nil))
+		return rec
+	}
+	if rec := recheck(); rec.Code != http.StatusOK || sc.LastReport() == nil {
+		t.Fatalf("first recheck: status = %d, report = %v", rec.Code, sc.LastReport())
+	}
+	rec := recheck()
+	if rec.Code != http.StatusTooManyRequests || rec.Header().Get("Retry-After") == "" {
+		t.Errorf("second recheck: status = %d, Retry-After = %q; want 429 with Retry-After", rec.Code, rec.Header().Get("Retry-After"))
+	}
+
+	// Plain reads are not limited
+	plain := httptest.NewRecorder()
+	sc.HandleDebugConfig(plain, httptest.NewRequest(http.MethodGet, "/debug/oauth-config", nil))
+	if plain.Code != http.StatusOK {
+		t.Errorf("read after recheck: status = %d, want 200", plain.Code)
+	}
+
+	if wait := sc.reserveRecheck(time.Now().Add(selfCheckRecheckInterval)); wait != 0 {
+		t.Errorf("reserveRecheck after the interval = %s, want 0", wait)
+	}
+}
@@ -0,0 +1,137 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+)
+
+// ErrSessionUnbound is returned when the caller's token was issued without a Webex
+// user binding (e.g. before session tracking existed), so its sessions cannot be found.
+var ErrSessionUnbound = errors.New("this token is not bound to a Webex user; re-authorize the MCP client to manage sessions")
+
+// Session is the user-facing view of an issued opaque token. The token itself is
+// never exposed; sessions are addressed by an ID derived from its hash.
+type Session struct {
+	ID         string    `json:"id"`
+	ClientID   string    `json:"client_id,omitempty"`
+	ClientName string    `json:"client_name,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	Current    bool      `json:"current"`
+}
+
+// SessionID returns the public identifier of an opaque token.
+func SessionID(opaqueToken string) string {
+	return tokenHash(opaqueToken)[:16]
+}
+
+// SessionManager lists and revokes the opaque tokens issued to a Webex user.
+type SessionManager struct {
+	store Store
+}
+
+// NewSessionManager creates a SessionManager backed by the given store.
+func NewSessionManager(store Store) *SessionManager {
+	return &SessionManager{store: store}
+}
+
+// List returns the sessions of the user that owns current, oldest first, marking
+// current itself.
+func (sm *SessionManager) List(current *TokenRecord) ([]Session, error) {
+	if current.UserID == "" {
+		return nil, ErrSessionUnbound
+	}
+	records, err := sm.store.ListTokensByUser(current.UserID)
+	if err != nil {
+		return nil, err
+	}
+	sessions := make([]Session, 0, len(records))
+	for _, r := range records {
+		sessions = append(sessions, Session{
+			ID:         SessionID(r.OpaqueToken),
+			ClientID:   r.ClientID,
+			ClientName: r.ClientName,
+			CreatedAt:  r.CreatedAt,
+			ExpiresAt:  r.ExpiresAt,
+			Current:    r.OpaqueToken == current.OpaqueToken,
+		})
+	}
+	return sessions, nil
+}
+
+// Revoke revokes one of the current user's sessions by ID. It returns false if the
+// user has no session with that ID, so users can never revoke each other's tokens.
+func (sm *SessionManager) Revoke(current *TokenRecord, sessionID string) (bool, error) {
+	if current.UserID == "" {
+		return false, ErrSessionUnbound
+	}
+	records, err := sm.store.ListTokensByUser(current.UserID)
+	if err != nil {
+		return false, err
+	}
+	for _, r := range records {
+		if SessionID(r.OpaqueToken) == sessionID {
+			sm.store.RevokeToken(r.OpaqueToken)
+			log.Printf("[Sessions] revoked session %s (client_id=%s)", sessionID, r.ClientID)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// HandleSessions serves /sessions for the authenticated caller:
+// GET lists their sessions and DELETE ?id=<session id> revokes one.
+// It must be wrapped by AuthMiddleware.
+func (sm *SessionManager) HandleSessions(w http.ResponseWriter, r *http.Request) {
+	current, ok := TokenRecordFromContext(r.Context())
+	if !ok {
+		writeJSONError(w, http.StatusForbidden, "invalid_request", "Sessions require an OAuth Bearer token")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		sessions, err := sm.List(current)
+		if err != nil {
+			writeSessionError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		json.NewEncoder(w).Encode(map[string]interface{}{"sessions": sessions})
+
+	case http.MethodDelete:
+		id := r.URL.Query().Get("id")
+		if id == "" {
+			writeJSONError(w, http.StatusBadRequest, "invalid_request", "id query parameter is required")
+			return
+		}
+		revoked, err := sm.Revoke(current, id)
+		if err != nil {
+			writeSessionError(w, err)
+			return
+		}
+		if !revoked {
+			writeJSONError(w, http.StatusNotFound, "not_found", "No session with that id")
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		w.Header().Set("Allow", "GET, DELETE")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// writeSessionError maps a SessionManager error to an HTTP response.
+func writeSessionError(w http.ResponseWriter, err error) {
+	if errors.Is(err, ErrSessionUnbound) {
+		writeJSONError(w, http.StatusConflict, "unbound_token", err.Error())
+		return
+	}
+	log.Printf("[Sessions] store error: %v", err)
+	writeJSONError(w, http.StatusInternalServerError, "server_error", "Failed to read sessions")
+}
@@ -0,0 +1,100 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSessionManager(t *testing.T) {
+	store := NewMemoryStore(time.Minute)
+	defer store.Close()
+	sm := NewSessionManager(store)
+
+	mine, _ := store.StoreToken("at-1", "rt-1", 3600, TokenOwner{UserID: "alice", ClientID: "c1", ClientName: "Desktop"})
+	other, _ := store.StoreToken("at-2", "rt-2", 3600, TokenOwner{UserID: "alice", ClientID: "c2", ClientName: "CLI"})
+	bobs, _ := store.StoreToken("at-3", "rt-3", 3600, TokenOwner{UserID: "bob"})
+	current, _ := store.LookupToken(mine)
+
+	sessions, err := sm.List(current)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(sessions) != 2 {
+		t.Fatalf("List returned %d sessions, want 2", len(sessions))
+	}
+	for _, sess := range sessions {
+		if sess.Current != (sess.ID == SessionID(mine)) {
+			t.Errorf("session %s (%s) Current = %v", sess.ID, sess.ClientName, sess.Current)
+		}
+	}
+
+	if revoked, err := sm.Revoke(current, SessionID(bobs)); err != nil || revoked {
+		t.Errorf("Revoke(bob's session) = %v, %v; want false, nil", revoked, err)
+	}
+	if _, ok := store.LookupToken(bobs); !ok {
+		t.Error("another user's token was revoked")
+	}
+
+	if revoked, err := sm.Revoke(current, SessionID(other)); err != nil || !revoked {
+		t.Errorf("Revoke(own session) = %v, %v; want true, nil", revoked, err)
+	}
+	if _, ok := store.LookupToken(other); ok {
+		t.Error("revoked token is still valid")
+	}
+
+	if _, err := sm.List(&TokenRecord{OpaqueToken: "legacy"}); !errors.Is(err, ErrSessionUnbound) {
+		t.Errorf("List(unbound) error = %v, want ErrSessionUnbound", err)
+	}
+}
+
+func TestHandleSessions(t *testing.T) {
+	store := NewMemoryStore(time.Minute)
+	defer store.Close()
+	sm := NewSessionManager(store)
+
+	mine, _ := store.StoreToken("at-1", "rt-1", 3600, TokenOwner{UserID: "alice", ClientName: "Desktop"})
+	other, _ := store.StoreToken("at-2", "rt-2", 3600, TokenOwner{UserID: "alice", ClientName: "CLI"})
+	current, _ := store.LookupToken(mine)
+
+	serve := func(method, target string, withRecord bool) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, nil)
+		if withRecord {
+			req = req.WithContext(ContextWithTokenRecord(req.Context(), current))
+		}
+		rec := httptest.NewRecorder()
+		sm.HandleSessions(rec, req)
+		return rec
+	}
+
+	if rec := serve(http.MethodGet, "/sessions", false); rec.Code != http.StatusForbidden {
+		t.Errorf("GET without a token record: status = %d, want 403", rec.Code)
+	}
+
+	rec := serve(http.MethodGet, "/sessions", true)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET: status = %d, want 200", rec.Code)
+	}
+	var body struct {
+		Sessions []Session `json:"sessions"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode JSON: %v", err)
+	}
+	if len(body.Sessions) != 2 {
+		t.Errorf("GET returned %d sessions, want 2", len(body.Sessions))
+	}
+
+	if rec := serve(http.MethodDelete, "/sessions?id=unknown", true); rec.Code != http.StatusNotFound {
+		t.Errorf("DELETE unknown: status = %d, want 404", rec.Code)
+	}
+	if rec := serve(http.MethodDelete, "/sessions?id="+SessionID(other), true); rec.Code != http.StatusNoContent {
+		t.Errorf("DELETE: status = %d, want 204", rec.Code)
+	}
+	if _, ok := store.LookupToken(other); ok {
+		t.Error("DELETE did not revoke the token")
+	}
+}
@@ -1,9 +1,12 @@
 package auth
 
 import (
+	"database/sql"
 	"encoding/json"
 	"fmt"
 	"log"
+	"net/url"
+	"strings"
 	"time"
 )
 
@@ -13,12 +16,15 @@ import (
 type Store interface {
 	// --- Token records ---
 
-	// StoreToken persists a new token record and returns the generated opaque token.
-	StoreToken(webexAccessToken, webexRefreshToken string, expiresIn int) (string, error)
+	// StoreToken persists a new token record bound to owner and returns the generated opaque token.
+	StoreToken(webexAccessToken, webexRefreshToken string, expiresIn int, owner TokenOwner) (string, error)
 
 	// LookupToken retrieves a token record by opaque token.
 	LookupToken(opaqueToken string) (*TokenRecord, bool)
 
+	// ListTokensByUser returns the token records bound to a Webex user, oldest first.
+	ListTokensByUser(userID string) ([]*TokenRecord, error)
+
 	// UpdateWebexToken updates the Webex tokens for an existing opaque token (after refresh).
 	UpdateWebexToken(opaqueToken, newAccessToken, newRefreshToken string, expiresIn int) error
 
@@ -55,12 +61,42 @@ type Store interface {
 	// ValidateRedirectURI checks if the given redirect_uri is allowed for the client.
 	ValidateRedirectURI(clientID, redirectURI string) bool
 
+	// --- Backup ---
+
+	// ListTokens returns every token record, oldest first.
+	ListTokens() ([]*TokenRecord, error)
+
+	// ImportToken creates or replaces a token record as given, keeping its opaque token.
+	ImportToken(record *TokenRecord) error
+
+	// ListClients returns every registered client, oldest first.
+	ListClients() ([]*RegisteredClient, error)
+
+	// ImportClient creates or replaces a client as given, keeping its client_id and secret.
+	ImportClient(client *RegisteredClient) error
+
+	// --- User preferences ---
+
+	PreferenceStore
+
 	// --- Lifecycle ---
 
 	// Close releases any resources held by the store (DB connections, etc.).
 	Close() error
 }
 
+// PreferenceStore persists per-user key/value preferences, namespaced by Webex user ID.
+type PreferenceStore interface {
+	// GetPreferences returns all preferences stored for a user (empty map if none).
+	GetPreferences(userID string) (map[string]string, error)
+
+	// SetPreference creates or replaces a single preference for a user.
+	SetPreference(userID, key, value string) error
+
+	// DeletePreference removes a single preference for a user. Deleting a missing key is not an error.
+	DeletePreference(userID, key string) error
+}
+
 // StoreConfig holds configuration for creating a Store.
 type StoreConfig struct {
 	// Type is the store backend: "memory", "sqlite", or "postgres".
@@ -73,6 +109,67 @@ type StoreConfig struct {
 
 	// CleanupInterval is how often expired entries are purged.
 	CleanupInterval time.Duration
+
+	// Pool tunes the connection pool of the sqlite/postgres backends.
+	Pool PoolConfig
+}
+
+// PoolConfig tunes the database/sql connection pool of the SQL-backed stores.
+// Zero values select the backend's defaults.
+type PoolConfig struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+
+	// BusyTimeout is how long SQLite waits for a locked database before
+	// returning "database is locked". Ignored by Postgres.
+	BusyTimeout time.Duration
+}
+
+// Default pools. The OAuth workload is many short queries, so Postgres keeps a
+// modest pool of warm connections and recycles them to follow failovers. SQLite
+// allows one writer at a time, so a small pool plus a busy timeout avoids lock errors.
+var (
+	defaultPostgresPool = PoolConfig{MaxOpenConns: 25, MaxIdleConns: 10, ConnMaxLifetime: 30 * time.Minute}
+	defaultSQLitePool   = PoolConfig{MaxOpenConns: 8, MaxIdleConns: 4, BusyTimeout: 5 * time.Second}
+)
+
+// withDefaults returns p with zero fields taken from defaults.
+func (p PoolConfig) withDefaults(defaults PoolConfig) PoolConfig {
+	if p.MaxOpenConns == 0 {
+		p.MaxOpenConns = defaults.MaxOpenConns
+	}
+	if p.MaxIdleConns == 0 {
+		p.MaxIdleConns = defaults.MaxIdleConns
+	}
+	if p.ConnMaxLifetime == 0 {
+		p.ConnMaxLifetime = defaults.ConnMaxLifetime
+	}
+	if p.BusyTimeout == 0 {
+		p.BusyTimeout = defaults.BusyTimeout
+	}
+	return p
+}
+
+// apply configures the pool limits on db.
+func (p PoolConfig) apply(db *sql.DB) {
+	db.SetMaxOpenConns(p.MaxOpenConns)
+	db.SetMaxIdleConns(p.MaxIdleConns)
+	db.SetConnMaxLifetime(p.ConnMaxLifetime)
+}
+
+// sqliteDSN adds the busy timeout to a SQLite DSN as a driver parameter, so it applies
+// to every pooled connection rather than only the one a PRAGMA happens to run on.
+// An explicit _busy_timeout (or its alias _timeout) already in the DSN wins.
+func sqliteDSN(dsn string, busyTimeout time.Duration) string {
+	if busyTimeout <= 0 || strings.Contains(dsn, "_timeout=") {
+		return dsn
+	}
+	sep := "?"
+	if strings.Contains(dsn, "?") {
+		sep = "&"
+	}
+	return dsn + sep + url.Values{"_busy_timeout": {fmt.Sprint(busyTimeout.Milliseconds())}}.Encode()
 }
 
 // NewStore creates a Store based on the given configuration.
@@ -90,10 +187,10 @@ func NewStore(cfg StoreConfig) (Store, error) {
 		} else {
 			log.Printf("[Store] Using SQLite store at: %s", cfg.DSN)
 		}
-		return NewSQLiteStore(cfg.DSN, cfg.CleanupInterval)
+		return NewSQLiteStore(cfg.DSN, cfg.CleanupInterval, cfg.Pool)
 	case "postgres":
 		log.Printf("[Store] Using PostgreSQL store")
-		return NewPostgresStore(cfg.DSN, cfg.CleanupInterval)
+		return NewPostgresStore(cfg.DSN, cfg.CleanupInterval, cfg.Pool)
 	default:
 		return nil, fmt.Errorf("unknown store type %q: must be 'memory', 'sqlite', or 'postgres'", cfg.Type)
 	}
@@ -158,3 +255,55 @@ func marshalClientJSON(client *RegisteredClient) (redirectURIs, grantTypes, resp
 	rt, _ := json.Marshal(client.ResponseTypes)
 	return string(r), string(g), string(rt)
 }
+
+// clientColumns is the column list read by scanClient, shared by the SQL stores.
+const clientColumns = `client_id, client_secret, redirect_uris, client_name, token_endpoint_auth_method, grant_types, response_types, created_at`
+
+// scanClient reads one row selected with clientColumns.
+func scanClient(row rowScanner) (*RegisteredClient, error) {
+	var c RegisteredClient
+	var clientSecret, clientName sql.NullString
+	var redirectURIsJSON, grantTypesJSON, responseTypesJSON string
+
+	if err := row.Scan(&c.ClientID, &clientSecret, &redirectURIsJSON, &clientName,
+		&c.TokenEndpointAuthMethod, &grantTypesJSON, &responseTypesJSON, &c.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	c.ClientSecret = clientSecret.String
+	c.ClientName = clientName.String
+	json.Unmarshal([]byte(redirectURIsJSON), &c.RedirectURIs)
+	json.Unmarshal([]byte(grantTypesJSON), &c.GrantTypes)
+	json.Unmarshal([]byte(responseTypesJSON), &c.ResponseTypes)
+	return &c, nil
+}
+
+// tokenColumns is the column list read by scanTokenRecord, shared by the SQL stores.
+const tokenColumns = `opaque_token, webex_access_token, webex_refresh_token, expires_at, user_id, client_id, client_name, created_at`
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanTokenRecord reads one row selected with tokenColumns.
+func scanTokenRecord(row rowScanner) (*TokenRecord, error) {
+	var r TokenRecord
+	var userID, clientID, clientName sql.NullString
+	if err := row.Scan(&r.OpaqueToken, &r.WebexAccessToken, &r.WebexRefreshToken, &r.ExpiresAt,
+		&userID, &clientID, &clientName, &r.CreatedAt); err != nil {
+		return nil, err
+	}
+	r.UserID = userID.String
+	r.ClientID = clientID.String
+	r.ClientName = clientName.String
+	return &r, nil
+}
+
+// nullIfEmpty stores empty strings as NULL in nullable columns.
+func nullIfEmpty(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
@@ -2,6 +2,7 @@ package auth
 
 import (
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 )
@@ -14,6 +15,7 @@ type MemoryStore struct {
 	authCodes    map[string]*AuthCodeRecord
 	pendingAuths map[string]*PendingAuth
 	clients      map[string]*RegisteredClient
+	preferences  map[string]map[string]string // userID -> key -> value
 	stopCleanup  chan struct{}
 }
 
@@ -24,6 +26,7 @@ func NewMemoryStore(cleanupInterval time.Duration) *MemoryStore {
 		authCodes:    make(map[string]*AuthCodeRecord),
 		pendingAuths: make(map[string]*PendingAuth),
 		clients:      make(map[string]*RegisteredClient),
+		preferences:  make(map[string]map[string]string),
 		stopCleanup:  make(chan struct{}),
 	}
 	go ms.cleanup(cleanupInterval)
@@ -32,7 +35,7 @@ func NewMemoryStore(cleanupInterval time.Duration) *MemoryStore {
 
 // --- Token records ---
 
-func (ms *MemoryStore) StoreToken(webexAccessToken, webexRefreshToken string, expiresIn int) (string, error) {
+func (ms *MemoryStore) StoreToken(webexAccessToken, webexRefreshToken string, expiresIn int, owner TokenOwner) (string, error) {
 	opaque, err := generateSecureToken(32)
 	if err != nil {
 		return "", fmt.Errorf("failed to generate opaque token: %w", err)
@@ -43,6 +46,9 @@ func (ms *MemoryStore) StoreToken(webexAccessToken, webexRefreshToken string, ex
 		WebexAccessToken:  webexAccessToken,
 		WebexRefreshToken: webexRefreshToken,
 		ExpiresAt:         time.Now().Add(time.Duration(expiresIn) * time.Second),
+		UserID:            owner.UserID,
+		ClientID:          owner.ClientID,
+		ClientName:        owner.ClientName,
 		CreatedAt:         time.Now(),
 	}
 
@@ -63,6 +69,20 @@ func (ms *MemoryStore) LookupToken(opaqueToken string) (*TokenRecord, bool) {
 	return record, true
 }
 
+func (ms *MemoryStore) ListTokensByUser(userID string) ([]*TokenRecord, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	var records []*TokenRecord
+	for _, record := range ms.tokens {
+		if userID != "" && record.UserID == userID {
+			copied := *record
+			records = append(records, &copied)
+		}
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].CreatedAt.Before(records[j].CreatedAt) })
+	return records, nil
+}
+
 func (ms *MemoryStore) UpdateWebexToken(opaqueToken, newAccessToken, newRefreshToken string, expiresIn int) error {
 	ms.mu.Lock()
 	defer ms.mu.Unlock()
@@ -181,6 +201,80 @@ func (ms *MemoryStore) ValidateRedirectURI(clientID, redirectURI string) bool {
 	return matchesRedirectURI(client.RedirectURIs, redirectURI)
 }
 
+// --- Backup ---
+
+func (ms *MemoryStore) ListTokens() ([]*TokenRecord, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	records := make([]*TokenRecord, 0, len(ms.tokens))
+	for _, record := range ms.tokens {
+		copied := *record
+		records = append(records, &copied)
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].CreatedAt.Before(records[j].CreatedAt) })
+	return records, nil
+}
+
+func (ms *MemoryStore) ImportToken(record *TokenRecord) error {
+	copied := *record
+	ms.mu.Lock()
+	ms.tokens[record.OpaqueToken] = &copied
+	ms.mu.Unlock()
+	return nil
+}
+
+func (ms *MemoryStore) ListClients() ([]*RegisteredClient, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	clients := make([]*RegisteredClient, 0, len(ms.clients))
+	for _, client := range ms.clients {
+		copied := *client
+		clients = append(clients, &copied)
+	}
+	sort.Slice(clients, func(i, j int) bool { return clients[i].CreatedAt.Before(clients[j].CreatedAt) })
+	return clients, nil
+}
+
+func (ms *MemoryStore) ImportClient(client *RegisteredClient) error {
+	copied := *client
+	ms.mu.Lock()
+	ms.clients[client.ClientID] = &copied
+	ms.mu.Unlock()
+	return nil
+}
+
+// --- User preferences ---
+
+func (ms *MemoryStore) GetPreferences(userID string) (map[string]string, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	prefs := make(map[string]string, len(ms.preferences[userID]))
+	for k, v := range ms.preferences[userID] {
+		prefs[k] = v
+	}
+	return prefs, nil
+}
+
+func (ms *MemoryStore) SetPreference(userID, key, value string) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	if ms.preferences[userID] == nil {
+		ms.preferences[userID] = make(map[string]string)
+	}
+	ms.preferences[userID][key] = value
+	return nil
+}
+
+func (ms *MemoryStore) DeletePreference(userID, key string) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	delete(ms.preferences[userID], key)
+	if len(ms.preferences[userID]) == 0 {
+		delete(ms.preferences, userID)
+	}
+	return nil
+}
+
 // --- Lifecycle ---
 
 func (ms *MemoryStore) Close() error {
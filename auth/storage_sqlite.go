@@ -3,13 +3,21 @@ package auth
 import (
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"os"
 	"path/filepath"
 	"time"
 
-	_ "github.com/mattn/go-sqlite3"
+	"github.com/mattn/go-sqlite3"
+)
+
+// Retry policy for writes that hit SQLITE_BUSY despite the busy timeout, e.g. when a
+// read transaction cannot be upgraded because another connection wrote first.
+const (
+	sqliteBusyRetries = 5
+	sqliteBusyBackoff = 20 * time.Millisecond
 )
 
 // SQLiteStore implements Store using a SQLite database.
@@ -30,7 +38,8 @@ func DefaultSQLitePath() string {
 // NewSQLiteStore creates a new SQLite-backed store.
 // dsn examples: "/path/to/store.db", ":memory:"
 // If dsn is empty, defaults to ~/mcps/webex-go-mcp/store.db
-func NewSQLiteStore(dsn string, cleanupInterval time.Duration) (*SQLiteStore, error) {
+// Zero fields in pool use defaultSQLitePool.
+func NewSQLiteStore(dsn string, cleanupInterval time.Duration, pool PoolConfig) (*SQLiteStore, error) {
 	if dsn == "" {
 		dsn = DefaultSQLitePath()
 	}
@@ -43,10 +52,17 @@ func NewSQLiteStore(dsn string, cleanupInterval time.Duration) (*SQLiteStore, er
 		}
 	}
 
-	db, err := sql.Open("sqlite3", dsn)
+	pool = pool.withDefaults(defaultSQLitePool)
+	if dsn == ":memory:" {
+		// Every connection to :memory: is a separate database, so keep exactly one forever
+		pool.MaxOpenConns, pool.MaxIdleConns, pool.ConnMaxLifetime = 1, 1, 0
+	}
+
+	db, err := sql.Open("sqlite3", sqliteDSN(dsn, pool.BusyTimeout))
 	if err != nil {
 		return nil, fmt.Errorf("failed to open sqlite: %w", err)
 	}
+	pool.apply(db)
 
 	// Enable WAL mode for better concurrent read performance
 	if _, err := db.Exec("PRAGMA journal_mode=WAL"); err != nil {
@@ -68,60 +84,145 @@ func NewSQLiteStore(dsn string, cleanupInterval time.Duration) (*SQLiteStore, er
 }
 
 func createSQLiteTables(db *sql.DB) error {
-	tables := []string{
-		`CREATE TABLE IF NOT EXISTS tokens (
-			opaque_token TEXT PRIMARY KEY,
-			webex_access_token TEXT NOT NULL,
-			webex_refresh_token TEXT NOT NULL,
-			expires_at DATETIME NOT NULL,
-			user_id TEXT,
-			created_at DATETIME NOT NULL
-		)`,
-		`CREATE TABLE IF NOT EXISTS auth_codes (
-			code TEXT PRIMARY KEY,
-			client_id TEXT NOT NULL,
-			redirect_uri TEXT NOT NULL,
-			code_challenge TEXT,
-			code_challenge_method TEXT,
-			webex_access_token TEXT NOT NULL,
-			webex_refresh_token TEXT NOT NULL,
-			webex_expires_in INTEGER NOT NULL,
-			created_at DATETIME NOT NULL,
-			expires_at DATETIME NOT NULL
-		)`,
-		`CREATE TABLE IF NOT EXISTS pending_auths (
-			state TEXT PRIMARY KEY,
-			client_id TEXT NOT NULL,
-			client_redirect_uri TEXT NOT NULL,
-			client_state TEXT,
-			code_challenge TEXT,
-			code_challenge_method TEXT,
-			webex_code_verifier TEXT NOT NULL,
-			created_at DATETIME NOT NULL
-		)`,
-		`CREATE TABLE IF NOT EXISTS clients (
-			client_id TEXT PRIMARY KEY,
-			client_secret TEXT,
-			redirect_uris TEXT NOT NULL,
-			client_name TEXT,
-			token_endpoint_auth_method TEXT,
-			grant_types TEXT NOT NULL,
-			response_types TEXT NOT NULL,
-			created_at DATETIME NOT NULL
-		)`,
-	}
-
-	for _, ddl := range tables {
-		if _, err := db.Exec(ddl); err != nil {
-			return fmt.Errorf("failed to create table: %w", err)
+	return migrateSchema(db, sqliteDialect, sqliteMigrations)
+}
+
+// sqliteMigrations is the versioned SQLite schema. Append new steps; never edit
+// one that has shipped.
+var sqliteMigrations = []schemaMigration{
+	{
+		version: 1,
+		name:    "initial schema",
+		apply: execStatements(
+			`CREATE TABLE IF NOT EXISTS tokens (
+				opaque_token TEXT PRIMARY KEY,
+				webex_access_token TEXT NOT NULL,
+				webex_refresh_token TEXT NOT NULL,
+				expires_at DATETIME NOT NULL,
+				user_id TEXT,
+				created_at DATETIME NOT NULL
+			)`,
+			`CREATE TABLE IF NOT EXISTS auth_codes (
+				code TEXT PRIMARY KEY,
+				client_id TEXT NOT NULL,
+				redirect_uri TEXT NOT NULL,
+				code_challenge TEXT,
+				code_challenge_method TEXT,
+				webex_access_token TEXT NOT NULL,
+				webex_refresh_token TEXT NOT NULL,
+				webex_expires_in INTEGER NOT NULL,
+				created_at DATETIME NOT NULL,
+				expires_at DATETIME NOT NULL
+			)`,
+			`CREATE TABLE IF NOT EXISTS pending_auths (
+				state TEXT PRIMARY KEY,
+				client_id TEXT NOT NULL,
+				client_redirect_uri TEXT NOT NULL,
+				client_state TEXT,
+				code_challenge TEXT,
+				code_challenge_method TEXT,
+				webex_code_verifier TEXT NOT NULL,
+				created_at DATETIME NOT NULL
+			)`,
+			`CREATE TABLE IF NOT EXISTS clients (
+				client_id TEXT PRIMARY KEY,
+				client_secret TEXT,
+				redirect_uris TEXT NOT NULL,
+				client_name TEXT,
+				token_endpoint_auth_method TEXT,
+				grant_types TEXT NOT NULL,
+				response_types TEXT NOT NULL,
+				created_at DATETIME NOT NULL
+			)`,
+			`CREATE TABLE IF NOT EXISTS user_preferences (
+				user_id TEXT NOT NULL,
+				key TEXT NOT NULL,
+				value TEXT NOT NULL,
+				updated_at DATETIME NOT NULL,
+				PRIMARY KEY (user_id, key)
+			)`,
+		),
+	},
+	{
+		version: 2,
+		name:    "token owner columns",
+		apply: func(tx *sql.Tx) error {
+			for _, column := range []string{"client_id", "client_name"} {
+				if err := addSQLiteColumn(tx, "tokens", column, "TEXT"); err != nil {
+					return err
+				}
+			}
+			_, err := tx.Exec(`CREATE INDEX IF NOT EXISTS idx_tokens_user_id ON tokens (user_id)`)
+			return err
+		},
+	},
+}
+
+// addSQLiteColumn adds a column to an existing table unless it is already present.
+// A table that does not exist yet is left alone for CREATE TABLE to build.
+func addSQLiteColumn(tx *sql.Tx, table, column, decl string) error {
+	rows, err := tx.Query(`SELECT name FROM pragma_table_info(?)`, table)
+	if err != nil {
+		return fmt.Errorf("failed to inspect table %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	found := false
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return fmt.Errorf("failed to inspect table %s: %w", table, err)
 		}
+		if name == column {
+			return nil
+		}
+		found = true
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to inspect table %s: %w", table, err)
+	}
+	if !found {
+		return nil
+	}
+	if _, err := tx.Exec(fmt.Sprintf(`ALTER TABLE %s ADD COLUMN %s %s`, table, column, decl)); err != nil {
+		return fmt.Errorf("failed to add column %s.%s: %w", table, column, err)
 	}
 	return nil
 }
 
+// isSQLiteBusy reports whether err means the database was locked by another connection.
+func isSQLiteBusy(err error) bool {
+	var sqliteErr sqlite3.Error
+	if !errors.As(err, &sqliteErr) {
+		return false
+	}
+	return sqliteErr.Code == sqlite3.ErrBusy || sqliteErr.Code == sqlite3.ErrLocked
+}
+
+// retryBusy runs a write, retrying with a short linear backoff while SQLite reports
+// the database as busy. Any other error is returned immediately.
+func retryBusy(op func() error) error {
+	var err error
+	for attempt := 1; attempt <= sqliteBusyRetries; attempt++ {
+		if err = op(); err == nil || !isSQLiteBusy(err) {
+			return err
+		}
+		time.Sleep(time.Duration(attempt) * sqliteBusyBackoff)
+	}
+	return err
+}
+
+// exec runs a write statement with busy retries.
+func (s *SQLiteStore) exec(query string, args ...interface{}) error {
+	return retryBusy(func() error {
+		_, err := s.db.Exec(query, args...)
+		return err
+	})
+}
+
 // --- Token records ---
 
-func (s *SQLiteStore) StoreToken(webexAccessToken, webexRefreshToken string, expiresIn int) (string, error) {
+func (s *SQLiteStore) StoreToken(webexAccessToken, webexRefreshToken string, expiresIn int, owner TokenOwner) (string, error) {
 	opaque, err := generateSecureToken(32)
 	if err != nil {
 		return "", fmt.Errorf("failed to generate opaque token: %w", err)
@@ -130,10 +231,11 @@ func (s *SQLiteStore) StoreToken(webexAccessToken, webexRefreshToken string, exp
 	now := time.Now()
 	expiresAt := now.Add(time.Duration(expiresIn) * time.Second)
 
-	_, err = s.db.Exec(
-		`INSERT INTO tokens (opaque_token, webex_access_token, webex_refresh_token, expires_at, created_at)
-		 VALUES (?, ?, ?, ?, ?)`,
-		opaque, webexAccessToken, webexRefreshToken, expiresAt, now,
+	err = s.exec(
+		`INSERT INTO tokens (opaque_token, webex_access_token, webex_refresh_token, expires_at, user_id, client_id, client_name, created_at)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		opaque, webexAccessToken, webexRefreshToken, expiresAt,
+		nullIfEmpty(owner.UserID), nullIfEmpty(owner.ClientID), nullIfEmpty(owner.ClientName), now,
 	)
 	if err != nil {
 		return "", fmt.Errorf("failed to store token: %w", err)
@@ -143,24 +245,38 @@ func (s *SQLiteStore) StoreToken(webexAccessToken, webexRefreshToken string, exp
 
 func (s *SQLiteStore) LookupToken(opaqueToken string) (*TokenRecord, bool) {
 	row := s.db.QueryRow(
-		`SELECT opaque_token, webex_access_token, webex_refresh_token, expires_at, user_id, created_at
-		 FROM tokens WHERE opaque_token = ?`, opaqueToken,
+		`SELECT `+tokenColumns+` FROM tokens WHERE opaque_token = ?`, opaqueToken,
 	)
-
-	var r TokenRecord
-	var userID sql.NullString
-	if err := row.Scan(&r.OpaqueToken, &r.WebexAccessToken, &r.WebexRefreshToken, &r.ExpiresAt, &userID, &r.CreatedAt); err != nil {
+	r, err := scanTokenRecord(row)
+	if err != nil {
 		return nil, false
 	}
-	if userID.Valid {
-		r.UserID = userID.String
+	return r, true
+}
+
+func (s *SQLiteStore) ListTokensByUser(userID string) ([]*TokenRecord, error) {
+	rows, err := s.db.Query(
+		`SELECT `+tokenColumns+` FROM tokens WHERE user_id = ? ORDER BY created_at`, userID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tokens: %w", err)
 	}
-	return &r, true
+	defer rows.Close()
+
+	var records []*TokenRecord
+	for rows.Next() {
+		r, err := scanTokenRecord(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan token: %w", err)
+		}
+		records = append(records, r)
+	}
+	return records, rows.Err()
 }
 
 func (s *SQLiteStore) UpdateWebexToken(opaqueToken, newAccessToken, newRefreshToken string, expiresIn int) error {
 	expiresAt := time.Now().Add(time.Duration(expiresIn) * time.Second)
-	_, err := s.db.Exec(
+	err := s.exec(
 		`UPDATE tokens SET webex_access_token = ?, webex_refresh_token = ?, expires_at = ? WHERE opaque_token = ?`,
 		newAccessToken, newRefreshToken, expiresAt, opaqueToken,
 	)
@@ -171,13 +287,15 @@ func (s *SQLiteStore) UpdateWebexToken(opaqueToken, newAccessToken, newRefreshTo
 }
 
 func (s *SQLiteStore) RevokeToken(opaqueToken string) {
-	s.db.Exec(`DELETE FROM tokens WHERE opaque_token = ?`, opaqueToken)
+	if err := s.exec(`DELETE FROM tokens WHERE opaque_token = ?`, opaqueToken); err != nil {
+		log.Printf("[SQLiteStore] failed to revoke token: %v", err)
+	}
 }
 
 // --- Authorization codes ---
 
 func (s *SQLiteStore) StoreAuthCode(record *AuthCodeRecord) error {
-	_, err := s.db.Exec(
+	err := s.exec(
 		`INSERT INTO auth_codes (code, client_id, redirect_uri, code_challenge, code_challenge_method, webex_access_token, webex_refresh_token, webex_expires_in, created_at, expires_at)
 		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 		record.Code, record.ClientID, record.RedirectURI, record.CodeChallenge, record.CodeChallengeMethod,
@@ -191,22 +309,33 @@ func (s *SQLiteStore) StoreAuthCode(record *AuthCodeRecord) error {
 }
 
 func (s *SQLiteStore) ConsumeAuthCode(code string) (*AuthCodeRecord, bool) {
-	tx, err := s.db.Begin()
-	if err != nil {
-		return nil, false
-	}
-	defer tx.Rollback()
-
-	row := tx.QueryRow(
-		`SELECT code, client_id, redirect_uri, code_challenge, code_challenge_method, webex_access_token, webex_refresh_token, webex_expires_in, created_at, expires_at
-		 FROM auth_codes WHERE code = ?`, code,
-	)
-
 	var r AuthCodeRecord
 	var codeChallenge, codeChallengeMethod sql.NullString
-	if err := row.Scan(&r.Code, &r.ClientID, &r.RedirectURI, &codeChallenge, &codeChallengeMethod,
-		&r.WebexAccessToken, &r.WebexRefreshToken, &r.WebexExpiresIn,
-		&r.CreatedAt, &r.ExpiresAt); err != nil {
+	err := retryBusy(func() error {
+		tx, err := s.db.Begin()
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback()
+
+		row := tx.QueryRow(
+			`SELECT code, client_id, redirect_uri, code_challenge, code_challenge_method, webex_access_token, webex_refresh_token, webex_expires_in, created_at, expires_at
+			 FROM auth_codes WHERE code = ?`, code,
+		)
+		if err := row.Scan(&r.Code, &r.ClientID, &r.RedirectURI, &codeChallenge, &codeChallengeMethod,
+			&r.WebexAccessToken, &r.WebexRefreshToken, &r.WebexExpiresIn,
+			&r.CreatedAt, &r.ExpiresAt); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(`DELETE FROM auth_codes WHERE code = ?`, code); err != nil {
+			return err
+		}
+		return tx.Commit()
+	})
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			log.Printf("[SQLiteStore] failed to consume auth code: %v", err)
+		}
 		return nil, false
 	}
 	if codeChallenge.Valid {
@@ -216,9 +345,6 @@ func (s *SQLiteStore) ConsumeAuthCode(code string) (*AuthCodeRecord, bool) {
 		r.CodeChallengeMethod = codeChallengeMethod.String
 	}
 
-	tx.Exec(`DELETE FROM auth_codes WHERE code = ?`, code)
-	tx.Commit()
-
 	if time.Now().After(r.ExpiresAt) {
 		return nil, false
 	}
@@ -228,7 +354,7 @@ func (s *SQLiteStore) ConsumeAuthCode(code string) (*AuthCodeRecord, bool) {
 // --- Pending auth state ---
 
 func (s *SQLiteStore) StorePendingAuth(pending *PendingAuth) error {
-	_, err := s.db.Exec(
+	err := s.exec(
 		`INSERT INTO pending_auths (state, client_id, client_redirect_uri, client_state, code_challenge, code_challenge_method, webex_code_verifier, created_at)
 		 VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
 		pending.State, pending.ClientID, pending.ClientRedirectURI, pending.ClientState,
@@ -241,21 +367,32 @@ func (s *SQLiteStore) StorePendingAuth(pending *PendingAuth) error {
 }
 
 func (s *SQLiteStore) ConsumePendingAuth(state string) (*PendingAuth, bool) {
-	tx, err := s.db.Begin()
-	if err != nil {
-		return nil, false
-	}
-	defer tx.Rollback()
-
-	row := tx.QueryRow(
-		`SELECT state, client_id, client_redirect_uri, client_state, code_challenge, code_challenge_method, webex_code_verifier, created_at
-		 FROM pending_auths WHERE state = ?`, state,
-	)
-
 	var p PendingAuth
 	var clientState, codeChallenge, codeChallengeMethod sql.NullString
-	if err := row.Scan(&p.State, &p.ClientID, &p.ClientRedirectURI, &clientState,
-		&codeChallenge, &codeChallengeMethod, &p.WebexCodeVerifier, &p.CreatedAt); err != nil {
+	err := retryBusy(func() error {
+		tx, err := s.db.Begin()
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback()
+
+		row := tx.QueryRow(
+			`SELECT state, client_id, client_redirect_uri, client_state, code_challenge, code_challenge_method, webex_code_verifier, created_at
+			 FROM pending_auths WHERE state = ?`, state,
+		)
+		if err := row.Scan(&p.State, &p.ClientID, &p.ClientRedirectURI, &clientState,
+			&codeChallenge, &codeChallengeMethod, &p.WebexCodeVerifier, &p.CreatedAt); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(`DELETE FROM pending_auths WHERE state = ?`, state); err != nil {
+			return err
+		}
+		return tx.Commit()
+	})
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			log.Printf("[SQLiteStore] failed to consume pending auth: %v", err)
+		}
 		return nil, false
 	}
 	if clientState.Valid {
@@ -268,9 +405,6 @@ func (s *SQLiteStore) ConsumePendingAuth(state string) (*PendingAuth, bool) {
 		p.CodeChallengeMethod = codeChallengeMethod.String
 	}
 
-	tx.Exec(`DELETE FROM pending_auths WHERE state = ?`, state)
-	tx.Commit()
-
 	if time.Since(p.CreatedAt) > 10*time.Minute {
 		return nil, false
 	}
@@ -287,7 +421,7 @@ func (s *SQLiteStore) RegisterClient(req *RegistrationRequest) (*RegisteredClien
 
 	redirectURIsJSON, grantTypesJSON, responseTypesJSON := marshalClientJSON(client)
 
-	_, err = s.db.Exec(
+	err = s.exec(
 		`INSERT INTO clients (client_id, client_secret, redirect_uris, client_name, token_endpoint_auth_method, grant_types, response_types, created_at)
 		 VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
 		client.ClientID, client.ClientSecret, redirectURIsJSON, client.ClientName,
@@ -308,7 +442,7 @@ func (s *SQLiteStore) RegisterClientWithID(clientID, redirectURI string) error {
 		}
 		existing.RedirectURIs = append(existing.RedirectURIs, redirectURI)
 		redirectURIsJSON, _ := json.Marshal(existing.RedirectURIs)
-		_, err := s.db.Exec(`UPDATE clients SET redirect_uris = ? WHERE client_id = ?`, string(redirectURIsJSON), clientID)
+		err := s.exec(`UPDATE clients SET redirect_uris = ? WHERE client_id = ?`, string(redirectURIsJSON), clientID)
 		if err != nil {
 			return fmt.Errorf("failed to update client redirect URIs: %w", err)
 		}
@@ -319,7 +453,7 @@ func (s *SQLiteStore) RegisterClientWithID(clientID, redirectURI string) error {
 	grantTypes, _ := json.Marshal([]string{"authorization_code"})
 	responseTypes, _ := json.Marshal([]string{"code"})
 
-	_, err := s.db.Exec(
+	err := s.exec(
 		`INSERT OR IGNORE INTO clients (client_id, redirect_uris, token_endpoint_auth_method, grant_types, response_types, created_at)
 		 VALUES (?, ?, ?, ?, ?, ?)`,
 		clientID, string(redirectURIs), "none", string(grantTypes), string(responseTypes), time.Now(),
@@ -331,39 +465,125 @@ func (s *SQLiteStore) RegisterClientWithID(clientID, redirectURI string) error {
 }
 
 func (s *SQLiteStore) LookupClient(clientID string) (*RegisteredClient, bool) {
-	row := s.db.QueryRow(
-		`SELECT client_id, client_secret, redirect_uris, client_name, token_endpoint_auth_method, grant_types, response_types, created_at
-		 FROM clients WHERE client_id = ?`, clientID,
+	row := s.db.QueryRow(`SELECT `+clientColumns+` FROM clients WHERE client_id = ?`, clientID)
+	c, err := scanClient(row)
+	if err != nil {
+		return nil, false
+	}
+	return c, true
+}
+
+func (s *SQLiteStore) ValidateRedirectURI(clientID, redirectURI string) bool {
+	client, ok := s.LookupClient(clientID)
+	if !ok {
+		return false
+	}
+	return matchesRedirectURI(client.RedirectURIs, redirectURI)
+}
+
+// --- Backup ---
+
+func (s *SQLiteStore) ListTokens() ([]*TokenRecord, error) {
+	rows, err := s.db.Query(`SELECT ` + tokenColumns + ` FROM tokens ORDER BY created_at`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tokens: %w", err)
+	}
+	defer rows.Close()
+
+	var records []*TokenRecord
+	for rows.Next() {
+		r, err := scanTokenRecord(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan token: %w", err)
+		}
+		records = append(records, r)
+	}
+	return records, rows.Err()
+}
+
+func (s *SQLiteStore) ImportToken(record *TokenRecord) error {
+	err := s.exec(
+		`INSERT OR REPLACE INTO tokens (`+tokenColumns+`)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		record.OpaqueToken, record.WebexAccessToken, record.WebexRefreshToken, record.ExpiresAt,
+		nullIfEmpty(record.UserID), nullIfEmpty(record.ClientID), nullIfEmpty(record.ClientName), record.CreatedAt,
 	)
+	if err != nil {
+		return fmt.Errorf("failed to import token: %w", err)
+	}
+	return nil
+}
 
-	var c RegisteredClient
-	var clientSecret, clientName sql.NullString
-	var redirectURIsJSON, grantTypesJSON, responseTypesJSON string
+func (s *SQLiteStore) ListClients() ([]*RegisteredClient, error) {
+	rows, err := s.db.Query(`SELECT ` + clientColumns + ` FROM clients ORDER BY created_at`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query clients: %w", err)
+	}
+	defer rows.Close()
 
-	if err := row.Scan(&c.ClientID, &clientSecret, &redirectURIsJSON, &clientName,
-		&c.TokenEndpointAuthMethod, &grantTypesJSON, &responseTypesJSON, &c.CreatedAt); err != nil {
-		return nil, false
+	var clients []*RegisteredClient
+	for rows.Next() {
+		c, err := scanClient(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan client: %w", err)
+		}
+		clients = append(clients, c)
 	}
+	return clients, rows.Err()
+}
 
-	if clientSecret.Valid {
-		c.ClientSecret = clientSecret.String
+func (s *SQLiteStore) ImportClient(client *RegisteredClient) error {
+	redirectURIsJSON, grantTypesJSON, responseTypesJSON := marshalClientJSON(client)
+	err := s.exec(
+		`INSERT OR REPLACE INTO clients (`+clientColumns+`)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		client.ClientID, nullIfEmpty(client.ClientSecret), redirectURIsJSON, nullIfEmpty(client.ClientName),
+		client.TokenEndpointAuthMethod, grantTypesJSON, responseTypesJSON, client.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to import client: %w", err)
 	}
-	if clientName.Valid {
-		c.ClientName = clientName.String
+	return nil
+}
+
+// --- User preferences ---
+
+func (s *SQLiteStore) GetPreferences(userID string) (map[string]string, error) {
+	rows, err := s.db.Query(`SELECT key, value FROM user_preferences WHERE user_id = ?`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query preferences: %w", err)
 	}
-	json.Unmarshal([]byte(redirectURIsJSON), &c.RedirectURIs)
-	json.Unmarshal([]byte(grantTypesJSON), &c.GrantTypes)
-	json.Unmarshal([]byte(responseTypesJSON), &c.ResponseTypes)
+	defer rows.Close()
 
-	return &c, true
+	prefs := make(map[string]string)
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			return nil, fmt.Errorf("failed to scan preference: %w", err)
+		}
+		prefs[key] = value
+	}
+	return prefs, rows.Err()
 }
 
-func (s *SQLiteStore) ValidateRedirectURI(clientID, redirectURI string) bool {
-	client, ok := s.LookupClient(clientID)
-	if !ok {
-		return false
+func (s *SQLiteStore) SetPreference(userID, key, value string) error {
+	err := s.exec(
+		`INSERT INTO user_preferences (user_id, key, value, updated_at)
+		 VALUES (?, ?, ?, ?)
+		 ON CONFLICT (user_id, key) DO UPDATE SET value = excluded.value, updated_at = excluded.updated_at`,
+		userID, key, value, time.Now(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to store preference: %w", err)
 	}
-	return matchesRedirectURI(client.RedirectURIs, redirectURI)
+	return nil
+}
+
+func (s *SQLiteStore) DeletePreference(userID, key string) error {
+	if err := s.exec(`DELETE FROM user_preferences WHERE user_id = ? AND key = ?`, userID, key); err != nil {
+		return fmt.Errorf("failed to delete preference: %w", err)
+	}
+	return nil
 }
 
 // --- Lifecycle ---
@@ -382,10 +602,10 @@ func (s *SQLiteStore) cleanup(interval time.Duration) {
 			return
 		case <-ticker.C:
 			now := time.Now()
-			if _, err := s.db.Exec(`DELETE FROM auth_codes WHERE expires_at < ?`, now); err != nil {
+			if err := s.exec(`DELETE FROM auth_codes WHERE expires_at < ?`, now); err != nil {
 				log.Printf("[SQLiteStore] cleanup: failed to delete expired auth codes: %v", err)
 			}
-			if _, err := s.db.Exec(`DELETE FROM pending_auths WHERE created_at < ?`, now.Add(-10*time.Minute)); err != nil {
+			if err := s.exec(`DELETE FROM pending_auths WHERE created_at < ?`, now.Add(-10*time.Minute)); err != nil {
 				log.Printf("[SQLiteStore] cleanup: failed to delete expired pending auths: %v", err)
 			}
 		}
@@ -1,9 +1,18 @@
 package auth
 
 import (
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 	"time"
+
+	"github.com/mattn/go-sqlite3"
 )
 
 func getTestStores(t *testing.T) map[string]Store {
@@ -11,13 +20,13 @@ func getTestStores(t *testing.T) map[string]Store {
 	stores := map[string]Store{
 		"memory": NewMemoryStore(time.Minute),
 	}
-	sqliteStore, err := NewSQLiteStore(":memory:", time.Minute)
+	sqliteStore, err := NewSQLiteStore(":memory:", time.Minute, PoolConfig{})
 	if err != nil {
 		t.Fatalf("failed to create sqlite store: %v", err)
 	}
 	stores["sqlite"] = sqliteStore
 	if dsn := os.Getenv("POSTGRES_TEST_DSN"); dsn != "" {
-		pgStore, err := NewPostgresStore(dsn, time.Minute)
+		pgStore, err := NewPostgresStore(dsn, time.Minute, PoolConfig{})
 		if err != nil {
 			t.Fatalf("failed to create postgres store: %v", err)
 		}
@@ -31,7 +40,7 @@ func TestStoreTokenLookupToken(t *testing.T) {
 		s := s
 		defer s.Close()
 		t.Run(name+"/StoreToken_LookupToken_lifecycle", func(t *testing.T) {
-			opaque, err := s.StoreToken("webex-at", "webex-rt", 3600)
+			opaque, err := s.StoreToken("webex-at", "webex-rt", 3600, TokenOwner{})
 			if err != nil {
 				t.Fatalf("StoreToken: %v", err)
 			}
@@ -62,12 +71,112 @@ func TestStoreTokenLookupToken(t *testing.T) {
 	}
 }
 
+func TestListTokensByUser(t *testing.T) {
+	for name, s := range getTestStores(t) {
+		s := s
+		defer s.Close()
+		t.Run(name+"/ListTokensByUser", func(t *testing.T) {
+			first, _ := s.StoreToken("at-1", "rt-1", 3600, TokenOwner{UserID: "user-a", ClientID: "c1", ClientName: "Desktop"})
+			time.Sleep(10 * time.Millisecond)
+			second, _ := s.StoreToken("at-2", "rt-2", 3600, TokenOwner{UserID: "user-a", ClientID: "c2"})
+			s.StoreToken("at-3", "rt-3", 3600, TokenOwner{UserID: "user-b"})
+			s.StoreToken("at-4", "rt-4", 3600, TokenOwner{})
+
+			records, err := s.ListTokensByUser("user-a")
+			if err != nil {
+				t.Fatalf("ListTokensByUser: %v", err)
+			}
+			if len(records) != 2 || records[0].OpaqueToken != first || records[1].OpaqueToken != second {
+				t.Fatalf("ListTokensByUser(user-a) = %+v, want [first, second]", records)
+			}
+			if records[0].ClientID != "c1" || records[0].ClientName != "Desktop" || records[0].UserID != "user-a" {
+				t.Errorf("first record owner = %q/%q/%q", records[0].UserID, records[0].ClientID, records[0].ClientName)
+			}
+			if records[1].ClientName != "" {
+				t.Errorf("ClientName = %q, want empty", records[1].ClientName)
+			}
+
+			if record, ok := s.LookupToken(first); !ok || record.ClientName != "Desktop" {
+				t.Errorf("LookupToken did not return the owner: %+v", record)
+			}
+			if records, _ := s.ListTokensByUser(""); len(records) != 0 {
+				t.Errorf("ListTokensByUser(\"\") returned %d unbound tokens, want 0", len(records))
+			}
+		})
+	}
+}
+
+func TestSQLiteAddsTokenOwnerColumns(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "old.db")
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(`CREATE TABLE tokens (
+		opaque_token TEXT PRIMARY KEY,
+		webex_access_token TEXT NOT NULL,
+		webex_refresh_token TEXT NOT NULL,
+		expires_at DATETIME NOT NULL,
+		user_id TEXT,
+		created_at DATETIME NOT NULL
+	)`); err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+
+	s, err := NewSQLiteStore(path, time.Minute, PoolConfig{})
+	if err != nil {
+		t.Fatalf("NewSQLiteStore on an old schema: %v", err)
+	}
+	defer s.Close()
+	opaque, err := s.StoreToken("at", "rt", 3600, TokenOwner{UserID: "u", ClientName: "CLI"})
+	if err != nil {
+		t.Fatalf("StoreToken: %v", err)
+	}
+	if record, ok := s.LookupToken(opaque); !ok || record.ClientName != "CLI" {
+		t.Errorf("LookupToken = %+v, %v", record, ok)
+	}
+}
+
+func TestSQLiteSchemaMigrations(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "store.db")
+	latest := latestSchemaVersion(sqliteMigrations)
+
+	s, err := NewSQLiteStore(path, time.Minute, PoolConfig{})
+	if err != nil {
+		t.Fatalf("NewSQLiteStore: %v", err)
+	}
+	if version, err := schemaVersion(s.db); err != nil || version != latest {
+		t.Errorf("schemaVersion = %d, %v; want %d", version, err, latest)
+	}
+	s.Close()
+
+	// Reopening an up-to-date database applies nothing and records nothing new
+	s, err = NewSQLiteStore(path, time.Minute, PoolConfig{})
+	if err != nil {
+		t.Fatalf("NewSQLiteStore on a migrated database: %v", err)
+	}
+	var rows int
+	if err := s.db.QueryRow(`SELECT COUNT(*) FROM schema_migrations`).Scan(&rows); err != nil || rows != len(sqliteMigrations) {
+		t.Errorf("schema_migrations rows = %d, %v; want %d", rows, err, len(sqliteMigrations))
+	}
+
+	// A database written by a newer server is refused rather than used
+	if _, err := s.db.Exec(`INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, 'future', ?)`, latest+1, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	s.Close()
+	if _, err := NewSQLiteStore(path, time.Minute, PoolConfig{}); err == nil || !strings.Contains(err.Error(), "upgrade webex-go-mcp") {
+		t.Errorf("NewSQLiteStore on a newer schema: err = %v, want version error", err)
+	}
+}
+
 func TestUpdateWebexToken(t *testing.T) {
 	for name, s := range getTestStores(t) {
 		s := s
 		defer s.Close()
 		t.Run(name+"/UpdateWebexToken", func(t *testing.T) {
-			opaque, err := s.StoreToken("old-at", "old-rt", 3600)
+			opaque, err := s.StoreToken("old-at", "old-rt", 3600, TokenOwner{})
 			if err != nil {
 				t.Fatalf("StoreToken: %v", err)
 			}
@@ -96,7 +205,7 @@ func TestRevokeToken(t *testing.T) {
 		s := s
 		defer s.Close()
 		t.Run(name+"/RevokeToken", func(t *testing.T) {
-			opaque, err := s.StoreToken("at", "rt", 3600)
+			opaque, err := s.StoreToken("at", "rt", 3600, TokenOwner{})
 			if err != nil {
 				t.Fatalf("StoreToken: %v", err)
 			}
@@ -365,3 +474,165 @@ func TestValidateRedirectURI(t *testing.T) {
 		})
 	}
 }
+
+func TestPreferences(t *testing.T) {
+	for name, s := range getTestStores(t) {
+		s := s
+		defer s.Close()
+		t.Run(name+"/Preferences", func(t *testing.T) {
+			prefs, err := s.GetPreferences("user-a")
+			if err != nil {
+				t.Fatalf("GetPreferences: %v", err)
+			}
+			if len(prefs) != 0 {
+				t.Fatalf("expected no preferences, got %v", prefs)
+			}
+
+			if err := s.SetPreference("user-a", "timezone", "UTC"); err != nil {
+				t.Fatalf("SetPreference: %v", err)
+			}
+			if err := s.SetPreference("user-a", "timezone", "Europe/London"); err != nil {
+				t.Fatalf("SetPreference (replace): %v", err)
+			}
+			if err := s.SetPreference("user-b", "timezone", "Asia/Kolkata"); err != nil {
+				t.Fatalf("SetPreference (other user): %v", err)
+			}
+
+			prefs, err = s.GetPreferences("user-a")
+			if err != nil {
+				t.Fatalf("GetPreferences: %v", err)
+			}
+			if len(prefs) != 1 || prefs["timezone"] != "Europe/London" {
+				t.Errorf("user-a preferences = %v, want timezone=Europe/London only", prefs)
+			}
+
+			if err := s.DeletePreference("user-a", "timezone"); err != nil {
+				t.Fatalf("DeletePreference: %v", err)
+			}
+			if err := s.DeletePreference("user-a", "missing"); err != nil {
+				t.Fatalf("DeletePreference (missing key): %v", err)
+			}
+			prefs, _ = s.GetPreferences("user-a")
+			if len(prefs) != 0 {
+				t.Errorf("expected user-a preferences cleared, got %v", prefs)
+			}
+			prefs, _ = s.GetPreferences("user-b")
+			if prefs["timezone"] != "Asia/Kolkata" {
+				t.Errorf("user-b preferences = %v, want untouched", prefs)
+			}
+		})
+	}
+}
+
+func TestPoolConfigWithDefaults(t *testing.T) {
+	got := PoolConfig{MaxOpenConns: 50}.withDefaults(defaultPostgresPool)
+	want := PoolConfig{MaxOpenConns: 50, MaxIdleConns: 10, ConnMaxLifetime: 30 * time.Minute}
+	if got != want {
+		t.Errorf("withDefaults() = %+v, want %+v", got, want)
+	}
+}
+
+func TestSQLiteDSN(t *testing.T) {
+	tests := []struct {
+		dsn  string
+		want string
+	}{
+		{"/data/store.db", "/data/store.db?_busy_timeout=5000"},
+		{"file:store.db?cache=shared", "file:store.db?cache=shared&_busy_timeout=5000"},
+		{"file:store.db?_busy_timeout=100", "file:store.db?_busy_timeout=100"},
+		{"store.db?_timeout=100", "store.db?_timeout=100"},
+	}
+	for _, tt := range tests {
+		if got := sqliteDSN(tt.dsn, 5*time.Second); got != tt.want {
+			t.Errorf("sqliteDSN(%q) = %q, want %q", tt.dsn, got, tt.want)
+		}
+	}
+	if got := sqliteDSN("store.db", 0); got != "store.db" {
+		t.Errorf("sqliteDSN with no timeout = %q, want unchanged", got)
+	}
+}
+
+func TestSQLiteConcurrentWrites(t *testing.T) {
+	s, err := NewSQLiteStore(filepath.Join(t.TempDir(), "store.db"), time.Minute, PoolConfig{})
+	if err != nil {
+		t.Fatalf("failed to create sqlite store: %v", err)
+	}
+	defer s.Close()
+
+	const workers, perWorker = 16, 20
+	var wg sync.WaitGroup
+	errs := make(chan error, workers*perWorker*3)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				state := fmt.Sprintf("state-%d-%d", w, i)
+				if err := s.StorePendingAuth(&PendingAuth{State: state, ClientID: "c", ClientRedirectURI: "http://localhost/cb", WebexCodeVerifier: "v", CreatedAt: time.Now()}); err != nil {
+					errs <- err
+					continue
+				}
+				if _, ok := s.ConsumePendingAuth(state); !ok {
+					errs <- fmt.Errorf("pending auth %s lost", state)
+				}
+				if _, err := s.StoreToken("access", "refresh", 3600, TokenOwner{}); err != nil {
+					errs <- err
+				}
+				if err := s.SetPreference(fmt.Sprintf("user-%d", w), fmt.Sprintf("key-%d", i), "v"); err != nil {
+					errs <- err
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+
+	var tokens int
+	if err := s.db.QueryRow(`SELECT COUNT(*) FROM tokens`).Scan(&tokens); err != nil {
+		t.Fatalf("count tokens: %v", err)
+	}
+	if tokens != workers*perWorker {
+		t.Errorf("stored %d tokens, want %d", tokens, workers*perWorker)
+	}
+}
+
+func TestRetryBusy(t *testing.T) {
+	calls := 0
+	err := retryBusy(func() error {
+		calls++
+		if calls < 3 {
+			return sqlite3.Error{Code: sqlite3.ErrBusy}
+		}
+		return nil
+	})
+	if err != nil || calls != 3 {
+		t.Errorf("retryBusy() = %v after %d calls, want nil after 3", err, calls)
+	}
+
+	calls = 0
+	permanent := errors.New("constraint failed")
+	if err := retryBusy(func() error { calls++; return permanent }); err != permanent || calls != 1 {
+		t.Errorf("retryBusy() = %v after %d calls, want the error after 1", err, calls)
+	}
+}
+
+func TestGenerateWebhookSecret(t *testing.T) {
+	seen := make(map[string]bool)
+	for i := 0; i < 20; i++ {
+		secret, err := GenerateWebhookSecret()
+		if err != nil {
+			t.Fatalf("GenerateWebhookSecret: %v", err)
+		}
+		// 32 random bytes, hex-encoded
+		if raw, err := hex.DecodeString(secret); err != nil || len(raw) != 32 {
+			t.Fatalf("secret %q is not 32 hex-encoded bytes (err %v)", secret, err)
+		}
+		if seen[secret] {
+			t.Fatalf("secret %q generated twice", secret)
+		}
+		seen[secret] = true
+	}
+}
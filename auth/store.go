@@ -17,9 +17,19 @@ type TokenRecord struct {
 	WebexRefreshToken string    `json:"webex_refresh_token"`
 	ExpiresAt         time.Time `json:"expires_at"`
 	UserID            string    `json:"user_id,omitempty"`
+	ClientID          string    `json:"client_id,omitempty"`
+	ClientName        string    `json:"client_name,omitempty"`
 	CreatedAt         time.Time `json:"created_at"`
 }
 
+// TokenOwner identifies the Webex user and MCP client an opaque token is issued to,
+// so users can later list and revoke their own sessions.
+type TokenOwner struct {
+	UserID     string
+	ClientID   string
+	ClientName string
+}
+
 // AuthCodeRecord holds a pending authorization code awaiting exchange.
 type AuthCodeRecord struct {
 	Code                string    `json:"code"`
@@ -55,6 +65,11 @@ func generateSecureToken(nBytes int) (string, error) {
 	return hex.EncodeToString(b), nil
 }
 
+// GenerateWebhookSecret generates a random secret for signing webhook payloads.
+func GenerateWebhookSecret() (string, error) {
+	return generateSecureToken(32)
+}
+
 // GenerateAuthCode generates a random authorization code.
 func GenerateAuthCode() (string, error) {
 	return generateSecureToken(16)
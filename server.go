@@ -5,6 +5,7 @@ import (
 	"crypto/tls"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"strings"
 	"time"
@@ -20,12 +21,21 @@ import (
 
 // registerTools creates the MCP server and registers all tool groups with the given resolver.
 // If mercuryMgr is non-nil, streaming tools (subscribe, unsubscribe, wait_for_message) are also registered.
-func registerTools(resolver auth.ClientResolver, include, exclude string, minimal, readonlyMinimal bool, mercuryMgr *streaming.MercuryManager) *server.MCPServer {
+// If prefs is non-nil, the per-user preferences tools are registered and consulted by other tools.
+// If caps is non-nil, tools for services the account cannot access are not registered.
+// Extra server options (e.g. per-request tool filters) are appended to the defaults.
+func registerTools(resolver auth.ClientResolver, include, exclude string, minimal, readonlyMinimal bool, mercuryMgr *streaming.MercuryManager, prefs auth.PreferenceStore, caps *tools.Capabilities, opts ...server.ServerOption) *server.MCPServer {
+	// Added last so it is innermost and counts only the tool handler, not capability probes
+	if tools.VerboseMeta() {
+		opts = append(opts, server.WithToolHandlerMiddleware(tools.MetaMiddleware(resolver)))
+	}
 	s := server.NewMCPServer(
 		"webex-mcp",
 		version,
-		server.WithToolCapabilities(false),
-		server.WithRecovery(),
+		append([]server.ServerOption{
+			server.WithToolCapabilities(false),
+			server.WithRecovery(),
+		}, opts...)...,
 	)
 
 	// Resolve preset flags into the include list
@@ -46,50 +56,90 @@ func registerTools(resolver auth.ClientResolver, include, exclude string, minima
 		registrar = s
 	}
 
+	// Skip tools for services the account is not entitled to
+	if caps != nil {
+		cr := tools.NewCapabilityRegistrar(registrar, caps)
+		registrar = cr
+		defer func() {
+			if skipped := cr.Skipped(); skipped > 0 {
+				log.Printf("Capability probe: %d tools skipped (no access to %s)", skipped, strings.Join(caps.MissingServices(), ", "))
+			}
+		}()
+	}
+
 	// Register all tool groups
-	tools.RegisterMessageTools(registrar, resolver)
+	tools.RegisterMessageTools(registrar, resolver, prefs)
 	tools.RegisterRoomTools(registrar, resolver)
+	tools.RegisterRoomTabTools(registrar, resolver)
 	tools.RegisterTeamTools(registrar, resolver)
 	tools.RegisterMembershipTools(registrar, resolver)
-	tools.RegisterMeetingTools(registrar, resolver)
-	tools.RegisterTranscriptTools(registrar, resolver)
+	tools.RegisterPeopleTools(registrar, resolver)
+	tools.RegisterMeetingTools(registrar, resolver, prefs)
+	tools.RegisterTranscriptTools(registrar, resolver, prefs)
 	tools.RegisterWebhookTools(registrar, resolver)
+	tools.RegisterComplianceTools(registrar, resolver)
 	tools.RegisterPaginationTools(registrar, resolver)
 
+	// Register preferences tools only when opted in
+	if prefs != nil {
+		tools.RegisterPreferenceTools(registrar, resolver, prefs)
+	}
+
 	// Register streaming tools only when MercuryManager is available (HTTP mode)
 	if mercuryMgr != nil {
-		tools.RegisterStreamingTools(registrar, resolver, mercuryMgr)
+		tools.RegisterStreamingTools(registrar, resolver, mercuryMgr, tools.ModeHTTP)
 	}
 
 	return s
 }
 
 // startSTDIOServer starts the MCP server in STDIO mode.
-func startSTDIOServer(resolver auth.ClientResolver, include, exclude string, minimal, readonlyMinimal bool) error {
+// prefs may be nil, in which case the preferences tools are not registered.
+// If probeCapabilities is set, the token's service entitlements are checked once
+// and tools for unavailable services are not registered.
+func startSTDIOServer(resolver auth.ClientResolver, include, exclude string, minimal, readonlyMinimal bool, prefs auth.PreferenceStore, probeCapabilities bool) error {
+	var caps *tools.Capabilities
+	if probeCapabilities {
+		client, err := resolver(context.Background())
+		if err != nil {
+			return fmt.Errorf("failed to resolve client for capability probe: %w", err)
+		}
+		caps = tools.ProbeCapabilities(client)
+	}
+
 	// Create MCPServer first, then wire up MercuryManager for streaming tools
-	s := registerTools(resolver, include, exclude, minimal, readonlyMinimal, nil)
+	s := registerTools(resolver, include, exclude, minimal, readonlyMinimal, nil, prefs, caps)
 
 	// Create MercuryManager and register streaming tools (works in STDIO too)
 	mercuryMgr := streaming.NewMercuryManager(s)
-	tools.RegisterStreamingTools(s, resolver, mercuryMgr)
+	tools.RegisterStreamingTools(s, resolver, mercuryMgr, tools.ModeSTDIO)
 
 	return server.ServeStdio(s)
 }
 
 // HTTPServerConfig holds configuration for the HTTP mode.
 type HTTPServerConfig struct {
-	Host            string
-	Port            int
-	TLSCert         string
-	TLSKey          string
-	OAuthConfig     *auth.OAuthConfig
-	WebexSDKConfig  *webexsdk.Config
-	StoreConfig     auth.StoreConfig
-	Include         string
-	Exclude         string
-	Minimal         bool
-	ReadonlyMinimal bool
-	CORSOrigins     string
+	Host             string
+	Port             int
+	TLSCert          string
+	TLSKey           string
+	OAuthConfig      *auth.OAuthConfig
+	WebexSDKConfig   *webexsdk.Config
+	StoreConfig      auth.StoreConfig
+	Include          string
+	Exclude          string
+	Minimal          bool
+	ReadonlyMinimal  bool
+	CORSOrigins      string
+	DevInsecure      bool // accept X-Dev-Webex-Token header; development only
+	Preferences      bool // enable per-user preferences tools backed by the store
+	SkipOAuthCheck   bool // skip the startup OAuth configuration self-check
+	StrictOAuthCheck bool // fail the self-check when the redirect URI is unreachable, not just warn
+
+	ClientTTL         time.Duration // recreate cached Webex clients this long after creation
+	ClientIdleTimeout time.Duration // also evict cached Webex clients unused for this long (0 = disabled)
+	ClientCacheMax    int           // max cached Webex clients; least-recently-used are evicted (0 = unbounded)
+	ProbeCapabilities bool          // hide tools for services each user's token cannot access
 }
 
 // requestLoggingMiddleware logs every incoming HTTP request for debugging.
@@ -105,7 +155,8 @@ func requestLoggingMiddleware(next http.Handler) http.Handler {
 
 // corsMiddleware adds CORS headers to all responses.
 // allowedOrigins is a comma-separated string. If it contains "*", all origins are allowed.
-func corsMiddleware(allowedOrigins string, next http.Handler) http.Handler {
+// When devInsecure is set, the X-Dev-Webex-Token header is also allowed.
+func corsMiddleware(allowedOrigins string, devInsecure bool, next http.Handler) http.Handler {
 	var allowAll bool
 	originSet := make(map[string]struct{})
 	for _, o := range strings.Split(allowedOrigins, ",") {
@@ -118,6 +169,11 @@ func corsMiddleware(allowedOrigins string, next http.Handler) http.Handler {
 		}
 	}
 
+	allowHeaders := "Content-Type, Authorization, Mcp-Session-Id"
+	if devInsecure {
+		allowHeaders += ", " + auth.DevTokenHeader
+	}
+
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		origin := r.Header.Get("Origin")
 		if allowAll {
@@ -130,7 +186,7 @@ func corsMiddleware(allowedOrigins string, next http.Handler) http.Handler {
 		}
 
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, Mcp-Session-Id")
+		w.Header().Set("Access-Control-Allow-Headers", allowHeaders)
 		w.Header().Set("Access-Control-Expose-Headers", "Mcp-Session-Id")
 
 		if r.Method == http.MethodOptions {
@@ -164,7 +220,11 @@ func startHTTPServer(cfg *HTTPServerConfig) error {
 
 	log.Printf("Using %s store", cfg.StoreConfig.Type)
 
-	clientCache := auth.NewClientCache(15*time.Minute, cfg.WebexSDKConfig)
+	clientCache := auth.NewClientCache(cfg.ClientTTL, cfg.ClientCacheMax, cfg.WebexSDKConfig)
+	clientCache.SetIdleTimeout(cfg.ClientIdleTimeout)
+	if tools.VerboseMeta() {
+		clientCache.SetOnCreate(tools.InstrumentClient)
+	}
 	defer clientCache.Close()
 
 	// Create OAuth handler
@@ -173,21 +233,45 @@ func startHTTPServer(cfg *HTTPServerConfig) error {
 	// Create discovery handler
 	discoveryHandler := auth.NewDiscoveryHandler(cfg.OAuthConfig)
 
+	// Create OAuth self-checker (also backs /debug/oauth-config)
+	selfChecker := auth.NewOAuthSelfChecker(cfg.OAuthConfig)
+	selfChecker.SetStrictRedirect(cfg.StrictOAuthCheck)
+
 	// Create auth middleware
 	authMiddleware := auth.NewAuthMiddleware(store, clientCache, oauthHandler, cfg.OAuthConfig.ServerURL)
+	if cfg.DevInsecure {
+		authMiddleware.EnableDevTokenHeader()
+	}
 
 	// Create the HTTP client resolver
 	resolver := auth.NewHTTPClientResolver()
 
 	// Register tools with the resolver.
 	// MercuryManager needs the MCPServer ref, so we pass nil first, then register streaming tools after.
-	mcpServer := registerTools(resolver, cfg.Include, cfg.Exclude, cfg.Minimal, cfg.ReadonlyMinimal, nil)
+	var prefs auth.PreferenceStore
+	if cfg.Preferences {
+		prefs = store
+	}
+	// Capabilities differ per user, so tools stay registered and are filtered per request
+	var serverOpts []server.ServerOption
+	if cfg.ProbeCapabilities {
+		capCache := tools.NewCapabilityCache()
+		serverOpts = append(serverOpts,
+			server.WithToolFilter(capCache.FilterTools),
+			server.WithToolHandlerMiddleware(capCache.Middleware),
+		)
+	}
+	mcpServer := registerTools(resolver, cfg.Include, cfg.Exclude, cfg.Minimal, cfg.ReadonlyMinimal, nil, prefs, nil, serverOpts...)
 
 	// Create MercuryManager for streaming tools (needs MCPServer for notifications)
 	mercuryMgr := streaming.NewMercuryManager(mcpServer)
 
 	// Register streaming tools now that we have both the MCPServer and MercuryManager
-	tools.RegisterStreamingTools(mcpServer, resolver, mercuryMgr)
+	tools.RegisterStreamingTools(mcpServer, resolver, mercuryMgr, tools.ModeHTTP)
+
+	// Self-service session management needs the store, so it is HTTP mode only
+	sessions := auth.NewSessionManager(store)
+	tools.RegisterSessionTools(mcpServer, sessions)
 
 	// Create the Streamable HTTP server with context propagation
 	// The auth middleware injects the Webex client into the HTTP request context,
@@ -202,6 +286,9 @@ func startHTTPServer(cfg *HTTPServerConfig) error {
 			if token, ok := auth.WebexTokenFromContext(r.Context()); ok {
 				ctx = auth.ContextWithWebexToken(ctx, token)
 			}
+			if record, ok := auth.TokenRecordFromContext(r.Context()); ok {
+				ctx = auth.ContextWithTokenRecord(ctx, record)
+			}
 			return ctx
 		}),
 	)
@@ -224,27 +311,61 @@ func startHTTPServer(cfg *HTTPServerConfig) error {
 	// MCP endpoint (authenticated)
 	mux.Handle("/mcp", authMiddleware.Wrap(streamableServer))
 
+	// The caller's own sessions: GET lists, DELETE ?id= revokes (authenticated)
+	mux.Handle("/sessions", authMiddleware.Wrap(http.HandlerFunc(sessions.HandleSessions)))
+
+	// OAuth config diagnostics (authenticated)
+	mux.Handle("/debug/oauth-config", authMiddleware.Wrap(http.HandlerFunc(selfChecker.HandleDebugConfig)))
+
+	// Client cache gauges (authenticated)
+	mux.Handle("/debug/vars", authMiddleware.Wrap(http.HandlerFunc(clientCache.HandleStats)))
+
 	// Wrap with logging and CORS
 	corsOrigins := cfg.CORSOrigins
 	if corsOrigins == "" {
 		corsOrigins = "*"
 	}
-	handler := requestLoggingMiddleware(corsMiddleware(corsOrigins, mux))
+	handler := requestLoggingMiddleware(corsMiddleware(corsOrigins, cfg.DevInsecure, mux))
 
 	addr := fmt.Sprintf("%s:%d", cfg.Host, cfg.Port)
+	httpServer := &http.Server{
+		Addr:    addr,
+		Handler: handler,
+	}
 
+	// Listen before serving so the self-check can probe our own /callback
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	serveErr := make(chan error, 1)
 	if cfg.TLSCert != "" && cfg.TLSKey != "" {
 		log.Printf("Starting Webex MCP Server v%s in HTTP mode (https://%s)", version, addr)
-		tlsServer := &http.Server{
-			Addr:    addr,
-			Handler: handler,
-			TLSConfig: &tls.Config{
-				MinVersion: tls.VersionTLS12,
-			},
+		httpServer.TLSConfig = &tls.Config{
+			MinVersion: tls.VersionTLS12,
+		}
+		go func() { serveErr <- httpServer.ServeTLS(ln, cfg.TLSCert, cfg.TLSKey) }()
+	} else {
+		log.Printf("Starting Webex MCP Server v%s in HTTP mode (http://%s)", version, addr)
+		go func() { serveErr <- httpServer.Serve(ln) }()
+	}
+
+	switch {
+	case cfg.SkipOAuthCheck:
+		log.Printf("OAuth self-check skipped (--skip-oauth-check)")
+	case cfg.DevInsecure && cfg.OAuthConfig.ClientID == "":
+		log.Printf("OAuth self-check skipped: no OAuth client configured in dev-insecure mode")
+	default:
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		report := selfChecker.Run(ctx)
+		cancel()
+		if !report.OK {
+			httpServer.Close()
+			return fmt.Errorf("OAuth self-check failed: %s (fix the configuration, or pass --skip-oauth-check to start anyway)", report.Failures())
 		}
-		return tlsServer.ListenAndServeTLS(cfg.TLSCert, cfg.TLSKey)
+		log.Printf("OAuth self-check passed")
 	}
 
-	log.Printf("Starting Webex MCP Server v%s in HTTP mode (http://%s)", version, addr)
-	return http.ListenAndServe(addr, handler)
+	return <-serveErr
 }
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/tejzpr/webex-go-mcp/auth"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// newStoreCmd returns the `store` command group, which backs up the registered
+// clients and tokens of a store and loads them into another, e.g. when moving
+// from sqlite to postgres. It uses the same --store and --store-dsn as the server.
+func newStoreCmd() *cobra.Command {
+	storeCmd := &cobra.Command{
+		Use:   "store",
+		Short: "Export and import the OAuth store (registered clients and tokens)",
+	}
+
+	exportCmd := &cobra.Command{
+		Use:   "export [file]",
+		Short: "Write the store's registered clients and tokens as JSON to file (default stdout)",
+		Long: "Write the store's registered clients and tokens as JSON.\n\n" +
+			"Secrets (client secrets, opaque tokens, Webex tokens) are encrypted with the passphrase from " +
+			"WEBEX_BACKUP_PASSPHRASE or --passphrase-file. With --redact they are left out instead: clients " +
+			"keep their registration without a secret and no tokens are exported, so users sign in again.",
+		Args: cobra.MaximumNArgs(1),
+		RunE: runStoreExport,
+	}
+	exportCmd.Flags().Bool("redact", false, "Export without secrets or tokens instead of encrypting them")
+	exportCmd.Flags().String("passphrase-file", "", "File holding the backup passphrase (env: WEBEX_BACKUP_PASSPHRASE)")
+
+	importCmd := &cobra.Command{
+		Use:   "import [file]",
+		Short: "Load registered clients and tokens from a JSON export in file (default stdin)",
+		Long: "Load registered clients and tokens from a JSON export into the store, replacing records " +
+			"with the same client_id or token. Encrypted exports need the passphrase they were written with.",
+		Args: cobra.MaximumNArgs(1),
+		RunE: runStoreImport,
+	}
+	importCmd.Flags().String("passphrase-file", "", "File holding the backup passphrase (env: WEBEX_BACKUP_PASSPHRASE)")
+
+	storeCmd.AddCommand(exportCmd, importCmd)
+	return storeCmd
+}
+
+func runStoreExport(cmd *cobra.Command, args []string) error {
+	redact, _ := cmd.Flags().GetBool("redact")
+	passphrase, err := backupPassphrase(cmd)
+	if err != nil {
+		return err
+	}
+	if redact {
+		passphrase = ""
+	} else if passphrase == "" {
+		return fmt.Errorf("set WEBEX_BACKUP_PASSPHRASE or --passphrase-file to encrypt the exported secrets, or pass --redact to export without them")
+	}
+
+	store, err := openBackupStore()
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	backup, err := auth.ExportStore(store, passphrase)
+	if err != nil {
+		return fmt.Errorf("failed to export store: %w", err)
+	}
+	data, _ := json.MarshalIndent(backup, "", "  ")
+	data = append(data, '\n')
+
+	if len(args) == 0 || args[0] == "-" {
+		_, err = os.Stdout.Write(data)
+	} else {
+		err = os.WriteFile(args[0], data, 0600)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write export: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Exported %d clients and %d tokens (secrets %s)\n", len(backup.Clients), len(backup.Tokens), backup.Secrets)
+	return nil
+}
+
+func runStoreImport(cmd *cobra.Command, args []string) error {
+	passphrase, err := backupPassphrase(cmd)
+	if err != nil {
+		return err
+	}
+
+	var data []byte
+	if len(args) == 0 || args[0] == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(args[0])
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read export: %w", err)
+	}
+	var backup auth.StoreBackup
+	if err := json.Unmarshal(data, &backup); err != nil {
+		return fmt.Errorf("failed to parse export: %w", err)
+	}
+
+	store, err := openBackupStore()
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	counts, err := auth.ImportStore(store, &backup, passphrase)
+	if err != nil {
+		return fmt.Errorf("failed to import store: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Imported %d clients and %d tokens\n", counts.Clients, counts.Tokens)
+	return nil
+}
+
+// backupPassphrase returns the passphrase from --passphrase-file, falling back to
+// WEBEX_BACKUP_PASSPHRASE. It is never taken as a flag value so it stays out of
+// shell history and process listings.
+func backupPassphrase(cmd *cobra.Command) (string, error) {
+	path, _ := cmd.Flags().GetString("passphrase-file")
+	if path == "" {
+		return viper.GetString("backup_passphrase"), nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read passphrase file: %w", err)
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// openBackupStore opens the store selected by --store and --store-dsn. The memory
+// store only exists inside a running server, so there is nothing to export from
+// or import into.
+func openBackupStore() (auth.Store, error) {
+	storeType := viper.GetString("store")
+	if storeType == "" || storeType == "memory" {
+		return nil, fmt.Errorf("the memory store lives inside the running server and cannot be exported or imported; use --store sqlite or --store postgres")
+	}
+	pool, err := storePoolConfig()
+	if err != nil {
+		return nil, err
+	}
+	return auth.NewStore(auth.StoreConfig{
+		Type: storeType,
+		DSN:  viper.GetString("store_dsn"),
+		Pool: pool,
+	})
+}
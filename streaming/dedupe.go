@@ -0,0 +1,58 @@
+package streaming
+
+import (
+	"container/list"
+	"sync"
+)
+
+// DefaultDedupeWindow is how many recent activity IDs each Mercury connection remembers.
+const DefaultDedupeWindow = 256
+
+// dedupeWindow is the size of each new connection's dedupe cache. Set once at
+// startup via SetDedupeWindow.
+var dedupeWindow = DefaultDedupeWindow
+
+// SetDedupeWindow sets how many recently delivered activity IDs each Mercury
+// connection remembers to drop duplicates (redeliveries after a reconnect).
+// 0 or less disables deduplication.
+func SetDedupeWindow(size int) {
+	dedupeWindow = size
+}
+
+// dedupeCache is a bounded LRU set of recently seen keys.
+type dedupeCache struct {
+	mu    sync.Mutex
+	size  int
+	keys  map[string]*list.Element
+	order *list.List // front = most recently seen
+}
+
+// newDedupeCache creates a cache remembering up to size keys, or nil if size <= 0.
+func newDedupeCache(size int) *dedupeCache {
+	if size <= 0 {
+		return nil
+	}
+	return &dedupeCache{size: size, keys: make(map[string]*list.Element), order: list.New()}
+}
+
+// seen records key and reports whether it was already in the cache. A nil cache
+// and an empty key never count as seen.
+func (c *dedupeCache) seen(key string) bool {
+	if c == nil || key == "" {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.keys[key]; ok {
+		c.order.MoveToFront(elem)
+		return true
+	}
+	c.keys[key] = c.order.PushFront(key)
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.keys, oldest.Value.(string))
+	}
+	return false
+}
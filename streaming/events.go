@@ -0,0 +1,141 @@
+package streaming
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/WebexCommunity/webex-go-sdk/v2/conversation"
+)
+
+// Event types a subscription can ask for. All but EventUpdate are Mercury activity
+// verbs; an edit arrives as a post whose parent has type "edit".
+const (
+	EventPost        = "post"
+	EventShare       = "share"
+	EventAcknowledge = "acknowledge"
+	EventDelete      = "delete"
+	EventUpdate      = "update"
+)
+
+// DefaultEventTypes are used when a subscription does not name any. Edits used to
+// arrive as posts, so the default includes updates (and deletes) to keep
+// delivering every change to subscribers that never set eventTypes.
+var DefaultEventTypes = []string{EventPost, EventShare, EventUpdate, EventDelete}
+
+// SupportedEventTypes lists every event type accepted by Subscribe.
+var SupportedEventTypes = []string{EventPost, EventShare, EventAcknowledge, EventDelete, EventUpdate}
+
+// ValidateEventTypes returns an error naming the first unsupported event type.
+func ValidateEventTypes(eventTypes []string) error {
+	for _, et := range eventTypes {
+		supported := false
+		for _, s := range SupportedEventTypes {
+			if et == s {
+				supported = true
+				break
+			}
+		}
+		if !supported {
+			return fmt.Errorf("unsupported event type %q (supported: %s)", et, strings.Join(SupportedEventTypes, ", "))
+		}
+	}
+	return nil
+}
+
+// listenVerbs returns the Mercury verbs to register handlers on for the given
+// event types, without duplicates. Edits are posts, so EventUpdate listens on
+// "post", and also on "update" in case the service sends that verb directly.
+func listenVerbs(eventTypes []string) []string {
+	var verbs []string
+	seen := make(map[string]bool)
+	add := func(verb string) {
+		if !seen[verb] {
+			seen[verb] = true
+			verbs = append(verbs, verb)
+		}
+	}
+	for _, et := range eventTypes {
+		if et == EventUpdate {
+			add(EventPost)
+		}
+		add(et)
+	}
+	return verbs
+}
+
+// activityParent returns the type and ID of the activity's parent, if any.
+// The SDK does not parse it, so it is read from the raw activity.
+func activityParent(activity *conversation.Activity) (string, string) {
+	raw, _ := activity.RawData["activity"].(map[string]interface{})
+	parent, _ := raw["parent"].(map[string]interface{})
+	parentType, _ := parent["type"].(string)
+	parentID, _ := parent["id"].(string)
+	return parentType, parentID
+}
+
+// activityEventType classifies an activity as one of the supported event types.
+func activityEventType(activity *conversation.Activity) string {
+	if activity.Verb == EventPost || activity.Verb == EventShare {
+		if parentType, _ := activityParent(activity); parentType == "edit" {
+			return EventUpdate
+		}
+	}
+	return activity.Verb
+}
+
+// activityMessageID returns the conversation ID of the message an event is about:
+// the deleted or read message for delete and acknowledge, the original message
+// for an edit, and the activity itself otherwise.
+func activityMessageID(activity *conversation.Activity, eventType string) string {
+	switch eventType {
+	case EventDelete, EventAcknowledge:
+		id, _ := activity.Object["id"].(string)
+		return id
+	case EventUpdate:
+		if _, parentID := activityParent(activity); parentID != "" {
+			return parentID
+		}
+	}
+	return activity.ID
+}
+
+// defaultRESTCluster is the REST ID cluster assumed when the room's own ID does not name one.
+const defaultRESTCluster = "us"
+
+// restIDCluster returns the cluster of a Webex REST API ID -- "us" in
+// ciscospark://us/ROOM/<uuid>, or the urn:TEAM:... cluster of other regions --
+// or "" if id is not a REST ID.
+func restIDCluster(id string) string {
+	id = strings.TrimRight(id, "=")
+	decoded, err := base64.RawStdEncoding.DecodeString(id)
+	if err != nil {
+		if decoded, err = base64.RawURLEncoding.DecodeString(id); err != nil {
+			return ""
+		}
+	}
+	rest, ok := strings.CutPrefix(string(decoded), "ciscospark://")
+	if !ok {
+		return ""
+	}
+	// <cluster>/<TYPE>/<uuid>
+	parts := strings.Split(rest, "/")
+	if len(parts) < 3 {
+		return ""
+	}
+	return strings.Join(parts[:len(parts)-2], "/")
+}
+
+// restMessageID converts a conversation activity UUID into the message ID used by
+// the Webex REST API (and by the webex_messages_* tools). Messages live in the same
+// cluster as their room, so the cluster is taken from roomID.
+func restMessageID(roomID, activityID string) string {
+	if activityID == "" {
+		return ""
+	}
+	cluster := restIDCluster(roomID)
+	if cluster == "" {
+		cluster = defaultRESTCluster
+	}
+	return base64.RawStdEncoding.EncodeToString([]byte("ciscospark://" + cluster + "/MESSAGE/" + activityID))
+}
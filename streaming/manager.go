@@ -32,6 +32,18 @@ type Subscription struct {
 	handlers  []eventHandler
 }
 
+// ConnectionError reports that a Mercury connection could not be set up, typically
+// because the token cannot register a device (e.g. a bot or integration token
+// without the spark:all scope). Callers can use errors.As to explain the requirement.
+type ConnectionError struct {
+	Op  string
+	Err error
+}
+
+func (e *ConnectionError) Error() string { return e.Op + ": " + e.Err.Error() }
+
+func (e *ConnectionError) Unwrap() error { return e.Err }
+
 // MercuryManager manages per-user Mercury connections and multiplexes
 // conversation events to MCP client sessions as notifications.
 type MercuryManager struct {
@@ -49,6 +61,7 @@ type userConnection struct {
 	connected  bool
 	refCount   int // number of active subscriptions using this connection
 	tokenHash  string
+	delivered  *dedupeCache // subscriptionId/activityId pairs already notified
 }
 
 // NewMercuryManager creates a new MercuryManager.
@@ -71,7 +84,14 @@ func (m *MercuryManager) Subscribe(
 	eventTypes []string,
 ) (*Subscription, error) {
 	if len(eventTypes) == 0 {
-		eventTypes = []string{"post", "share"}
+		eventTypes = DefaultEventTypes
+	}
+	if err := ValidateEventTypes(eventTypes); err != nil {
+		return nil, err
+	}
+	wanted := make(map[string]bool, len(eventTypes))
+	for _, et := range eventTypes {
+		wanted[et] = true
 	}
 
 	tokHash := hashToken(accessToken)
@@ -79,7 +99,7 @@ func (m *MercuryManager) Subscribe(
 	// Get or create the user's Mercury connection
 	uc, err := m.getOrCreateConnection(client, tokHash)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create Mercury connection: %w", err)
+		return nil, &ConnectionError{Op: "failed to create Mercury connection", Err: err}
 	}
 
 	// Generate subscription ID
@@ -106,27 +126,12 @@ func (m *MercuryManager) Subscribe(
 	m.subscriptions[subID] = sub
 	m.mu.Unlock()
 
-	// Register event handlers for the requested event types, storing refs for cleanup
-	for _, eventType := range eventTypes {
-		et := eventType // capture
-		handler := func(activity *conversation.Activity) {
-			select {
-			case <-subCtx.Done():
-				return
-			default:
-			}
-
-			if roomID != "" && activity.Target != nil && activity.Target.ID != roomID {
-				if activity.Target.GlobalID != roomID {
-					return
-				}
-			}
-
-			payload := m.buildEventPayload(sub, et, activity)
-			m.sendNotification(sessionID, payload)
-		}
-		uc.convClient.On(et, handler)
-		sub.handlers = append(sub.handlers, eventHandler{eventType: et, handler: handler})
+	// Register one handler per Mercury verb the requested event types need, storing
+	// refs for cleanup.
+	for _, verb := range listenVerbs(eventTypes) {
+		handler := m.activityHandler(subCtx, sub, uc, wanted)
+		uc.convClient.On(verb, handler)
+		sub.handlers = append(sub.handlers, eventHandler{eventType: verb, handler: handler})
 	}
 
 	// Ensure Mercury is connected
@@ -136,7 +141,7 @@ func (m *MercuryManager) Subscribe(
 		if err := uc.convClient.Connect(); err != nil {
 			uc.mu.Unlock()
 			m.Unsubscribe(subID)
-			return nil, fmt.Errorf("failed to connect Mercury: %w", err)
+			return nil, &ConnectionError{Op: "failed to connect Mercury", Err: err}
 		}
 		uc.connected = true
 		log.Printf("[Mercury] Connected successfully for user (hash=%s...)", tokHash[:8])
@@ -147,6 +152,38 @@ func (m *MercuryManager) Subscribe(
 	return sub, nil
 }
 
+// activityHandler returns the Mercury handler for sub. Each activity is classified
+// so an edit (a post) is only delivered as an update, and only if that event type
+// is in wanted; activities already delivered on uc are dropped.
+func (m *MercuryManager) activityHandler(ctx context.Context, sub *Subscription, uc *userConnection, wanted map[string]bool) conversation.ActivityHandler {
+	return func(activity *conversation.Activity) {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		if sub.RoomID != "" && activity.Target != nil && activity.Target.ID != sub.RoomID {
+			if activity.Target.GlobalID != sub.RoomID {
+				return
+			}
+		}
+
+		et := activityEventType(activity)
+		if !wanted[et] {
+			return
+		}
+
+		// Mercury can redeliver an activity (e.g. after a reconnect)
+		if activity.ID != "" && uc.delivered.seen(sub.ID+"/"+activity.ID) {
+			return
+		}
+
+		payload := m.buildEventPayload(sub, et, activity)
+		m.sendNotification(sub.SessionID, payload)
+	}
+}
+
 // Unsubscribe cancels a subscription and cleans up resources.
 func (m *MercuryManager) Unsubscribe(subscriptionID string) error {
 	m.mu.Lock()
@@ -220,7 +257,7 @@ func (m *MercuryManager) WaitForMessage(
 
 	uc, err := m.getOrCreateConnection(client, tokHash)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create Mercury connection: %w", err)
+		return nil, &ConnectionError{Op: "failed to create Mercury connection", Err: err}
 	}
 
 	resultCh := make(chan map[string]interface{}, 1)
@@ -229,6 +266,10 @@ func (m *MercuryManager) WaitForMessage(
 
 	// Register a one-shot handler
 	handler := func(activity *conversation.Activity) {
+		// Edits arrive as posts; only a new message ends the wait
+		if activityEventType(activity) == EventUpdate {
+			return
+		}
 		if roomID != "" && activity.Target != nil {
 			if activity.Target.ID != roomID && activity.Target.GlobalID != roomID {
 				return
@@ -267,7 +308,7 @@ func (m *MercuryManager) WaitForMessage(
 	if !uc.connected {
 		if err := uc.convClient.Connect(); err != nil {
 			uc.mu.Unlock()
-			return nil, fmt.Errorf("failed to connect Mercury: %w", err)
+			return nil, &ConnectionError{Op: "failed to connect Mercury", Err: err}
 		}
 		uc.connected = true
 	}
@@ -318,6 +359,7 @@ func (m *MercuryManager) getOrCreateConnection(client *webex.WebexClient, tokHas
 		convClient: convClient,
 		tokenHash:  tokHash,
 		refCount:   1,
+		delivered:  newDedupeCache(dedupeWindow),
 	}
 
 	m.userConns[tokHash] = uc
@@ -333,6 +375,11 @@ func (m *MercuryManager) buildEventPayload(sub *Subscription, eventType string,
 		"timestamp":      activity.Published,
 	}
 
+	if id := activityMessageID(activity, eventType); id != "" {
+		payload["messageId"] = restMessageID(sub.RoomID, id)
+		payload["activityId"] = id
+	}
+
 	if activity.Actor != nil {
 		payload["sender"] = map[string]interface{}{
 			"displayName":  activity.Actor.DisplayName,
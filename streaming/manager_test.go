@@ -1,9 +1,16 @@
 package streaming
 
 import (
+	"context"
+	"encoding/base64"
 	"regexp"
+	"slices"
 	"testing"
 	"time"
+
+	"github.com/WebexCommunity/webex-go-sdk/v2/conversation"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
 )
 
 func TestNewMercuryManager(t *testing.T) {
@@ -169,3 +176,155 @@ func TestHashToken_DifferentInputsDifferentOutputs(t *testing.T) {
 		t.Errorf("hashToken should produce different hashes for different inputs")
 	}
 }
+
+func editActivity(parentType, parentID string) *conversation.Activity {
+	return &conversation.Activity{
+		ID:   "edit-activity",
+		Verb: "post",
+		RawData: map[string]interface{}{
+			"activity": map[string]interface{}{
+				"parent": map[string]interface{}{"type": parentType, "id": parentID},
+			},
+		},
+	}
+}
+
+func TestActivityEventTypeAndMessageID(t *testing.T) {
+	tests := []struct {
+		name      string
+		activity  *conversation.Activity
+		wantType  string
+		wantMsgID string
+	}{
+		{"post", &conversation.Activity{ID: "m1", Verb: "post"}, EventPost, "m1"},
+		{"share", &conversation.Activity{ID: "m2", Verb: "share"}, EventShare, "m2"},
+		{"edit", editActivity("edit", "original"), EventUpdate, "original"},
+		{"reply is still a post", editActivity("reply", "thread"), EventPost, "edit-activity"},
+		{"delete", &conversation.Activity{ID: "d1", Verb: "delete", Object: map[string]interface{}{"id": "gone"}}, EventDelete, "gone"},
+		{"acknowledge", &conversation.Activity{ID: "a1", Verb: "acknowledge", Object: map[string]interface{}{"id": "read"}}, EventAcknowledge, "read"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			et := activityEventType(tt.activity)
+			if et != tt.wantType {
+				t.Errorf("activityEventType = %q, want %q", et, tt.wantType)
+			}
+			if id := activityMessageID(tt.activity, et); id != tt.wantMsgID {
+				t.Errorf("activityMessageID = %q, want %q", id, tt.wantMsgID)
+			}
+		})
+	}
+}
+
+func TestListenVerbs(t *testing.T) {
+	got := listenVerbs([]string{EventUpdate, EventPost, EventDelete})
+	want := []string{"post", "update", "delete"}
+	if !slices.Equal(got, want) {
+		t.Errorf("listenVerbs = %v, want %v", got, want)
+	}
+}
+
+func TestValidateEventTypes(t *testing.T) {
+	if err := ValidateEventTypes(SupportedEventTypes); err != nil {
+		t.Errorf("supported types rejected: %v", err)
+	}
+	if !slices.Contains(DefaultEventTypes, EventUpdate) {
+		t.Error("default event types must include update so edits are still delivered")
+	}
+	if err := ValidateEventTypes([]string{"post", "edit"}); err == nil {
+		t.Error("expected an error for unsupported type \"edit\"")
+	}
+}
+
+func TestBuildEventPayloadMessageID(t *testing.T) {
+	m := NewMercuryManager(nil)
+	sub := &Subscription{ID: "sub1", RoomID: "room1"}
+	activity := &conversation.Activity{Verb: "delete", Object: map[string]interface{}{"id": "92db3be0-43bd-11e6-8ae9-dd5b3dfc565d"}}
+
+	payload := m.buildEventPayload(sub, EventDelete, activity)
+	if payload["activityId"] != "92db3be0-43bd-11e6-8ae9-dd5b3dfc565d" {
+		t.Errorf("activityId = %v", payload["activityId"])
+	}
+	want := "Y2lzY29zcGFyazovL3VzL01FU1NBR0UvOTJkYjNiZTAtNDNiZC0xMWU2LThhZTktZGQ1YjNkZmM1NjVk"
+	if payload["messageId"] != want {
+		t.Errorf("messageId = %v, want %s", payload["messageId"], want)
+	}
+}
+
+func TestDedupeCache(t *testing.T) {
+	c := newDedupeCache(2)
+	if c.seen("sub1/a") {
+		t.Error("first delivery of a reported as seen")
+	}
+	if !c.seen("sub1/a") {
+		t.Error("repeated activity a was not suppressed")
+	}
+	if c.seen("sub2/a") {
+		t.Error("same activity for another subscription reported as seen")
+	}
+	// Window is 2: adding c evicts the least recently seen key (sub1/a)
+	c.seen("sub1/c")
+	if c.seen("sub1/a") {
+		t.Error("sub1/a should have been evicted")
+	}
+	if c.seen("") {
+		t.Error("empty key must never be seen")
+	}
+
+	disabled := newDedupeCache(0)
+	if disabled.seen("x") || disabled.seen("x") {
+		t.Error("disabled cache suppressed an activity")
+	}
+}
+
+// fakeSession is an initialized MCP client session that buffers notifications.
+type fakeSession struct {
+	id            string
+	notifications chan mcp.JSONRPCNotification
+}
+
+func (f *fakeSession) Initialize()                                         {}
+func (f *fakeSession) Initialized() bool                                   { return true }
+func (f *fakeSession) NotificationChannel() chan<- mcp.JSONRPCNotification { return f.notifications }
+func (f *fakeSession) SessionID() string                                   { return f.id }
+
+func TestActivityHandlerDropsRedelivery(t *testing.T) {
+	mcpServer := server.NewMCPServer("test", "0.0.0")
+	session := &fakeSession{id: "session1", notifications: make(chan mcp.JSONRPCNotification, 10)}
+	if err := mcpServer.RegisterSession(context.Background(), session); err != nil {
+		t.Fatalf("RegisterSession: %v", err)
+	}
+
+	m := NewMercuryManager(mcpServer)
+	sub := &Subscription{ID: "sub1", SessionID: "session1"}
+	uc := &userConnection{delivered: newDedupeCache(DefaultDedupeWindow)}
+	handler := m.activityHandler(context.Background(), sub, uc, map[string]bool{EventPost: true})
+
+	// The same activity twice, as after a Mercury reconnect, then a new one
+	handler(&conversation.Activity{ID: "a1", Verb: "post"})
+	handler(&conversation.Activity{ID: "a1", Verb: "post"})
+	handler(&conversation.Activity{ID: "a2", Verb: "post"})
+
+	if got := len(session.notifications); got != 2 {
+		t.Errorf("notifications = %d, want 2 (the redelivered a1 dropped)", got)
+	}
+}
+
+func TestRestMessageIDUsesRoomCluster(t *testing.T) {
+	const activityID = "92db3be0-43bd-11e6-8ae9-dd5b3dfc565d"
+	encode := func(s string) string { return base64.RawStdEncoding.EncodeToString([]byte(s)) }
+
+	tests := []struct {
+		name, roomID, want string
+	}{
+		{"us room", encode("ciscospark://us/ROOM/abc"), encode("ciscospark://us/MESSAGE/" + activityID)},
+		{"eu room", encode("ciscospark://urn:TEAM:eu-central-1_k/ROOM/abc"), encode("ciscospark://urn:TEAM:eu-central-1_k/MESSAGE/" + activityID)},
+		{"padded eu room", base64.StdEncoding.EncodeToString([]byte("ciscospark://urn:TEAM:eu-central-1_k/ROOM/ab")), encode("ciscospark://urn:TEAM:eu-central-1_k/MESSAGE/" + activityID)},
+		{"not a REST ID", "room1", encode("ciscospark://us/MESSAGE/" + activityID)},
+	}
+	for _, tt := range tests {
+		if got := restMessageID(tt.roomID, activityID); got != tt.want {
+			t.Errorf("%s: restMessageID() = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
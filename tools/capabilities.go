@@ -0,0 +1,236 @@
+package tools
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	webex "github.com/WebexCommunity/webex-go-sdk/v2"
+	"github.com/WebexCommunity/webex-go-sdk/v2/meetings"
+	"github.com/WebexCommunity/webex-go-sdk/v2/recordings"
+	"github.com/WebexCommunity/webex-go-sdk/v2/transcripts"
+	"github.com/WebexCommunity/webex-go-sdk/v2/webexsdk"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/tejzpr/webex-go-mcp/auth"
+)
+
+// Webex services whose tools depend on account entitlements.
+const (
+	ServiceMeetings    = "meetings"
+	ServiceRecordings  = "recordings"
+	ServiceTranscripts = "transcripts"
+)
+
+// capabilityCacheTTL is how long a token's probed capabilities are reused in HTTP mode.
+const capabilityCacheTTL = time.Hour
+
+// Capabilities records which entitlement-gated Webex services a token can use.
+type Capabilities struct {
+	// Missing maps each unavailable service to the reason the probe gave.
+	Missing map[string]string
+	// Complete is false when some probe failed for a reason other than missing
+	// access (e.g. a network error); such services are assumed available.
+	Complete bool
+}
+
+// Available reports whether the service can be used. Services that are not
+// gated, or whose probe was inconclusive, are always available.
+func (c *Capabilities) Available(service string) bool {
+	if c == nil || service == "" {
+		return true
+	}
+	_, missing := c.Missing[service]
+	return !missing
+}
+
+// MissingServices returns the unavailable services in sorted order.
+func (c *Capabilities) MissingServices() []string {
+	if c == nil {
+		return nil
+	}
+	services := make([]string, 0, len(c.Missing))
+	for s := range c.Missing {
+		services = append(services, s)
+	}
+	sort.Strings(services)
+	return services
+}
+
+// ProbeCapabilities makes one minimal list call per gated service and records
+// which ones the token is not entitled to.
+func ProbeCapabilities(client *webex.WebexClient) *Capabilities {
+	return probeServices(map[string]func() error{
+		ServiceMeetings: func() error {
+			_, err := client.Meetings().List(&meetings.ListOptions{Max: 1})
+			return err
+		},
+		ServiceRecordings: func() error {
+			_, err := client.Recordings().List(&recordings.ListOptions{Max: 1})
+			return err
+		},
+		ServiceTranscripts: func() error {
+			_, err := client.Transcripts().List(&transcripts.ListOptions{Max: 1})
+			return err
+		},
+	})
+}
+
+// probeServices runs each probe. A 401/403 marks the service as missing; any other
+// error leaves it available and marks the result incomplete so it is not cached.
+func probeServices(probes map[string]func() error) *Capabilities {
+	caps := &Capabilities{Missing: make(map[string]string), Complete: true}
+	for service, probe := range probes {
+		err := probe()
+		switch {
+		case err == nil:
+		case webexsdk.IsForbidden(err) || webexsdk.IsAuthError(err):
+			caps.Missing[service] = err.Error()
+		default:
+			log.Printf("[Capabilities] probe for %s was inconclusive, assuming available: %v", service, err)
+			caps.Complete = false
+		}
+	}
+	return caps
+}
+
+// toolService returns the gated service a tool belongs to, or "" if the tool is
+// available to every account.
+func toolService(toolName string) string {
+	for _, service := range []string{ServiceMeetings, ServiceRecordings, ServiceTranscripts} {
+		if strings.HasPrefix(toolName, "webex_"+service+"_") {
+			return service
+		}
+	}
+	return ""
+}
+
+// unavailableMessage explains why a gated tool cannot be used with this account.
+func unavailableMessage(toolName, service string) string {
+	return fmt.Sprintf("%s is unavailable: this Webex account or token does not have access to %s (missing license or OAuth scope)", toolName, service)
+}
+
+// CapabilityRegistrar wraps a ToolRegistrar and declines to register tools for
+// services the account cannot access. Used in STDIO mode, where there is a single token.
+type CapabilityRegistrar struct {
+	inner   ToolRegistrar
+	caps    *Capabilities
+	skipped int
+}
+
+// NewCapabilityRegistrar creates a CapabilityRegistrar wrapping the given ToolRegistrar.
+func NewCapabilityRegistrar(inner ToolRegistrar, caps *Capabilities) *CapabilityRegistrar {
+	return &CapabilityRegistrar{inner: inner, caps: caps}
+}
+
+// AddTool registers the tool unless its service is unavailable.
+func (cr *CapabilityRegistrar) AddTool(tool mcp.Tool, handler server.ToolHandlerFunc) {
+	if !cr.caps.Available(toolService(tool.Name)) {
+		cr.skipped++
+		log.Printf("Skipping tool: %s (no access to %s)", tool.Name, toolService(tool.Name))
+		return
+	}
+	cr.inner.AddTool(tool, handler)
+}
+
+// Skipped returns the number of tools not registered because of missing capabilities.
+func (cr *CapabilityRegistrar) Skipped() int {
+	return cr.skipped
+}
+
+// capabilityEntry is a cached probe result.
+type capabilityEntry struct {
+	caps      *Capabilities
+	expiresAt time.Time
+}
+
+// CapabilityCache probes and caches capabilities per access token. Used in HTTP
+// mode, where tools are registered once but each user's entitlements differ:
+// unavailable tools are hidden from tools/list and rejected if called.
+type CapabilityCache struct {
+	mu      sync.Mutex
+	entries map[[32]byte]capabilityEntry
+	ttl     time.Duration
+	probe   func(*webex.WebexClient) *Capabilities
+}
+
+// NewCapabilityCache creates an empty per-token capability cache.
+func NewCapabilityCache() *CapabilityCache {
+	return &CapabilityCache{
+		entries: make(map[[32]byte]capabilityEntry),
+		ttl:     capabilityCacheTTL,
+		probe:   ProbeCapabilities,
+	}
+}
+
+// Get returns the capabilities for the token and client in ctx, probing on first
+// use. It returns nil when the request is not authenticated.
+func (cc *CapabilityCache) Get(ctx context.Context) *Capabilities {
+	token, ok := auth.WebexTokenFromContext(ctx)
+	if !ok || token == "" {
+		return nil
+	}
+	client, ok := auth.WebexClientFromContext(ctx)
+	if !ok || client == nil {
+		return nil
+	}
+
+	key := sha256.Sum256([]byte(token))
+	now := time.Now()
+
+	cc.mu.Lock()
+	entry, found := cc.entries[key]
+	cc.mu.Unlock()
+	if found && now.Before(entry.expiresAt) {
+		return entry.caps
+	}
+
+	caps := cc.probe(client)
+	if missing := caps.MissingServices(); len(missing) > 0 {
+		log.Printf("[Capabilities] token lacks access to: %s", strings.Join(missing, ", "))
+	}
+	if caps.Complete {
+		cc.mu.Lock()
+		for k, e := range cc.entries {
+			if !now.Before(e.expiresAt) {
+				delete(cc.entries, k)
+			}
+		}
+		cc.entries[key] = capabilityEntry{caps: caps, expiresAt: now.Add(cc.ttl)}
+		cc.mu.Unlock()
+	}
+	return caps
+}
+
+// FilterTools hides tools for services the caller cannot access.
+// It satisfies server.ToolFilterFunc.
+func (cc *CapabilityCache) FilterTools(ctx context.Context, tools []mcp.Tool) []mcp.Tool {
+	caps := cc.Get(ctx)
+	if len(caps.MissingServices()) == 0 {
+		return tools
+	}
+	filtered := make([]mcp.Tool, 0, len(tools))
+	for _, tool := range tools {
+		if caps.Available(toolService(tool.Name)) {
+			filtered = append(filtered, tool)
+		}
+	}
+	return filtered
+}
+
+// Middleware rejects calls to tools for services the caller cannot access.
+// It satisfies server.ToolHandlerMiddleware.
+func (cc *CapabilityCache) Middleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		service := toolService(req.Params.Name)
+		if service != "" && !cc.Get(ctx).Available(service) {
+			return mcp.NewToolResultError(unavailableMessage(req.Params.Name, service)), nil
+		}
+		return next(ctx, req)
+	}
+}
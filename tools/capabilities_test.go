@@ -0,0 +1,67 @@
+package tools
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/WebexCommunity/webex-go-sdk/v2/webexsdk"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestProbeServices(t *testing.T) {
+	forbidden := webexsdk.NewAPIError(&http.Response{StatusCode: http.StatusForbidden, Header: http.Header{}}, nil)
+	serverErr := webexsdk.NewAPIError(&http.Response{StatusCode: http.StatusBadGateway, Header: http.Header{}}, nil)
+
+	caps := probeServices(map[string]func() error{
+		ServiceMeetings:    func() error { return nil },
+		ServiceRecordings:  func() error { return forbidden },
+		ServiceTranscripts: func() error { return nil },
+	})
+	if !caps.Complete {
+		t.Error("Complete = false, want true when every probe was conclusive")
+	}
+	if got := caps.MissingServices(); len(got) != 1 || got[0] != ServiceRecordings {
+		t.Errorf("MissingServices() = %v, want [recordings]", got)
+	}
+
+	caps = probeServices(map[string]func() error{
+		ServiceMeetings:   func() error { return serverErr },
+		ServiceRecordings: func() error { return errors.New("connection reset") },
+	})
+	if caps.Complete {
+		t.Error("Complete = true, want false after inconclusive probes")
+	}
+	if !caps.Available(ServiceMeetings) || !caps.Available(ServiceRecordings) {
+		t.Error("inconclusive probes should leave services available")
+	}
+}
+
+func TestToolService(t *testing.T) {
+	tests := map[string]string{
+		"webex_meetings_list":           ServiceMeetings,
+		"webex_recordings_get":          ServiceRecordings,
+		"webex_transcripts_download":    ServiceTranscripts,
+		"webex_messages_list":           "",
+		"webex_rooms_membership_counts": "",
+	}
+	for name, want := range tests {
+		if got := toolService(name); got != want {
+			t.Errorf("toolService(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestCapabilityRegistrar(t *testing.T) {
+	inner := &mockRegistrar{}
+	cr := NewCapabilityRegistrar(inner, &Capabilities{Missing: map[string]string{ServiceTranscripts: "403"}})
+	for _, name := range []string{"webex_messages_list", "webex_transcripts_list", "webex_meetings_list"} {
+		cr.AddTool(mcp.Tool{Name: name}, nil)
+	}
+	if inner.count != 2 {
+		t.Errorf("registered %d tools, want 2", inner.count)
+	}
+	if cr.Skipped() != 1 {
+		t.Errorf("Skipped() = %d, want 1", cr.Skipped())
+	}
+}
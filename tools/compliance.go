@@ -0,0 +1,176 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/WebexCommunity/webex-go-sdk/v2/events"
+	"github.com/WebexCommunity/webex-go-sdk/v2/people"
+	"github.com/WebexCommunity/webex-go-sdk/v2/webexsdk"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/tejzpr/webex-go-mcp/auth"
+)
+
+// complianceScopeHint explains what is needed to use the admin Events API.
+const complianceScopeHint = "the Events API requires a Compliance Officer account and a token with the spark-compliance:events_read scope"
+
+// RegisterComplianceTools registers the org-wide compliance (e-discovery) MCP tools.
+func RegisterComplianceTools(s ToolRegistrar, resolver auth.ClientResolver) {
+	// webex_compliance_message_events
+	s.AddTool(
+		mcp.NewTool("webex_compliance_message_events",
+			mcp.WithDescription("Retrieve message events (created, updated, deleted) across the whole organization in a time window, using the admin Events API. For compliance officers doing e-discovery.\n"+
+				"\n"+
+				"USE THIS WHEN:\n"+
+				"- 'Show every message alice@example.com sent last week' → from, to, actorEmail\n"+
+				"- 'Which messages were deleted yesterday?' → from, to, type='deleted'\n"+
+				"- Org-wide discovery where listing room by room is impractical.\n"+
+				"\n"+
+				"REQUIREMENTS: Only works for Compliance Officers with the spark-compliance:events_read scope. Regular users get an explicit permission error.\n"+
+				"\n"+
+				"RESPONSE: Each event includes eventType, created, the actor (id, displayName), the room (id, title, type), and the message (id, text when available, personEmail). "+
+				"Text is usually absent for deleted messages.\n"+
+				"\n"+
+				"IMPORTANT: Results can contain sensitive content from spaces the user is not a member of. Only share what the compliance request needs."+
+				PaginationDescription),
+			mcp.WithString("from", mcp.Required(), mcp.Description("Start of the window (UTC format: '2026-01-01T00:00:00Z').")),
+			mcp.WithString("to", mcp.Description("End of the window (UTC format: '2026-01-08T00:00:00Z'). Defaults to now.")),
+			mcp.WithString("type", mcp.Description("Only this kind of event: 'created', 'updated', or 'deleted'. Omit for all three.")),
+			mcp.WithString("actorEmail", mcp.Description("Only events performed by this person (email address).")),
+			mcp.WithNumber("maxResults", mcp.Description(MaxResultsParamDescription)),
+			mcp.WithString("nextPageUrl", mcp.Description(NextPageUrlParamDescription)),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			client, err := resolver(ctx)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Auth error: %v", err)), nil
+			}
+
+			nextPageUrl := req.GetString("nextPageUrl", "")
+
+			var items []events.Event
+			var hasNextPage bool
+			var nextURL string
+
+			if nextPageUrl != "" {
+				page, pErr := FetchPage(client, nextPageUrl)
+				if pErr != nil {
+					return mcp.NewToolResultError(complianceError("fetch next page", pErr)), nil
+				}
+				items, err = UnmarshalPageItems[events.Event](page)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("Failed to parse events: %v", err)), nil
+				}
+				hasNextPage = page.HasNext
+				nextURL = page.NextPage
+			} else {
+				opts := &events.ListOptions{Resource: "messages", Max: PageSize}
+
+				from, err := req.RequireString("from")
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				if opts.From, err = validateAndConvertISO8601(from, "from"); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				if v := req.GetString("to", ""); v != "" {
+					if opts.To, err = validateAndConvertISO8601(v, "to"); err != nil {
+						return mcp.NewToolResultError(err.Error()), nil
+					}
+				}
+				if v := strings.ToLower(strings.TrimSpace(req.GetString("type", ""))); v != "" {
+					if v != "created" && v != "updated" && v != "deleted" {
+						return mcp.NewToolResultError(fmt.Sprintf("type must be 'created', 'updated', or 'deleted', got %q", v)), nil
+					}
+					opts.Type = v
+				}
+				if v := strings.TrimSpace(req.GetString("actorEmail", "")); v != "" {
+					found, pErr := client.People().List(&people.ListOptions{Email: v})
+					if pErr != nil {
+						return mcp.NewToolResultError(fmt.Sprintf("Failed to look up actor: %v", pErr)), nil
+					}
+					if len(found.Items) == 0 {
+						return mcp.NewToolResultError(fmt.Sprintf("No Webex user found for actorEmail %q", v)), nil
+					}
+					opts.ActorID = found.Items[0].ID
+				}
+
+				page, lErr := client.Events().List(opts)
+				if lErr != nil {
+					return mcp.NewToolResultError(complianceError("list message events", lErr)), nil
+				}
+				items = page.Items
+				hasNextPage = page.HasNext
+				nextURL = page.NextPage
+			}
+
+			items, hasNextPage, nextURL, _ = AutoPaginate(items, hasNextPage, nextURL, client, ClampMaxResults(req))
+
+			names := NewPersonNameCache(client)
+			roomCache := make(map[string]*RoomInfo)
+			enriched := make([]map[string]interface{}, 0, len(items))
+			for _, ev := range items {
+				summary := summarizeMessageEvent(ev)
+				if name := names.Resolve(ev.ActorID); name != "" {
+					summary["actor"].(map[string]interface{})["displayName"] = name
+				}
+				if roomID := ev.Data.RoomID; roomID != "" {
+					info, ok := roomCache[roomID]
+					if !ok {
+						info = resolveRoomInfo(client, roomID)
+						roomCache[roomID] = info
+					}
+					if info != nil {
+						summary["room"].(map[string]interface{})["title"] = info.Title
+					}
+				}
+				enriched = append(enriched, summary)
+			}
+
+			response := map[string]interface{}{
+				"events": enriched,
+			}
+			AddPaginationToMap(response, len(enriched), hasNextPage, nextURL)
+
+			data, _ := json.MarshalIndent(response, "", "  ")
+			return mcp.NewToolResultText(string(data)), nil
+		},
+	)
+}
+
+// summarizeMessageEvent flattens a message event into actor, room, and message
+// sections. Names and room titles are filled in by the caller.
+func summarizeMessageEvent(ev events.Event) map[string]interface{} {
+	message := map[string]interface{}{
+		"id": ev.Data.ID,
+	}
+	if ev.Data.Text != "" {
+		message["text"] = ev.Data.Text
+	}
+	if ev.Data.PersonEmail != "" {
+		message["personEmail"] = ev.Data.PersonEmail
+	}
+	if ev.Data.Created != "" {
+		message["created"] = ev.Data.Created
+	}
+
+	return map[string]interface{}{
+		"eventId":   ev.ID,
+		"eventType": ev.Type,
+		"created":   ev.Created,
+		"actor":     map[string]interface{}{"id": ev.ActorID},
+		"room":      map[string]interface{}{"id": ev.Data.RoomID, "type": ev.Data.RoomType},
+		"message":   message,
+	}
+}
+
+// complianceError formats an Events API failure, calling out missing compliance
+// permissions explicitly since that is the most common cause.
+func complianceError(action string, err error) string {
+	if webexsdk.IsForbidden(err) || webexsdk.IsAuthError(err) {
+		return fmt.Sprintf("Failed to %s: insufficient permissions -- %s (%v)", action, complianceScopeHint, err)
+	}
+	return fmt.Sprintf("Failed to %s: %v", action, err)
+}
@@ -0,0 +1,47 @@
+package tools
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/WebexCommunity/webex-go-sdk/v2/events"
+	"github.com/WebexCommunity/webex-go-sdk/v2/webexsdk"
+)
+
+func TestSummarizeMessageEvent(t *testing.T) {
+	ev := events.Event{
+		ID:      "ev1",
+		Type:    "deleted",
+		ActorID: "p1",
+		Data:    events.EventData{ID: "m1", RoomID: "r1", RoomType: "group", PersonEmail: "alice@example.com"},
+	}
+
+	got := summarizeMessageEvent(ev)
+
+	if got["eventType"] != "deleted" || got["eventId"] != "ev1" {
+		t.Errorf("event fields = %v", got)
+	}
+	msg := got["message"].(map[string]interface{})
+	if msg["id"] != "m1" || msg["personEmail"] != "alice@example.com" {
+		t.Errorf("message = %v", msg)
+	}
+	if _, ok := msg["text"]; ok {
+		t.Error("message text should be omitted when empty")
+	}
+	if room := got["room"].(map[string]interface{}); room["id"] != "r1" || room["type"] != "group" {
+		t.Errorf("room = %v", room)
+	}
+}
+
+func TestComplianceError(t *testing.T) {
+	forbidden := webexsdk.NewAPIError(&http.Response{StatusCode: http.StatusForbidden, Header: http.Header{}}, nil)
+
+	if got := complianceError("list message events", forbidden); !strings.Contains(got, "spark-compliance:events_read") {
+		t.Errorf("forbidden error = %q, want scope hint", got)
+	}
+	if got := complianceError("list message events", errors.New("boom")); strings.Contains(got, "Compliance Officer") {
+		t.Errorf("plain error = %q, should not mention compliance scope", got)
+	}
+}
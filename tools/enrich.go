@@ -6,15 +6,31 @@ import (
 	"log"
 	"mime"
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
 
 	webex "github.com/WebexCommunity/webex-go-sdk/v2"
+	"github.com/WebexCommunity/webex-go-sdk/v2/messages"
 )
 
 // maxTextFileSize is the maximum size of a text file to include inline (100KB).
 const maxTextFileSize = 100 * 1024
 
+// DefaultMaxBinaryContentSize is the default total size of binary attachments that
+// webex_messages_get returns with includeBinaryContent (1MB).
+const DefaultMaxBinaryContentSize = 1024 * 1024
+
+// maxBinaryContentSize caps the binary attachment bytes returned per message. Set once
+// at startup via SetMaxBinaryContentSize.
+var maxBinaryContentSize int64 = DefaultMaxBinaryContentSize
+
+// SetMaxBinaryContentSize sets the total size of binary attachments webex_messages_get
+// returns per message with includeBinaryContent. 0 or less disables the option.
+func SetMaxBinaryContentSize(size int64) {
+	maxBinaryContentSize = size
+}
+
 // FileInfo holds metadata (and optionally content) about a message file attachment.
 type FileInfo struct {
 	URL         string `json:"url"`
@@ -22,6 +38,9 @@ type FileInfo struct {
 	ContentType string `json:"contentType,omitempty"`
 	Size        int64  `json:"size,omitempty"`
 	Content     string `json:"content,omitempty"` // populated for text-based files only
+	// ContentBase64 holds a binary file's bytes when requested with includeBinaryContent
+	ContentBase64 string `json:"contentBase64,omitempty"`
+	Note          string `json:"note,omitempty"`
 }
 
 // resolvePersonName returns the displayName for a personID, or "" on failure.
@@ -29,6 +48,7 @@ func resolvePersonName(client *webex.WebexClient, personID string) string {
 	if client == nil || personID == "" {
 		return ""
 	}
+	countEnrichment(client)
 	person, err := client.People().Get(personID)
 	if err != nil {
 		log.Printf("Enrichment: failed to resolve person %s: %v", personID, err)
@@ -49,6 +69,7 @@ func resolveRoomInfo(client *webex.WebexClient, roomID string) *RoomInfo {
 	if roomID == "" {
 		return nil
 	}
+	countEnrichment(client)
 	room, err := client.Rooms().Get(roomID)
 	if err != nil {
 		log.Printf("Enrichment: failed to resolve room %s: %v", roomID, err)
@@ -66,6 +87,7 @@ func resolveTeamName(client *webex.WebexClient, teamID string) string {
 	if client == nil || teamID == "" {
 		return ""
 	}
+	countEnrichment(client)
 	team, err := client.Teams().Get(teamID)
 	if err != nil {
 		log.Printf("Enrichment: failed to resolve team %s: %v", teamID, err)
@@ -110,6 +132,7 @@ func parseContentDisposition(header string) string {
 }
 
 // makeAuthenticatedRequest creates an HTTP request with the Webex auth token.
+// Callers that make it only to enrich a response count it with countEnrichment.
 func makeAuthenticatedRequest(client *webex.WebexClient, method, url string) (*http.Response, error) {
 	req, err := http.NewRequest(method, url, nil)
 	if err != nil {
@@ -126,6 +149,7 @@ func resolveFileMetadata(client *webex.WebexClient, fileURL string) *FileInfo {
 		return nil
 	}
 
+	countEnrichment(client)
 	resp, err := makeAuthenticatedRequest(client, http.MethodHead, fileURL)
 	if err != nil {
 		log.Printf("Enrichment: failed HEAD request for file %s: %v", fileURL, err)
@@ -173,6 +197,7 @@ func resolveFileContent(client *webex.WebexClient, fileURL string) *FileInfo {
 	}
 
 	// GET the content
+	countEnrichment(client)
 	resp, err := makeAuthenticatedRequest(client, http.MethodGet, fileURL)
 	if err != nil {
 		log.Printf("Enrichment: failed GET request for file %s: %v", fileURL, err)
@@ -202,6 +227,117 @@ func resolveFileContent(client *webex.WebexClient, fileURL string) *FileInfo {
 	return info
 }
 
+// loadBinaryContent downloads a binary file of at most maxSize bytes (what remains of
+// the per-message limit). When the file is larger or cannot be read, it returns nil
+// and sets info.Note to say why.
+func loadBinaryContent(client *webex.WebexClient, info *FileInfo, maxSize int64) []byte {
+	tooLarge := fmt.Sprintf("content not included: file is larger than the %d bytes left of the binary content limit", maxSize)
+	if info.Size > maxSize {
+		info.Note = tooLarge
+		return nil
+	}
+
+	countEnrichment(client)
+	resp, err := makeAuthenticatedRequest(client, http.MethodGet, info.URL)
+	if err != nil {
+		log.Printf("Enrichment: failed GET request for file %s: %v", info.URL, err)
+		info.Note = "content not included: download failed"
+		return nil
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		log.Printf("Enrichment: GET request for file %s returned %d", info.URL, resp.StatusCode)
+		info.Note = fmt.Sprintf("content not included: download returned HTTP %d", resp.StatusCode)
+		return nil
+	}
+
+	// The size may be unknown up front, so enforce the limit while reading
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxSize+1))
+	if err != nil {
+		log.Printf("Enrichment: failed to read file %s: %v", info.URL, err)
+		info.Note = "content not included: download failed"
+		return nil
+	}
+	if int64(len(body)) > maxSize {
+		info.Note = tooLarge
+		return nil
+	}
+	return body
+}
+
+// AttachmentInfo describes a message attachment (typically an Adaptive Card).
+type AttachmentInfo struct {
+	ContentType string      `json:"contentType"`
+	Content     interface{} `json:"content,omitempty"`
+	Inputs      []CardInput `json:"inputs,omitempty"` // Input.* elements found in the card body
+}
+
+// CardInput describes an input element in an Adaptive Card, e.g. a poll question.
+type CardInput struct {
+	ID      string   `json:"id,omitempty"`
+	Type    string   `json:"type"`
+	Label   string   `json:"label,omitempty"`
+	Choices []string `json:"choices,omitempty"`
+}
+
+// summarizeAttachments converts message attachments into AttachmentInfo, extracting the
+// input elements of any Adaptive Card so the card's structure is easy to reason about.
+func summarizeAttachments(atts []messages.Attachment) []*AttachmentInfo {
+	if len(atts) == 0 {
+		return nil
+	}
+	infos := make([]*AttachmentInfo, 0, len(atts))
+	for _, att := range atts {
+		info := &AttachmentInfo{
+			ContentType: att.ContentType,
+			Content:     att.Content,
+		}
+		collectCardInputs(att.Content, &info.Inputs)
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// collectCardInputs recursively walks a parsed card and appends every Input.* element.
+func collectCardInputs(node interface{}, out *[]CardInput) {
+	switch v := node.(type) {
+	case map[string]interface{}:
+		if t, _ := v["type"].(string); strings.HasPrefix(t, "Input.") {
+			in := CardInput{Type: t}
+			in.ID, _ = v["id"].(string)
+			if label, ok := v["label"].(string); ok && label != "" {
+				in.Label = label
+			} else {
+				in.Label, _ = v["placeholder"].(string)
+			}
+			if choices, ok := v["choices"].([]interface{}); ok {
+				for _, c := range choices {
+					if cm, ok := c.(map[string]interface{}); ok {
+						if title, ok := cm["title"].(string); ok {
+							in.Choices = append(in.Choices, title)
+						}
+					}
+				}
+			}
+			*out = append(*out, in)
+		}
+		// Walk keys in sorted order so the result is deterministic.
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			collectCardInputs(v[k], out)
+		}
+	case []interface{}:
+		for _, item := range v {
+			collectCardInputs(item, out)
+		}
+	}
+}
+
 // PersonNameCache is a simple cache for person ID -> display name lookups to avoid redundant API calls.
 type PersonNameCache struct {
 	client *webex.WebexClient
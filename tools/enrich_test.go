@@ -1,7 +1,14 @@
 package tools
 
 import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+
+	webex "github.com/WebexCommunity/webex-go-sdk/v2"
+	"github.com/WebexCommunity/webex-go-sdk/v2/messages"
+	"github.com/WebexCommunity/webex-go-sdk/v2/webexsdk"
 )
 
 func TestIsTextContentType(t *testing.T) {
@@ -57,3 +64,105 @@ func TestTeamNameCache_NilClient(t *testing.T) {
 		t.Errorf("Resolve(unknown-id) = %q, want \"\"", got)
 	}
 }
+
+func TestSummarizeAttachments(t *testing.T) {
+	if got := summarizeAttachments(nil); got != nil {
+		t.Errorf("summarizeAttachments(nil) = %v, want nil", got)
+	}
+
+	card := map[string]interface{}{
+		"type":    "AdaptiveCard",
+		"version": "1.3",
+		"body": []interface{}{
+			map[string]interface{}{"type": "TextBlock", "text": "Lunch poll"},
+			map[string]interface{}{
+				"type":  "Input.ChoiceSet",
+				"id":    "lunch",
+				"label": "Where should we eat?",
+				"choices": []interface{}{
+					map[string]interface{}{"title": "Tacos", "value": "tacos"},
+					map[string]interface{}{"title": "Sushi", "value": "sushi"},
+				},
+			},
+			map[string]interface{}{"type": "Input.Text", "id": "notes", "placeholder": "Anything else?"},
+		},
+	}
+	got := summarizeAttachments([]messages.Attachment{
+		{ContentType: "application/vnd.microsoft.card.adaptive", Content: card},
+	})
+	if len(got) != 1 {
+		t.Fatalf("len = %d, want 1", len(got))
+	}
+	inputs := got[0].Inputs
+	if len(inputs) != 2 {
+		t.Fatalf("inputs = %+v, want 2 entries", inputs)
+	}
+	if inputs[0].ID != "lunch" || inputs[0].Label != "Where should we eat?" || len(inputs[0].Choices) != 2 || inputs[0].Choices[1] != "Sushi" {
+		t.Errorf("inputs[0] = %+v", inputs[0])
+	}
+	if inputs[1].ID != "notes" || inputs[1].Type != "Input.Text" || inputs[1].Label != "Anything else?" {
+		t.Errorf("inputs[1] = %+v", inputs[1])
+	}
+}
+
+func TestLoadBinaryContent(t *testing.T) {
+	png := []byte("\x89PNG\r\n\x1a\nfake image bytes")
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "image/png")
+		w.Write(png)
+	}))
+	defer srv.Close()
+
+	client, err := webex.NewClient("test-token", &webexsdk.Config{BaseURL: srv.URL})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	t.Run("within limit", func(t *testing.T) {
+		info := &FileInfo{URL: srv.URL + "/file", Size: int64(len(png))}
+		if got := loadBinaryContent(client, info, 1024); string(got) != string(png) {
+			t.Errorf("content = %q, want %q", got, png)
+		}
+		if info.Note != "" {
+			t.Errorf("unexpected note %q", info.Note)
+		}
+	})
+
+	t.Run("known size over limit is not downloaded", func(t *testing.T) {
+		info := &FileInfo{URL: srv.URL + "/file", Size: 4096}
+		if got := loadBinaryContent(client, info, 1024); got != nil {
+			t.Error("expected no content")
+		}
+		if !strings.Contains(info.Note, "1024 bytes") {
+			t.Errorf("note = %q, want it to mention the limit", info.Note)
+		}
+	})
+
+	t.Run("unknown size over limit", func(t *testing.T) {
+		info := &FileInfo{URL: srv.URL + "/file"}
+		if got := loadBinaryContent(client, info, 4); got != nil {
+			t.Error("expected no content")
+		}
+		if info.Note == "" {
+			t.Error("expected a note")
+		}
+	})
+
+	t.Run("download error", func(t *testing.T) {
+		info := &FileInfo{URL: srv.URL + "/missing"}
+		if got := loadBinaryContent(client, info, 1024); got != nil {
+			t.Error("expected no content")
+		}
+		if !strings.Contains(info.Note, "404") {
+			t.Errorf("note = %q, want the HTTP status", info.Note)
+		}
+	})
+}
@@ -154,10 +154,10 @@ var (
 	// PresetMinimal includes all tools for messages, rooms, teams, meetings, and transcripts.
 	// Excludes memberships and webhooks.
 	PresetMinimal = []string{
-		"webex_messages_list", "webex_messages_create", "webex_messages_send_attachment", "webex_messages_send_adaptive_card", "webex_messages_get", "webex_messages_delete",
-		"webex_rooms_list", "webex_rooms_create", "webex_rooms_get", "webex_rooms_update", "webex_rooms_delete",
-		"webex_teams_list", "webex_teams_create", "webex_teams_get", "webex_teams_update",
-		"webex_meetings_list", "webex_meetings_create", "webex_meetings_get", "webex_meetings_update", "webex_meetings_delete", "webex_meetings_list_participants",
+		"webex_messages_list", "webex_messages_create", "webex_messages_send_attachment", "webex_messages_send_adaptive_card", "webex_messages_get", "webex_messages_get_thread", "webex_messages_delete",
+		"webex_rooms_list", "webex_rooms_create", "webex_rooms_get", "webex_rooms_update", "webex_rooms_delete", "webex_rooms_watch_changes", "webex_rooms_catalog", "webex_rooms_membership_breakdown", "webex_rooms_digest", "webex_rooms_suggest",
+		"webex_teams_list", "webex_teams_create", "webex_teams_get", "webex_teams_update", "webex_teams_catalog",
+		"webex_meetings_list", "webex_meetings_create", "webex_meetings_get", "webex_meetings_update", "webex_meetings_delete", "webex_meetings_end", "webex_meetings_list_participants", "webex_meetings_list_invitations", "webex_meetings_get_invite", "webex_meetings_reschedule", "webex_meetings_find_conflicts", "webex_meetings_get_chat", "webex_meetings_attendance",
 		"webex_transcripts_list", "webex_transcripts_download", "webex_transcripts_list_snippets", "webex_transcripts_get_snippet", "webex_transcripts_update_snippet",
 		"webex_subscribe_room_messages", "webex_unsubscribe", "webex_wait_for_message", "webex_list_subscriptions",
 		"webex_fetch_next_page",
@@ -166,10 +166,10 @@ var (
 	// PresetReadonlyMinimal includes only read/GET tools for messages, rooms, teams, meetings, and transcripts.
 	// No create, update, or delete operations.
 	PresetReadonlyMinimal = []string{
-		"webex_messages_list", "webex_messages_get",
-		"webex_rooms_list", "webex_rooms_get",
-		"webex_teams_list", "webex_teams_get",
-		"webex_meetings_list", "webex_meetings_get", "webex_meetings_list_participants",
+		"webex_messages_list", "webex_messages_get", "webex_messages_get_thread",
+		"webex_rooms_list", "webex_rooms_get", "webex_rooms_watch_changes", "webex_rooms_catalog", "webex_rooms_membership_breakdown", "webex_rooms_suggest",
+		"webex_teams_list", "webex_teams_get", "webex_teams_catalog",
+		"webex_meetings_list", "webex_meetings_get", "webex_meetings_list_participants", "webex_meetings_list_invitations", "webex_meetings_get_invite", "webex_meetings_find_conflicts", "webex_meetings_get_chat", "webex_meetings_attendance",
 		"webex_transcripts_list", "webex_transcripts_download", "webex_transcripts_list_snippets", "webex_transcripts_get_snippet",
 		"webex_subscribe_room_messages", "webex_unsubscribe", "webex_wait_for_message", "webex_list_subscriptions",
 		"webex_fetch_next_page",
@@ -9,11 +9,16 @@ import (
 )
 
 type mockRegistrar struct {
-	count int
+	count    int
+	handlers map[string]server.ToolHandlerFunc
 }
 
 func (m *mockRegistrar) AddTool(tool mcp.Tool, handler server.ToolHandlerFunc) {
 	m.count++
+	if m.handlers == nil {
+		m.handlers = make(map[string]server.ToolHandlerFunc)
+	}
+	m.handlers[tool.Name] = handler
 }
 
 func TestNewToolFilter_EmptyStrings(t *testing.T) {
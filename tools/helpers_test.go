@@ -0,0 +1,74 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	webex "github.com/WebexCommunity/webex-go-sdk/v2"
+	"github.com/WebexCommunity/webex-go-sdk/v2/webexsdk"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/tejzpr/webex-go-mcp/auth"
+)
+
+// callTool registers a tool group against a client for baseURL and calls the named tool with args.
+func callTool(t *testing.T, register func(ToolRegistrar, auth.ClientResolver), baseURL, name string, args map[string]interface{}) *mcp.CallToolResult {
+	t.Helper()
+	client, err := webex.NewClient("test-token", &webexsdk.Config{BaseURL: baseURL})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	reg := &mockRegistrar{}
+	register(reg, auth.NewStaticClientResolver(client))
+	handler, ok := reg.handlers[name]
+	if !ok {
+		t.Fatalf("tool %s is not registered", name)
+	}
+	req := mcp.CallToolRequest{}
+	req.Params.Name = name
+	req.Params.Arguments = args
+	result, err := handler(context.Background(), req)
+	if err != nil {
+		t.Fatalf("%s: %v", name, err)
+	}
+	return result
+}
+
+// resultText returns the text of a tool result's first content block.
+func resultText(result *mcp.CallToolResult) string {
+	if len(result.Content) == 0 {
+		return ""
+	}
+	text, _ := result.Content[0].(mcp.TextContent)
+	return text.Text
+}
+
+// newCaptureServer serves a single API path for handler tests. GET requests get
+// getBody (405 if it is empty). Any other request has its JSON body decoded into
+// *captured and is answered with the status and JSON body that reply returns for it.
+func newCaptureServer(t *testing.T, path, getBody string, captured *map[string]interface{}, reply func(body map[string]interface{}) (int, interface{})) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != path {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodGet {
+			if getBody == "" {
+				http.Error(w, "unexpected method", http.StatusMethodNotAllowed)
+				return
+			}
+			w.Write([]byte(getBody))
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(captured); err != nil {
+			t.Errorf("decode %s body: %v", r.Method, err)
+		}
+		status, body := reply(*captured)
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(body)
+	}))
+}
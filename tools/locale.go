@@ -0,0 +1,121 @@
+package tools
+
+import (
+	"fmt"
+	"sync"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// outputLocale is the locale used for human-readable fields such as sizeHuman and
+// durationHuman. It is set once at startup via SetOutputLocale; while unset, output is
+// plain English with no digit grouping, as it was before locales were supported.
+var (
+	outputLocaleMu  sync.RWMutex
+	outputLocale    language.Tag
+	outputLocaleSet bool
+)
+
+// Message keys for localized human-readable units.
+const (
+	msgBytes   = "%d B"
+	msgKB      = "%.1f KB"
+	msgMB      = "%.1f MB"
+	msgSeconds = "%d seconds"
+	msgMinutes = "%.1f minutes"
+	msgHours   = "%.1f hours"
+)
+
+// unitTranslations holds the non-English unit strings. Numbers inside them are
+// formatted by the printer with the locale's own decimal and grouping separators.
+var unitTranslations = map[language.Tag]map[string]string{
+	language.German: {
+		msgSeconds: "%d Sekunden",
+		msgMinutes: "%.1f Minuten",
+		msgHours:   "%.1f Stunden",
+	},
+	language.French: {
+		msgBytes:   "%d o",
+		msgKB:      "%.1f Ko",
+		msgMB:      "%.1f Mo",
+		msgSeconds: "%d secondes",
+		msgMinutes: "%.1f minutes",
+		msgHours:   "%.1f heures",
+	},
+	language.Spanish: {
+		msgSeconds: "%d segundos",
+		msgMinutes: "%.1f minutos",
+		msgHours:   "%.1f horas",
+	},
+	language.Portuguese: {
+		msgSeconds: "%d segundos",
+		msgMinutes: "%.1f minutos",
+		msgHours:   "%.1f horas",
+	},
+}
+
+func init() {
+	for tag, msgs := range unitTranslations {
+		for key, translation := range msgs {
+			_ = message.SetString(tag, key, translation)
+		}
+	}
+}
+
+// SetOutputLocale sets the locale for human-readable output from a BCP 47 tag
+// (e.g. "en", "de-DE", "fr"). Unsupported languages fall back to English units
+// but still use the locale's number formatting. An empty tag restores the default
+// plain formatting.
+func SetOutputLocale(tag string) error {
+	if tag == "" {
+		outputLocaleMu.Lock()
+		outputLocale, outputLocaleSet = language.Tag{}, false
+		outputLocaleMu.Unlock()
+		return nil
+	}
+	t, err := language.Parse(tag)
+	if err != nil {
+		return fmt.Errorf("invalid locale %q: %w", tag, err)
+	}
+	outputLocaleMu.Lock()
+	outputLocale, outputLocaleSet = t, true
+	outputLocaleMu.Unlock()
+	return nil
+}
+
+// localeSprintf formats a unit message in the configured output locale, or with
+// plain fmt.Sprintf when no locale is set.
+func localeSprintf(key string, a ...interface{}) string {
+	outputLocaleMu.RLock()
+	tag, set := outputLocale, outputLocaleSet
+	outputLocaleMu.RUnlock()
+	if !set {
+		return fmt.Sprintf(key, a...)
+	}
+	return message.NewPrinter(tag).Sprintf(key, a...)
+}
+
+// formatByteSize renders a byte count as B, KB, or MB in the output locale.
+func formatByteSize(bytes int64) string {
+	switch {
+	case bytes < 1024:
+		return localeSprintf(msgBytes, bytes)
+	case bytes < 1024*1024:
+		return localeSprintf(msgKB, float64(bytes)/1024)
+	default:
+		return localeSprintf(msgMB, float64(bytes)/(1024*1024))
+	}
+}
+
+// formatDuration renders a duration in seconds as seconds, minutes, or hours in the output locale.
+func formatDuration(seconds int) string {
+	switch {
+	case seconds < 60:
+		return localeSprintf(msgSeconds, seconds)
+	case seconds < 3600:
+		return localeSprintf(msgMinutes, float64(seconds)/60)
+	default:
+		return localeSprintf(msgHours, float64(seconds)/3600)
+	}
+}
@@ -0,0 +1,39 @@
+package tools
+
+import "testing"
+
+func TestLocalizedFormatting(t *testing.T) {
+	defer func() { _ = SetOutputLocale("") }()
+
+	tests := []struct {
+		locale   string
+		bytes    int64
+		seconds  int
+		wantSize string
+		wantDur  string
+	}{
+		{"", 1572864, 8280, "1.5 MB", "2.3 hours"},
+		{"", 1572864000, 36000000, "1500.0 MB", "10000.0 hours"}, // default: no digit grouping, as before locales
+		{"en", 1572864000, 8280, "1,500.0 MB", "2.3 hours"},
+		{"en", 1572864, 8280, "1.5 MB", "2.3 hours"},
+		{"en", 512, 45, "512 B", "45 seconds"},
+		{"de", 1572864, 8280, "1,5 MB", "2,3 Stunden"},
+		{"fr", 1572864, 90, "1,5 Mo", "1,5 minutes"},
+		{"ja", 2048, 8280, "2.0 KB", "2.3 hours"},
+	}
+	for _, tt := range tests {
+		if err := SetOutputLocale(tt.locale); err != nil {
+			t.Fatalf("SetOutputLocale(%q) error: %v", tt.locale, err)
+		}
+		if got := formatByteSize(tt.bytes); got != tt.wantSize {
+			t.Errorf("[%s] formatByteSize(%d) = %q, want %q", tt.locale, tt.bytes, got, tt.wantSize)
+		}
+		if got := formatDuration(tt.seconds); got != tt.wantDur {
+			t.Errorf("[%s] formatDuration(%d) = %q, want %q", tt.locale, tt.seconds, got, tt.wantDur)
+		}
+	}
+
+	if err := SetOutputLocale("not a locale!"); err == nil {
+		t.Error("SetOutputLocale() accepted an invalid tag")
+	}
+}
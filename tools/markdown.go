@@ -0,0 +1,152 @@
+package tools
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// markdownTextFallback controls whether webex_messages_create derives a plain-text
+// version of markdown-only messages. Set once at startup via SetMarkdownTextFallback.
+var markdownTextFallback = true
+
+// SetMarkdownTextFallback enables or disables sending a plain-text fallback derived
+// from the markdown when a message has markdown but no text.
+func SetMarkdownTextFallback(enabled bool) {
+	markdownTextFallback = enabled
+}
+
+// Modes for webex_messages_create when text contains markdown syntax and no
+// markdown was given.
+const (
+	// MarkdownAutodetectOff sends the text as-is.
+	MarkdownAutodetectOff = "off"
+	// MarkdownAutodetectWarn sends the text as-is and returns a warning.
+	MarkdownAutodetectWarn = "warn"
+	// MarkdownAutodetectRoute sends the text as markdown so it renders.
+	MarkdownAutodetectRoute = "route"
+)
+
+// markdownAutodetect is the active mode. Set once at startup via SetMarkdownAutodetect.
+var markdownAutodetect = MarkdownAutodetectWarn
+
+// SetMarkdownAutodetect sets how webex_messages_create handles markdown syntax
+// passed in the plain-text field: "off", "warn" or "route".
+func SetMarkdownAutodetect(mode string) error {
+	switch mode {
+	case MarkdownAutodetectOff, MarkdownAutodetectWarn, MarkdownAutodetectRoute:
+		markdownAutodetect = mode
+		return nil
+	}
+	return fmt.Errorf("invalid markdown autodetect mode %q: must be 'off', 'warn' or 'route'", mode)
+}
+
+var (
+	mdFence         = regexp.MustCompile("^\\s*(```|~~~)")
+	mdHeading       = regexp.MustCompile(`^\s{0,3}#{1,6}\s+`)
+	mdBlockquote    = regexp.MustCompile(`^\s{0,3}(>\s?)+`)
+	mdBullet        = regexp.MustCompile(`^(\s*)[*+-]\s+`)
+	mdRule          = regexp.MustCompile(`^\s{0,3}([-*_]\s*){3,}$`)
+	mdImage         = regexp.MustCompile(`!\[([^\]]*)\]\([^)]*\)`)
+	mdLink          = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)[^)]*\)`)
+	mdInlineCode    = regexp.MustCompile("`([^`]+)`")
+	mdBold          = regexp.MustCompile(`(\*\*|__)(\S(?:.*?\S)?)(\*\*|__)`)
+	mdStrike        = regexp.MustCompile(`~~(\S(?:.*?\S)?)~~`)
+	mdItalicStar    = regexp.MustCompile(`\*(\S(?:[^*]*?\S)?)\*`)
+	mdItalicUnder   = regexp.MustCompile(`(^|[^\w])_(\S(?:[^_]*?\S)?)_([^\w]|$)`)
+	mdMentionNamed  = regexp.MustCompile(`<@person(?:Id|Email):[^|>]+\|([^>]+)>`)
+	mdMentionEmail  = regexp.MustCompile(`<@personEmail:([^>]+)>`)
+	mdMentionAll    = regexp.MustCompile(`<@all>`)
+	mdExtraNewlines = regexp.MustCompile(`\n{3,}`)
+)
+
+// looksLikeMarkdown reports whether text uses markdown constructs that are
+// unlikely in plain prose: code, headings, links, bold or strikethrough. Single
+// '*' or '_' emphasis and '-' bullets are ignored, as plain text uses them too.
+func looksLikeMarkdown(text string) bool {
+	for _, line := range strings.Split(text, "\n") {
+		if mdFence.MatchString(line) || mdHeading.MatchString(line) {
+			return true
+		}
+	}
+	for _, re := range []*regexp.Regexp{mdLink, mdInlineCode, mdBold, mdStrike} {
+		if re.MatchString(text) {
+			return true
+		}
+	}
+	return false
+}
+
+// autodetectMarkdown applies the configured autodetect mode to a message whose
+// markdown is empty and whose text looks like markdown, updating text and markdown
+// in place. It returns a note for the caller, or "" if nothing was detected.
+func autodetectMarkdown(mode string, text, markdown *string) string {
+	if mode == MarkdownAutodetectOff || *markdown != "" || !looksLikeMarkdown(*text) {
+		return ""
+	}
+	if mode == MarkdownAutodetectRoute {
+		*markdown, *text = *text, ""
+		return "text contained markdown formatting, so it was sent as markdown"
+	}
+	return "text contains markdown formatting but was sent as plain text, so the formatting characters appear literally; pass it as markdown to render it"
+}
+
+// markdownToText strips Webex markdown formatting, keeping the visible text. Links
+// become "text (url)", mentions become "@Name", and code block contents are kept as-is.
+func markdownToText(markdown string) string {
+	lines := strings.Split(strings.ReplaceAll(markdown, "\r\n", "\n"), "\n")
+	out := make([]string, 0, len(lines))
+	inCode := false
+	for _, line := range lines {
+		if mdFence.MatchString(line) {
+			inCode = !inCode
+			continue
+		}
+		if inCode {
+			out = append(out, line)
+			continue
+		}
+		if mdRule.MatchString(line) {
+			out = append(out, "")
+			continue
+		}
+		line = mdHeading.ReplaceAllString(line, "")
+		line = mdBlockquote.ReplaceAllString(line, "")
+		line = mdBullet.ReplaceAllString(line, "$1- ")
+		out = append(out, stripInlineMarkdown(line))
+	}
+	text := strings.Join(out, "\n")
+	return strings.TrimSpace(mdExtraNewlines.ReplaceAllString(text, "\n\n"))
+}
+
+// stripInlineMarkdown removes inline formatting (emphasis, code, links, mentions) from one line.
+func stripInlineMarkdown(line string) string {
+	// Protect inline code so its contents are not treated as formatting
+	var code []string
+	line = mdInlineCode.ReplaceAllStringFunc(line, func(m string) string {
+		code = append(code, mdInlineCode.FindStringSubmatch(m)[1])
+		return "\x00" + strconv.Itoa(len(code)-1) + "\x00"
+	})
+
+	line = mdMentionNamed.ReplaceAllString(line, "@$1")
+	line = mdMentionEmail.ReplaceAllString(line, "@$1")
+	line = mdMentionAll.ReplaceAllString(line, "@all")
+	line = mdImage.ReplaceAllString(line, "$1")
+	line = mdLink.ReplaceAllStringFunc(line, func(m string) string {
+		sub := mdLink.FindStringSubmatch(m)
+		if sub[1] == sub[2] {
+			return sub[2]
+		}
+		return sub[1] + " (" + sub[2] + ")"
+	})
+	line = mdBold.ReplaceAllString(line, "$2")
+	line = mdStrike.ReplaceAllString(line, "$1")
+	line = mdItalicStar.ReplaceAllString(line, "$1")
+	line = mdItalicUnder.ReplaceAllString(line, "$1$2$3")
+
+	for i, c := range code {
+		line = strings.Replace(line, "\x00"+strconv.Itoa(i)+"\x00", c, 1)
+	}
+	return line
+}
@@ -0,0 +1,91 @@
+package tools
+
+import "testing"
+
+func TestMarkdownToText(t *testing.T) {
+	tests := []struct {
+		name     string
+		markdown string
+		want     string
+	}{
+		{"emphasis", "**Deploy** is _done_ and ~~late~~ *ok*", "Deploy is done and late ok"},
+		{"snake_case survives", "set max_results_per_page", "set max_results_per_page"},
+		{"heading and list", "## Release notes\n- **fixed** bug\n* added `--flag`", "Release notes\n- fixed bug\n- added --flag"},
+		{"links", "See [the doc](https://example.com/doc) or https://example.com", "See the doc (https://example.com/doc) or https://example.com"},
+		{"bare link text", "[https://example.com](https://example.com)", "https://example.com"},
+		{"inline code keeps stars", "run `a*b*c` now", "run a*b*c now"},
+		{"code block", "before\n```go\nx := **y**\n```\nafter", "before\nx := **y**\nafter"},
+		{"quote and rule", "> quoted\n\n---\n\n\n\nend", "quoted\n\nend"},
+		{"mentions", "<@personId:Y2lz|Alice>, <@personEmail:bob@example.com> and <@all> please review", "@Alice, @bob@example.com and @all please review"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := markdownToText(tt.markdown); got != tt.want {
+				t.Errorf("markdownToText(%q) = %q, want %q", tt.markdown, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLooksLikeMarkdown(t *testing.T) {
+	tests := []struct {
+		text string
+		want bool
+	}{
+		{"Deploy is **done**", true},
+		{"## Status\nall good", true},
+		{"see [doc](https://example.com/doc)", true},
+		{"run `make test`", true},
+		{"```\ncode\n```", true},
+		{"~~cancelled~~", true},
+		{"plain message", false},
+		{"set max_results_per_page", false},
+		{"- one\n- two", false},
+		{"2 * 3 * 4 = 24", false},
+	}
+	for _, tt := range tests {
+		if got := looksLikeMarkdown(tt.text); got != tt.want {
+			t.Errorf("looksLikeMarkdown(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestAutodetectMarkdown(t *testing.T) {
+	tests := []struct {
+		name         string
+		mode         string
+		text         string
+		markdown     string
+		wantText     string
+		wantMarkdown string
+		wantNote     bool
+	}{
+		{"off", MarkdownAutodetectOff, "**hi**", "", "**hi**", "", false},
+		{"warn", MarkdownAutodetectWarn, "**hi**", "", "**hi**", "", true},
+		{"route", MarkdownAutodetectRoute, "**hi**", "", "", "**hi**", true},
+		{"plain text untouched", MarkdownAutodetectRoute, "hi", "", "hi", "", false},
+		{"markdown already given", MarkdownAutodetectRoute, "**hi**", "**hi**", "**hi**", "**hi**", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, markdown := tt.text, tt.markdown
+			note := autodetectMarkdown(tt.mode, &text, &markdown)
+			if text != tt.wantText || markdown != tt.wantMarkdown {
+				t.Errorf("got text=%q markdown=%q, want text=%q markdown=%q", text, markdown, tt.wantText, tt.wantMarkdown)
+			}
+			if (note != "") != tt.wantNote {
+				t.Errorf("note = %q, want note: %v", note, tt.wantNote)
+			}
+		})
+	}
+}
+
+func TestSetMarkdownAutodetect(t *testing.T) {
+	defer SetMarkdownAutodetect(MarkdownAutodetectWarn)
+	if err := SetMarkdownAutodetect("route"); err != nil {
+		t.Errorf("route rejected: %v", err)
+	}
+	if err := SetMarkdownAutodetect("always"); err == nil {
+		t.Error("expected an error for an unknown mode")
+	}
+}
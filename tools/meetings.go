@@ -5,12 +5,20 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"net/http"
+	"net/url"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	webex "github.com/WebexCommunity/webex-go-sdk/v2"
 	"github.com/WebexCommunity/webex-go-sdk/v2/meetings"
+	"github.com/WebexCommunity/webex-go-sdk/v2/memberships"
 	"github.com/WebexCommunity/webex-go-sdk/v2/transcripts"
+	"github.com/WebexCommunity/webex-go-sdk/v2/webexsdk"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/tejzpr/webex-go-mcp/auth"
 )
@@ -48,8 +56,711 @@ func validateAndConvertISO8601(dateStr, fieldName string) (string, error) {
 	return "", fmt.Errorf("invalid %s format: must be UTC format 'YYYY-MM-DDTHH:MM:SSZ' (e.g., '2026-01-01T00:00:00Z') or 'YYYY-MM-DDTHH:MM' (e.g., '2026-01-01T00:00')", fieldName)
 }
 
+// meetingSite is a Webex site the user can schedule meetings on.
+type meetingSite struct {
+	SiteURL string `json:"siteUrl"`
+	Default bool   `json:"default"`
+}
+
+// listMeetingSites returns the user's Webex sites from the meeting preferences API.
+func listMeetingSites(client *webex.WebexClient) ([]meetingSite, error) {
+	resp, err := client.Core().Request(http.MethodGet, "meetingPreferences/sites", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	var result struct {
+		Sites []meetingSite `json:"sites"`
+	}
+	if err := webexsdk.ParseResponse(resp, &result); err != nil {
+		return nil, err
+	}
+	return result.Sites, nil
+}
+
+// normalizeSiteURL strips the scheme, trailing slash, and case from a site URL
+// so 'https://Example.webex.com/' and 'example.webex.com' compare equal.
+func normalizeSiteURL(siteURL string) string {
+	siteURL = strings.TrimSpace(strings.ToLower(siteURL))
+	siteURL = strings.TrimPrefix(siteURL, "https://")
+	siteURL = strings.TrimPrefix(siteURL, "http://")
+	return strings.TrimSuffix(siteURL, "/")
+}
+
+// matchMeetingSite finds the requested site among the user's sites, returning the
+// site URL exactly as Webex reports it.
+func matchMeetingSite(sites []meetingSite, requested string) (string, bool) {
+	want := normalizeSiteURL(requested)
+	for _, site := range sites {
+		if normalizeSiteURL(site.SiteURL) == want {
+			return site.SiteURL, true
+		}
+	}
+	return "", false
+}
+
+// isMeetingLive reports whether a meeting instance is currently in progress.
+// Scheduled series report "active", which is not the same as a started meeting.
+func isMeetingLive(state string) bool {
+	return strings.EqualFold(state, "inProgress")
+}
+
+// endMeeting ends a live meeting for all participants via the meetings end API.
+func endMeeting(client *webex.WebexClient, meetingID string) error {
+	resp, err := client.Core().Request(http.MethodPost, "meetings/"+meetingID+"/end", nil, nil)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= 400 {
+		return webexsdk.ParseResponse(resp, nil)
+	}
+	_ = resp.Body.Close()
+	return nil
+}
+
+// meetingCoHost is a co-host of a meeting as surfaced by webex_meetings_get.
+type meetingCoHost struct {
+	Email       string `json:"email"`
+	DisplayName string `json:"displayName,omitempty"`
+}
+
+// meetingInviteeCap is the maximum number of invitees read for one meeting or series.
+const meetingInviteeCap = 1000
+
+// listMeetingInvitees returns the invitees of a meeting from the meeting invitees API,
+// reading up to meetingInviteeCap. truncated and err are as for FetchAll: if a later
+// page fails to load, err is set and invitees holds those read before it.
+func listMeetingInvitees(client *webex.WebexClient, meetingID string) (invitees []meetings.Invitee, truncated bool, err error) {
+	// The SDK has no invitee listing, so the first page is requested directly
+	params := url.Values{}
+	params.Set("meetingId", meetingID)
+	params.Set("max", strconv.Itoa(CatalogPageSize))
+	page, err := FetchPage(client, client.Core().BaseURL.String()+"/meetingInvitees?"+params.Encode())
+	if err != nil {
+		return nil, false, err
+	}
+	items, err := UnmarshalPageItems[meetings.Invitee](page)
+	if err != nil {
+		return nil, false, err
+	}
+	return FetchAll(items, page.HasNext, page.NextPage, client, meetingInviteeCap)
+}
+
+// inviteeListNote explains an incomplete invitee list, or returns "" if it is complete.
+func inviteeListNote(truncated bool, err error) string {
+	if err != nil {
+		return fmt.Sprintf("The invitee list could not be read in full (%v); some invitees may be missing.", err)
+	}
+	if truncated {
+		return fmt.Sprintf("Only the first %d invitees are included; the meeting has more.", meetingInviteeCap)
+	}
+	return ""
+}
+
+// coHostsFromInvitees returns the invitees flagged as co-hosts.
+func coHostsFromInvitees(invitees []meetings.Invitee) []meetingCoHost {
+	coHosts := make([]meetingCoHost, 0)
+	for _, inv := range invitees {
+		if inv.CoHost {
+			coHosts = append(coHosts, meetingCoHost{Email: inv.Email, DisplayName: inv.DisplayName})
+		}
+	}
+	return coHosts
+}
+
+// resolveCoHostNames fills in missing display names by looking up co-host emails.
+// Lookup failures are logged and leave the name empty.
+func resolveCoHostNames(client *webex.WebexClient, coHosts []meetingCoHost) {
+	var emails []string
+	var idx []int
+	for i, c := range coHosts {
+		if c.DisplayName == "" && c.Email != "" {
+			emails = append(emails, c.Email)
+			idx = append(idx, i)
+		}
+	}
+	if len(emails) == 0 {
+		return
+	}
+	for i, r := range lookupPeopleByEmail(client, emails) {
+		switch {
+		case r.err != nil:
+			log.Printf("Enrichment: failed to resolve co-host %s: %v", emails[i], r.err)
+		case r.person != nil:
+			coHosts[idx[i]].DisplayName = r.person.DisplayName
+		}
+	}
+}
+
+// summarizeMeetingSecurity groups a meeting's entry and security settings into one object.
+// Fields the API did not return are reported as their zero value, except the
+// waiting room, which is omitted when unlockedMeetingJoinSecurity is absent.
+func summarizeMeetingSecurity(m *meetings.Meeting) map[string]interface{} {
+	joinBeforeHost := map[string]interface{}{
+		"enabled":                m.EnabledJoinBeforeHost,
+		"connectAudioBeforeHost": m.EnableConnectAudioBeforeHost,
+	}
+	if m.EnabledJoinBeforeHost {
+		joinBeforeHost["minutesBeforeStart"] = m.JoinBeforeHostMinutes
+	}
+
+	automaticLock := map[string]interface{}{
+		"enabled": m.EnableAutomaticLock,
+	}
+	if m.EnableAutomaticLock {
+		automaticLock["minutesAfterStart"] = m.AutomaticLockMinutes
+	}
+
+	security := map[string]interface{}{
+		"joinBeforeHost":           joinBeforeHost,
+		"automaticLock":            automaticLock,
+		"requireAttendeeLogin":     m.RequireAttendeeLogin,
+		"restrictToInvitees":       m.RestrictToInvitees,
+		"allowAnyUserToBeCoHost":   m.AllowAnyUserToBeCoHost,
+		"allowFirstUserToBeCoHost": m.AllowFirstUserToBeCoHost,
+	}
+	if m.UnlockedMeetingJoinSecurity != "" {
+		security["waitingRoom"] = map[string]interface{}{
+			"enabled":                     m.UnlockedMeetingJoinSecurity == "allowJoinWithLobby",
+			"unlockedMeetingJoinSecurity": m.UnlockedMeetingJoinSecurity,
+		}
+	}
+	return security
+}
+
+const (
+	// defaultInvitationWindow is how far ahead webex_meetings_list_invitations looks by default.
+	defaultInvitationWindow = 7 * 24 * time.Hour
+
+	// maxInvitationWindow is the widest from/to range webex_meetings_list_invitations accepts.
+	maxInvitationWindow = 30 * 24 * time.Hour
+
+	// invitationScanCap is the maximum number of meetings webex_meetings_list_invitations
+	// examines; meetings the user hosts are filtered out of these, so it is well above maxResults.
+	invitationScanCap = 1000
+)
+
+// filterInvitedMeetings returns the meetings hosted by someone other than the user
+// (identified by personID or any of their emails), sorted by start time.
+func filterInvitedMeetings(items []meetings.Meeting, personID string, emails []string) []meetings.Meeting {
+	mine := make(map[string]bool, len(emails))
+	for _, e := range emails {
+		mine[strings.ToLower(e)] = true
+	}
+
+	invited := make([]meetings.Meeting, 0, len(items))
+	for _, m := range items {
+		if personID != "" && m.HostUserID == personID {
+			continue
+		}
+		if mine[strings.ToLower(m.HostEmail)] {
+			continue
+		}
+		invited = append(invited, m)
+	}
+	sort.SliceStable(invited, func(i, j int) bool {
+		return invited[i].Start < invited[j].Start
+	})
+	return invited
+}
+
+// conflictScanCap is the maximum number of meetings webex_meetings_find_conflicts examines.
+const conflictScanCap = 1000
+
+// conflictMeeting identifies one side of an overlapping pair.
+type conflictMeeting struct {
+	MeetingID string `json:"meetingId"`
+	Title     string `json:"title"`
+	Start     string `json:"start"`
+	End       string `json:"end"`
+}
+
+// meetingConflict is a pair of meetings whose times overlap.
+type meetingConflict struct {
+	First          conflictMeeting `json:"first"`
+	Second         conflictMeeting `json:"second"`
+	OverlapMinutes int             `json:"overlapMinutes"`
+}
+
+// findMeetingConflicts returns every pair of meetings whose [start, end) intervals overlap,
+// ordered by the first meeting's start. Cancelled meetings and meetings without valid times are skipped.
+func findMeetingConflicts(items []meetings.Meeting) []meetingConflict {
+	type interval struct {
+		m          meetings.Meeting
+		start, end time.Time
+	}
+	var ivs []interval
+	for _, m := range items {
+		if strings.EqualFold(m.State, "cancelled") {
+			continue
+		}
+		start, sErr := time.Parse(time.RFC3339, m.Start)
+		end, eErr := time.Parse(time.RFC3339, m.End)
+		if sErr != nil || eErr != nil || !end.After(start) {
+			continue
+		}
+		ivs = append(ivs, interval{m: m, start: start, end: end})
+	}
+	sort.SliceStable(ivs, func(i, j int) bool { return ivs[i].start.Before(ivs[j].start) })
+
+	conflicts := make([]meetingConflict, 0)
+	for i := range ivs {
+		for j := i + 1; j < len(ivs) && ivs[j].start.Before(ivs[i].end); j++ {
+			overlapEnd := ivs[i].end
+			if ivs[j].end.Before(overlapEnd) {
+				overlapEnd = ivs[j].end
+			}
+			conflicts = append(conflicts, meetingConflict{
+				First:          conflictMeeting{MeetingID: ivs[i].m.ID, Title: ivs[i].m.Title, Start: ivs[i].m.Start, End: ivs[i].m.End},
+				Second:         conflictMeeting{MeetingID: ivs[j].m.ID, Title: ivs[j].m.Title, Start: ivs[j].m.Start, End: ivs[j].m.End},
+				OverlapMinutes: int(overlapEnd.Sub(ivs[j].start).Minutes()),
+			})
+		}
+	}
+	return conflicts
+}
+
+// meetingInvite is a forwardable summary of a meeting's join details.
+type meetingInvite struct {
+	Title         string                  `json:"title"`
+	When          string                  `json:"when"`
+	Timezone      string                  `json:"timezone"`
+	Host          string                  `json:"host,omitempty"`
+	HostEmail     string                  `json:"hostEmail,omitempty"`
+	JoinLink      string                  `json:"joinLink,omitempty"`
+	MeetingNumber string                  `json:"meetingNumber,omitempty"`
+	Password      string                  `json:"password,omitempty"`
+	VideoAddress  string                  `json:"videoAddress,omitempty"`
+	AccessCode    string                  `json:"accessCode,omitempty"`
+	DialIn        []meetings.CallInNumber `json:"dialIn,omitempty"`
+	Agenda        string                  `json:"agenda,omitempty"`
+	Invitees      []string                `json:"invitees,omitempty"`
+	InviteesNote  string                  `json:"inviteesNote,omitempty"`
+}
+
+// formatMeetingWhen renders a meeting's start/end in its own timezone, e.g.
+// "Friday, February 6, 2026, 2:00 PM - 2:30 PM EST". Unparseable times are returned as-is.
+func formatMeetingWhen(start, end, timezone string) (string, string) {
+	loc, err := time.LoadLocation(timezone)
+	if timezone == "" || err != nil {
+		loc, timezone = time.UTC, "UTC"
+	}
+	startTime, err := time.Parse(time.RFC3339, start)
+	if err != nil {
+		return start, timezone
+	}
+	startTime = startTime.In(loc)
+	when := startTime.Format("Monday, January 2, 2006, 3:04 PM")
+
+	endTime, err := time.Parse(time.RFC3339, end)
+	if err != nil {
+		return when + " " + startTime.Format("MST"), timezone
+	}
+	endTime = endTime.In(loc)
+	if endTime.YearDay() == startTime.YearDay() && endTime.Year() == startTime.Year() {
+		return when + " - " + endTime.Format("3:04 PM MST"), timezone
+	}
+	return when + " - " + endTime.Format("Monday, January 2, 2006, 3:04 PM MST"), timezone
+}
+
+// computeReschedule shifts a meeting's start and end, preserving its duration. Either
+// offsetMinutes is non-zero or newStart (UTC, RFC 3339) is set. The result must start after now.
+func computeReschedule(start, end string, offsetMinutes int, newStart string, now time.Time) (time.Time, time.Time, error) {
+	oldStart, err := time.Parse(time.RFC3339, start)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("meeting has no valid start time (%q)", start)
+	}
+	oldEnd, err := time.Parse(time.RFC3339, end)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("meeting has no valid end time (%q)", end)
+	}
+
+	var shifted time.Time
+	if newStart != "" {
+		t, err := time.Parse(time.RFC3339, newStart)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid newStart %q", newStart)
+		}
+		shifted = t.In(oldStart.Location())
+	} else {
+		shifted = oldStart.Add(time.Duration(offsetMinutes) * time.Minute)
+	}
+
+	if !shifted.After(now) {
+		return time.Time{}, time.Time{}, fmt.Errorf("new start %s is in the past", shifted.UTC().Format(time.RFC3339))
+	}
+	return shifted, shifted.Add(oldEnd.Sub(oldStart)), nil
+}
+
+// buildMeetingInvite collects the forwardable details of a meeting.
+func buildMeetingInvite(m *meetings.Meeting, invitees []meetings.Invitee) meetingInvite {
+	when, timezone := formatMeetingWhen(m.Start, m.End, m.Timezone)
+	inv := meetingInvite{
+		Title:         m.Title,
+		When:          when,
+		Timezone:      timezone,
+		Host:          m.HostDisplayName,
+		HostEmail:     m.HostEmail,
+		JoinLink:      m.WebLink,
+		MeetingNumber: m.MeetingNumber,
+		Password:      m.Password,
+		VideoAddress:  m.SipAddress,
+		Agenda:        strings.TrimSpace(m.Agenda),
+	}
+	if m.Telephony != nil {
+		inv.AccessCode = m.Telephony.AccessCode
+		inv.DialIn = m.Telephony.CallInNumbers
+	}
+	for _, i := range invitees {
+		if i.DisplayName != "" && !strings.EqualFold(i.DisplayName, i.Email) {
+			inv.Invitees = append(inv.Invitees, fmt.Sprintf("%s <%s>", i.DisplayName, i.Email))
+		} else {
+			inv.Invitees = append(inv.Invitees, i.Email)
+		}
+	}
+	return inv
+}
+
+// renderMeetingInviteMarkdown formats an invite as markdown suitable for forwarding.
+func renderMeetingInviteMarkdown(inv meetingInvite) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "## %s\n\n", inv.Title)
+	fmt.Fprintf(&b, "**When:** %s (%s)\n", inv.When, inv.Timezone)
+	if inv.Host != "" || inv.HostEmail != "" {
+		host := inv.Host
+		if host == "" {
+			host = inv.HostEmail
+		} else if inv.HostEmail != "" {
+			host = fmt.Sprintf("%s (%s)", inv.Host, inv.HostEmail)
+		}
+		fmt.Fprintf(&b, "**Host:** %s\n", host)
+	}
+
+	b.WriteString("\n### Join\n\n")
+	if inv.JoinLink != "" {
+		fmt.Fprintf(&b, "- **Join link:** %s\n", inv.JoinLink)
+	}
+	if inv.MeetingNumber != "" {
+		fmt.Fprintf(&b, "- **Meeting number:** %s\n", inv.MeetingNumber)
+	}
+	if inv.Password != "" {
+		fmt.Fprintf(&b, "- **Password:** %s\n", inv.Password)
+	}
+	if inv.VideoAddress != "" {
+		fmt.Fprintf(&b, "- **Video system:** %s\n", inv.VideoAddress)
+	}
+
+	if len(inv.DialIn) > 0 {
+		b.WriteString("\n### Dial-in\n\n")
+		for _, n := range inv.DialIn {
+			label := n.Label
+			if label == "" {
+				label = n.TollType
+			}
+			if label != "" {
+				fmt.Fprintf(&b, "- %s: %s\n", label, n.CallInNumber)
+			} else {
+				fmt.Fprintf(&b, "- %s\n", n.CallInNumber)
+			}
+		}
+		if inv.AccessCode != "" {
+			fmt.Fprintf(&b, "- **Access code:** %s\n", inv.AccessCode)
+		}
+	}
+
+	if inv.Agenda != "" {
+		fmt.Fprintf(&b, "\n### Agenda\n\n%s\n", inv.Agenda)
+	}
+
+	if len(inv.Invitees) > 0 || inv.InviteesNote != "" {
+		b.WriteString("\n### Invitees\n\n")
+		for _, i := range inv.Invitees {
+			fmt.Fprintf(&b, "- %s\n", i)
+		}
+		if inv.InviteesNote != "" {
+			fmt.Fprintf(&b, "\n_%s_\n", inv.InviteesNote)
+		}
+	}
+	return b.String()
+}
+
+// roomInviteeCap bounds how many members fromRoomId invites on webex_meetings_create.
+const roomInviteeCap = 200
+
+// roomInviteeEmails returns the emails of the room's members, skipping bots and
+// excludeEmail (the caller, who hosts the meeting). truncated is true when the room
+// had more members than roomInviteeCap. Any page that fails to load is an error,
+// so a create never goes ahead with a silently shortened invitee list.
+func roomInviteeEmails(client *webex.WebexClient, roomID, excludeEmail string) (emails []string, skippedBots int, truncated bool, err error) {
+	page, err := client.Memberships().List(&memberships.ListOptions{RoomID: roomID, Max: CatalogPageSize})
+	if err != nil {
+		return nil, 0, false, err
+	}
+	members, truncated, err := FetchAll(page.Items, page.HasNext, page.NextPage, client, roomInviteeCap)
+	if err != nil {
+		return nil, 0, false, err
+	}
+	for _, m := range members {
+		email := strings.TrimSpace(m.PersonEmail)
+		switch {
+		case email == "", strings.EqualFold(email, excludeEmail):
+		case strings.HasSuffix(strings.ToLower(email), "@webex.bot"):
+			skippedBots++
+		default:
+			emails = append(emails, email)
+		}
+	}
+	return emails, skippedBots, truncated, nil
+}
+
+// mergeInvitees combines explicit and room-derived invitee emails, keeping the
+// first occurrence of each address (case-insensitive) and explicit ones first.
+func mergeInvitees(explicit, fromRoom []string) []meetings.Invitee {
+	seen := make(map[string]bool, len(explicit)+len(fromRoom))
+	invitees := make([]meetings.Invitee, 0, len(explicit)+len(fromRoom))
+	for _, email := range append(append([]string{}, explicit...), fromRoom...) {
+		email = strings.TrimSpace(email)
+		key := strings.ToLower(email)
+		if email == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		invitees = append(invitees, meetings.Invitee{Email: email})
+	}
+	return invitees
+}
+
+// meetingChatCap is the maximum number of chat messages webex_meetings_get_chat returns.
+const meetingChatCap = 1000
+
+// meetingChatPerson is the sender or a receiver of an in-meeting chat message.
+type meetingChatPerson struct {
+	DisplayName string `json:"displayName,omitempty"`
+	Email       string `json:"email,omitempty"`
+	PersonID    string `json:"personId,omitempty"`
+}
+
+// meetingChat is one in-meeting chat message from the post-meeting chats API,
+// which the SDK does not model.
+type meetingChat struct {
+	ID        string              `json:"id"`
+	ChatTime  string              `json:"chatTime"`
+	Text      string              `json:"text"`
+	Type      string              `json:"type,omitempty"` // "public" or "private"
+	Sender    meetingChatPerson   `json:"sender"`
+	Receivers []meetingChatPerson `json:"receivers,omitempty"`
+}
+
+// name returns the best label for a chat participant.
+func (p meetingChatPerson) name() string {
+	if p.DisplayName != "" {
+		return p.DisplayName
+	}
+	if p.Email != "" {
+		return p.Email
+	}
+	return "Unknown"
+}
+
+// renderMeetingChat renders chat messages as "[time] Sender: text" lines, oldest
+// first, noting the recipients of private messages.
+func renderMeetingChat(chats []meetingChat) string {
+	sorted := make([]meetingChat, len(chats))
+	copy(sorted, chats)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].ChatTime < sorted[j].ChatTime })
+
+	var b strings.Builder
+	for _, c := range sorted {
+		text := strings.TrimSpace(c.Text)
+		if text == "" {
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString("[" + c.ChatTime + "] " + c.Sender.name())
+		if strings.EqualFold(c.Type, "private") && len(c.Receivers) > 0 {
+			names := make([]string, len(c.Receivers))
+			for i, r := range c.Receivers {
+				names[i] = r.name()
+			}
+			b.WriteString(" (privately to " + strings.Join(names, ", ") + ")")
+		}
+		b.WriteString(": " + text)
+	}
+	return b.String()
+}
+
+const (
+	// attendanceOccurrenceCap is the maximum number of occurrences webex_meetings_attendance reports on.
+	attendanceOccurrenceCap = 50
+
+	// attendanceScanCap is the maximum number of meetings webex_meetings_attendance lists from the window.
+	attendanceScanCap = 500
+
+	// attendanceParticipantCap is the maximum number of participants read per occurrence.
+	attendanceParticipantCap = 1000
+
+	// attendanceConcurrency bounds the participant lookups running at once.
+	attendanceConcurrency = 5
+
+	// defaultAttendanceWindow is how far back webex_meetings_attendance looks by default.
+	defaultAttendanceWindow = 90 * 24 * time.Hour
+
+	// maxAttendanceWindow is the widest from/to range webex_meetings_attendance accepts.
+	maxAttendanceWindow = 366 * 24 * time.Hour
+)
+
+// attendanceOccurrence is one held meeting of a series as listed by webex_meetings_attendance.
+type attendanceOccurrence struct {
+	MeetingID        string `json:"meetingId"`
+	Start            string `json:"start"`
+	End              string `json:"end,omitempty"`
+	ParticipantCount int    `json:"participantCount"`
+}
+
+// attendanceRow is one person's attendance across the occurrences of a series.
+// Present is aligned with the occurrences and forms one row of the matrix.
+type attendanceRow struct {
+	Name         string `json:"name,omitempty"`
+	Email        string `json:"email,omitempty"`
+	Invited      bool   `json:"invited"`
+	Attended     int    `json:"attended"`
+	Of           int    `json:"of"`
+	TotalMinutes int    `json:"totalMinutes"`
+	Present      []bool `json:"present"`
+}
+
+// occurrenceParticipants pairs a held meeting with the participants who joined it.
+type occurrenceParticipants struct {
+	meeting      meetings.Meeting
+	participants []meetings.Participant
+}
+
+// attendanceKey identifies a person across occurrences: by email when known,
+// otherwise by display name.
+func attendanceKey(email, displayName string) string {
+	if email != "" {
+		return strings.ToLower(email)
+	}
+	return "name:" + strings.ToLower(displayName)
+}
+
+// participantSeconds returns how long a participant was in the meeting. A
+// participant still in the meeting is counted up to meetingEnd, if known.
+func participantSeconds(p meetings.Participant, meetingEnd string) float64 {
+	joined, err := time.Parse(time.RFC3339, p.JoinedTime)
+	if err != nil {
+		return 0
+	}
+	leftStr := p.LeftTime
+	if leftStr == "" {
+		leftStr = meetingEnd
+	}
+	left, err := time.Parse(time.RFC3339, leftStr)
+	if err != nil || left.Before(joined) {
+		return 0
+	}
+	return left.Sub(joined).Seconds()
+}
+
+// buildAttendance lays out who attended which occurrences, oldest occurrence
+// first. Invitees who never joined get a row with zero attendance. Rows are
+// sorted with the lowest attendance first, so regular absentees lead the list.
+func buildAttendance(held []occurrenceParticipants, invitees []meetings.Invitee) ([]attendanceOccurrence, []attendanceRow) {
+	sorted := make([]occurrenceParticipants, len(held))
+	copy(sorted, held)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].meeting.Start < sorted[j].meeting.Start })
+
+	occurrences := make([]attendanceOccurrence, len(sorted))
+	rows := make(map[string]*attendanceRow)
+	seconds := make(map[string]float64)
+	row := func(key, email, name string) *attendanceRow {
+		r, ok := rows[key]
+		if !ok {
+			r = &attendanceRow{Email: email, Of: len(sorted), Present: make([]bool, len(sorted))}
+			rows[key] = r
+		}
+		if r.Name == "" {
+			r.Name = name
+		}
+		return r
+	}
+
+	for _, inv := range invitees {
+		if inv.Email == "" && inv.DisplayName == "" {
+			continue
+		}
+		row(attendanceKey(inv.Email, inv.DisplayName), inv.Email, inv.DisplayName).Invited = true
+	}
+
+	for i, occ := range sorted {
+		attendees := make(map[string]bool)
+		for _, p := range occ.participants {
+			if p.Email == "" && p.DisplayName == "" {
+				continue
+			}
+			key := attendanceKey(p.Email, p.DisplayName)
+			r := row(key, p.Email, p.DisplayName)
+			if !r.Present[i] {
+				r.Present[i] = true
+				r.Attended++
+			}
+			attendees[key] = true
+			seconds[key] += participantSeconds(p, occ.meeting.End)
+		}
+		occurrences[i] = attendanceOccurrence{
+			MeetingID:        occ.meeting.ID,
+			Start:            occ.meeting.Start,
+			End:              occ.meeting.End,
+			ParticipantCount: len(attendees),
+		}
+	}
+
+	result := make([]attendanceRow, 0, len(rows))
+	for key, r := range rows {
+		r.TotalMinutes = int(seconds[key]/60 + 0.5)
+		result = append(result, *r)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.Attended != b.Attended {
+			return a.Attended < b.Attended
+		}
+		if a.TotalMinutes != b.TotalMinutes {
+			return a.TotalMinutes < b.TotalMinutes
+		}
+		return attendanceKey(a.Email, a.Name) < attendanceKey(b.Email, b.Name)
+	})
+	return occurrences, result
+}
+
+// resolveAttendanceNames fills in missing names of attendance rows by email.
+// Lookup failures are logged and leave the name empty.
+func resolveAttendanceNames(client *webex.WebexClient, rows []attendanceRow) {
+	var emails []string
+	var idx []int
+	for i, r := range rows {
+		if r.Name == "" && r.Email != "" {
+			emails = append(emails, r.Email)
+			idx = append(idx, i)
+		}
+	}
+	if len(emails) == 0 {
+		return
+	}
+	for i, r := range lookupPeopleByEmail(client, emails) {
+		switch {
+		case r.err != nil:
+			log.Printf("Enrichment: failed to resolve attendee %s: %v", emails[i], r.err)
+		case r.person != nil:
+			rows[idx[i]].Name = r.person.DisplayName
+		}
+	}
+}
+
 // RegisterMeetingTools registers all meeting-related MCP tools.
-func RegisterMeetingTools(s ToolRegistrar, resolver auth.ClientResolver) {
+// prefs may be nil; when set, webex_meetings_create falls back to the user's stored default timezone.
+func RegisterMeetingTools(s ToolRegistrar, resolver auth.ClientResolver, prefs auth.PreferenceStore) {
 	// webex_meetings_list
 	s.AddTool(
 		mcp.NewTool("webex_meetings_list",
@@ -187,6 +898,7 @@ func RegisterMeetingTools(s ToolRegistrar, resolver auth.ClientResolver) {
 
 				// Enrich: transcripts for meetings that have them
 				if meeting.HasTranscription {
+					countEnrichment(client)
 					if tPage, tErr := client.Transcripts().List(&transcripts.ListOptions{
 						MeetingID: meeting.ID,
 					}); tErr == nil && len(tPage.Items) > 0 {
@@ -269,17 +981,24 @@ func RegisterMeetingTools(s ToolRegistrar, resolver auth.ClientResolver) {
 				"\n"+
 				"INVITEES: Pass a comma-separated list of email addresses to automatically invite people. They receive a Webex meeting invite. Example: 'alice@example.com,bob@example.com'\n"+
 				"\n"+
+				"INVITE A SPACE: For 'schedule a meeting with everyone in <space>', pass fromRoomId instead of listing members yourself. "+
+				"Its members are invited (bots and you, the host, are skipped), merged with any invitees and de-duplicated. "+
+				fmt.Sprintf("At most %d members are invited; the response then includes a warning. ", roomInviteeCap)+
+				"With fromRoomId the response is {meeting, fromRoom: {roomId, title, invited, skippedBots, truncated, warning}}.\n"+
+				"\n"+
 				"IMPORTANT: Always confirm the meeting details (title, time, timezone, invitees) with the user before creating.\n"+
 				"\n"+
 				"TIPS:\n"+
 				"- Always specify a timezone if the user mentions one (e.g. 'America/New_York', 'Asia/Kolkata', 'Europe/London'). If no timezone is mentioned, ask the user or default to UTC.\n"+
 				"- For a 30-minute meeting at 2pm ET: start='2026-02-06T14:00:00', end='2026-02-06T14:30:00', timezone='America/New_York'\n"+
-				"- The response includes the webLink (join URL) and meetingNumber that participants need to join."),
+				"- The response includes the webLink (join URL) and meetingNumber that participants need to join.\n"+
+				"- Users with several Webex sites (e.g. a corporate and a training site) can pick one with siteUrl."),
 			mcp.WithString("title", mcp.Required(), mcp.Description("Title of the meeting (e.g. 'Weekly Team Sync', '1:1 with Alice').")),
 			mcp.WithString("start", mcp.Required(), mcp.Description("Start time in UTC format (e.g. '2026-02-06T14:00:00Z'). Always clarify the timezone with the user and convert to UTC.")),
 			mcp.WithString("end", mcp.Required(), mcp.Description("End time in UTC format (e.g. '2026-02-06T15:00:00Z'). Must be after start. Common durations: 30 min, 1 hour.")),
 			mcp.WithString("invitees", mcp.Description("Comma-separated email addresses to invite to the meeting (e.g. 'alice@example.com,bob@example.com,charlie@example.com'). Each person receives a Webex meeting invite.")),
-			mcp.WithString("timezone", mcp.Description("IANA timezone name (e.g. 'America/New_York', 'Asia/Kolkata', 'Europe/London', 'US/Pacific'). If omitted, times are treated as UTC. ALWAYS set this when the user mentions a timezone or location.")),
+			mcp.WithString("fromRoomId", mcp.Description("Invite every member of this room/space. Get this from webex_rooms_list. Combined with invitees; duplicates are removed.")),
+			mcp.WithString("timezone", mcp.Description("IANA timezone name (e.g. 'America/New_York', 'Asia/Kolkata', 'Europe/London', 'US/Pacific'). If omitted, the user's saved default timezone (webex_preferences_set) is used when available, otherwise UTC. ALWAYS set this when the user mentions a timezone or location.")),
 			mcp.WithString("agenda", mcp.Description("Optional meeting agenda or description. Appears in the meeting invite.")),
 			mcp.WithString("password", mcp.Description("Optional meeting password. If omitted, Webex generates one automatically.")),
 			mcp.WithString("recurrence", mcp.Description("Optional recurrence rule in RFC 2445 / iCal RRULE format. Examples: 'FREQ=WEEKLY;BYDAY=MO' (every Monday), 'FREQ=DAILY;COUNT=5' (next 5 days), 'FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,TH' (every other Tue/Thu).")),
@@ -288,6 +1007,7 @@ func RegisterMeetingTools(s ToolRegistrar, resolver auth.ClientResolver) {
 			mcp.WithNumber("joinBeforeHostMinutes", mcp.Description("Number of minutes participants can join before host. Required if enabledJoinBeforeHost is true.")),
 			mcp.WithBoolean("publicMeeting", mcp.Description("Make the meeting publicly accessible. Default: false.")),
 			mcp.WithBoolean("allowAnyUserToBeCoHost", mcp.Description("Allow any user to be co-host. Default: false.")),
+			mcp.WithString("siteUrl", mcp.Description("Webex site to host the meeting (e.g. 'example.webex.com'). Only needed for users with multiple sites. If omitted, your preferred (default) site is used. Must be one of your sites; the error lists valid sites otherwise.")),
 		),
 		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			client, err := resolver(ctx)
@@ -316,11 +1036,16 @@ func RegisterMeetingTools(s ToolRegistrar, resolver auth.ClientResolver) {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
+			timezone := req.GetString("timezone", "")
+			if timezone == "" {
+				timezone = lookupPreference(prefs, client, PrefTimezone)
+			}
+
 			meeting := &meetings.Meeting{
 				Title:                    title,
 				Start:                    convertedStart,
 				End:                      convertedEnd,
-				Timezone:                 req.GetString("timezone", ""),
+				Timezone:                 timezone,
 				Agenda:                   req.GetString("agenda", ""),
 				Password:                 req.GetString("password", ""),
 				Recurrence:               req.GetString("recurrence", ""),
@@ -331,19 +1056,55 @@ func RegisterMeetingTools(s ToolRegistrar, resolver auth.ClientResolver) {
 				AllowAnyUserToBeCoHost:   req.GetBool("allowAnyUserToBeCoHost", false),
 			}
 
-			// Parse invitees from comma-separated emails
-			if inviteesStr := req.GetString("invitees", ""); inviteesStr != "" {
-				emails := strings.Split(inviteesStr, ",")
-				invitees := make([]meetings.Invitee, 0, len(emails))
-				for _, email := range emails {
-					email = strings.TrimSpace(email)
-					if email != "" {
-						invitees = append(invitees, meetings.Invitee{Email: email})
+			// Validate the requested site against the user's sites
+			if siteURL := req.GetString("siteUrl", ""); siteURL != "" {
+				sites, sErr := listMeetingSites(client)
+				if sErr != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("Failed to list your Webex sites to validate siteUrl (omit siteUrl to use your default site): %v", sErr)), nil
+				}
+				matched, ok := matchMeetingSite(sites, siteURL)
+				if !ok {
+					valid := make([]string, 0, len(sites))
+					for _, site := range sites {
+						valid = append(valid, site.SiteURL)
 					}
+					return mcp.NewToolResultError(fmt.Sprintf("siteUrl %q is not one of your Webex sites. Valid sites: %s", siteURL, strings.Join(valid, ", "))), nil
 				}
-				if len(invitees) > 0 {
-					meeting.Invitees = invitees
+				meeting.SiteURL = matched
+			}
+
+			// Parse invitees from comma-separated emails, plus the members of fromRoomId
+			var explicit []string
+			if inviteesStr := req.GetString("invitees", ""); inviteesStr != "" {
+				explicit = strings.Split(inviteesStr, ",")
+			}
+			var fromRoom map[string]interface{}
+			var roomEmails []string
+			if roomID := strings.TrimSpace(req.GetString("fromRoomId", "")); roomID != "" {
+				hostEmail := ""
+				if me, meErr := client.People().GetMe(); meErr == nil && len(me.Emails) > 0 {
+					hostEmail = me.Emails[0]
+				}
+				emails, skippedBots, truncated, rErr := roomInviteeEmails(client, roomID, hostEmail)
+				if rErr != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("Failed to list room members for fromRoomId: %v", rErr)), nil
 				}
+				roomEmails = emails
+				fromRoom = map[string]interface{}{
+					"roomId":      roomID,
+					"invited":     len(emails),
+					"skippedBots": skippedBots,
+					"truncated":   truncated,
+				}
+				if info := resolveRoomInfo(client, roomID); info != nil {
+					fromRoom["title"] = info.Title
+				}
+				if truncated {
+					fromRoom["warning"] = fmt.Sprintf("The room has more than %d members; only the first %d were invited. Share the webLink in the space so everyone else can join.", roomInviteeCap, roomInviteeCap)
+				}
+			}
+			if invitees := mergeInvitees(explicit, roomEmails); len(invitees) > 0 {
+				meeting.Invitees = invitees
 			}
 
 			result, err := client.Meetings().Create(meeting)
@@ -351,7 +1112,15 @@ func RegisterMeetingTools(s ToolRegistrar, resolver auth.ClientResolver) {
 				return mcp.NewToolResultError(fmt.Sprintf("Failed to create meeting: %v", err)), nil
 			}
 
-			data, _ := json.MarshalIndent(result, "", "  ")
+			var data []byte
+			if fromRoom != nil {
+				data, _ = json.MarshalIndent(map[string]interface{}{
+					"meeting":  result,
+					"fromRoom": fromRoom,
+				}, "", "  ")
+			} else {
+				data, _ = json.MarshalIndent(result, "", "  ")
+			}
 			return mcp.NewToolResultText(string(data)), nil
 		},
 	)
@@ -364,10 +1133,14 @@ func RegisterMeetingTools(s ToolRegistrar, resolver auth.ClientResolver) {
 				"RESPONSE: Enriched with:\n"+
 				"- meeting: Full meeting details (title, start, end, state, webLink, meetingNumber, etc.).\n"+
 				"- hostName: Display name of the meeting host.\n"+
+				"- coHosts: Only with includeCoHosts=true. Invitees flagged as co-hosts, with email and displayName.\n"+
+				"- security: Entry and security settings -- joinBeforeHost, automaticLock, waitingRoom (lobby for unlocked meetings), requireAttendeeLogin, restrictToInvitees, and co-host rules.\n"+
 				"- transcripts: If the meeting has transcripts (hasTranscription=true), includes transcript IDs and meetingIds ready for webex_transcripts_download.\n"+
 				"\n"+
-				"COMMON USE: After finding a meeting via webex_meetings_list, use this tool if you need the full details, host name, or transcript IDs."),
+				"COMMON USE: After finding a meeting via webex_meetings_list, use this tool if you need the full details, host name, or transcript IDs. "+
+				"Also answers 'who can start this meeting?' (host, coHosts with includeCoHosts=true, and joinBeforeHost) and 'is the waiting room on?' (security.waitingRoom)."),
 			mcp.WithString("meetingId", mcp.Required(), mcp.Description("The ID of the meeting to retrieve. Get this from webex_meetings_list results.")),
+			mcp.WithBoolean("includeCoHosts", mcp.Description("Also list co-hosts with display names. Costs extra API calls (the invitee list plus a people lookup per co-host). Default: false.")),
 		),
 		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			client, err := resolver(ctx)
@@ -397,8 +1170,31 @@ func RegisterMeetingTools(s ToolRegistrar, resolver auth.ClientResolver) {
 				}
 			}
 
+			// Enrich: co-hosts (the meeting object rarely carries invitees, so fall back to the invitees API)
+			if req.GetBool("includeCoHosts", false) {
+				invitees := result.Invitees
+				if len(invitees) == 0 {
+					countEnrichment(client)
+					list, truncated, iErr := listMeetingInvitees(client, result.ID)
+					if iErr != nil {
+						log.Printf("Enrichment: failed to list invitees for meeting %s: %v", result.ID, iErr)
+					}
+					invitees = list
+					if note := inviteeListNote(truncated, iErr); note != "" {
+						response["coHostsNote"] = note + " Co-hosts among them are not listed."
+					}
+				}
+				coHosts := coHostsFromInvitees(invitees)
+				resolveCoHostNames(client, coHosts)
+				response["coHosts"] = coHosts
+			}
+
+			// Enrich: security and entry settings
+			response["security"] = summarizeMeetingSecurity(result)
+
 			// Enrich: transcripts
 			if result.HasTranscription {
+				countEnrichment(client)
 				if tPage, tErr := client.Transcripts().List(&transcripts.ListOptions{
 					MeetingID: result.ID,
 				}); tErr == nil && len(tPage.Items) > 0 {
@@ -525,6 +1321,55 @@ func RegisterMeetingTools(s ToolRegistrar, resolver auth.ClientResolver) {
 		},
 	)
 
+	// webex_meetings_end
+	s.AddTool(
+		mcp.NewTool("webex_meetings_end",
+			mcp.WithDescription("End a Webex meeting that is currently in progress, disconnecting everyone in it. Only the host can end a meeting.\n"+
+				"\n"+
+				"USE THIS WHEN:\n"+
+				"- 'End the call now' or 'Stop the meeting for everyone'\n"+
+				"\n"+
+				"NOTE: This only works on a live meeting instance (state 'inProgress'). To cancel a meeting that has not started yet, use webex_meetings_delete instead.\n"+
+				"\n"+
+				"IMPORTANT: Always confirm with the user before ending a meeting. All participants will be disconnected immediately."),
+			mcp.WithString("meetingId", mcp.Required(), mcp.Description("The meeting instance ID of the live meeting (not the series ID). Get this from webex_meetings_list with meetingType='meeting' and state='inProgress'.")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			client, err := resolver(ctx)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Auth error: %v", err)), nil
+			}
+
+			meetingID, err := req.RequireString("meetingId")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			meeting, err := client.Meetings().Get(meetingID)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to get meeting: %v", err)), nil
+			}
+			if !isMeetingLive(meeting.State) {
+				return mcp.NewToolResultError(fmt.Sprintf("Meeting is not in progress (state: %q); only live meetings can be ended. Use webex_meetings_delete to cancel a scheduled meeting.", meeting.State)), nil
+			}
+
+			if err := endMeeting(client, meetingID); err != nil {
+				if webexsdk.IsForbidden(err) {
+					return mcp.NewToolResultError("Failed to end meeting: only the meeting host can end a meeting"), nil
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to end meeting: %v", err)), nil
+			}
+
+			result := map[string]interface{}{
+				"ended":     true,
+				"meetingId": meeting.ID,
+				"title":     meeting.Title,
+			}
+			data, _ := json.MarshalIndent(result, "", "  ")
+			return mcp.NewToolResultText(string(data)), nil
+		},
+	)
+
 	// webex_meetings_list_participants
 	s.AddTool(
 		mcp.NewTool("webex_meetings_list_participants",
@@ -720,4 +1565,602 @@ func RegisterMeetingTools(s ToolRegistrar, resolver auth.ClientResolver) {
 			return mcp.NewToolResultText(string(data)), nil
 		},
 	)
+
+	// webex_meetings_list_invitations
+	s.AddTool(
+		mcp.NewTool("webex_meetings_list_invitations",
+			mcp.WithDescription("List upcoming meetings the user has been invited to by someone else (meetings they do not host).\n"+
+				"\n"+
+				"USE THIS WHEN:\n"+
+				"- 'Do I have any meeting invites?' or 'What meetings have I been invited to this week?'\n"+
+				"\n"+
+				"NOTE: The Webex API does not expose RSVP (accept/decline) status, so this is best-effort: it returns every scheduled meeting in the window "+
+				"that someone else hosts, with rsvpStatus='unknown'. Do not tell the user these are unanswered invites -- say they are upcoming meetings they were invited to.\n"+
+				"\n"+
+				"RESPONSE:\n"+
+				"- invitations: meetingId, title, start, end, hostName, hostEmail, and webLink (join URL) for each meeting, soonest first (at most maxResults).\n"+
+				"- window: The from/to range that was searched.\n"+
+				"- count: Number of invitations returned. Flagged countIsLowerBound when more invitations exist than were returned: "+
+				"re-call with a higher maxResults or a narrower window. truncated=true means not every meeting in the window was scanned, "+
+				"and partial=true with fetchError means a page failed to load."),
+			mcp.WithString("from", mcp.Description("Start of the window (UTC format: '2026-02-06T00:00:00Z'). Defaults to now.")),
+			mcp.WithString("to", mcp.Description("End of the window (UTC format: '2026-02-13T00:00:00Z'). Defaults to 7 days after 'from'. The window may be at most 30 days.")),
+			mcp.WithNumber("maxResults", mcp.Description(MaxResultsParamDescription)),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			client, err := resolver(ctx)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Auth error: %v", err)), nil
+			}
+
+			from := time.Now().UTC().Truncate(time.Second)
+			if v := req.GetString("from", ""); v != "" {
+				converted, err := validateAndConvertISO8601(v, "from")
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				from, _ = time.Parse(time.RFC3339, converted)
+			}
+			to := from.Add(defaultInvitationWindow)
+			if v := req.GetString("to", ""); v != "" {
+				converted, err := validateAndConvertISO8601(v, "to")
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				to, _ = time.Parse(time.RFC3339, converted)
+			}
+			if !to.After(from) {
+				return mcp.NewToolResultError("'to' must be after 'from'"), nil
+			}
+			if to.Sub(from) > maxInvitationWindow {
+				return mcp.NewToolResultError("The window between 'from' and 'to' may be at most 30 days"), nil
+			}
+
+			me, err := client.People().GetMe()
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to identify current user: %v", err)), nil
+			}
+
+			page, err := client.Meetings().List(&meetings.ListOptions{
+				MeetingType: "scheduledMeeting",
+				From:        from.Format(time.RFC3339),
+				To:          to.Format(time.RFC3339),
+				Max:         CatalogPageSize,
+			})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to list meetings: %v", err)), nil
+			}
+			// Scan the window first and apply maxResults after filtering out the
+			// user's own meetings, so the budget is spent on invitations.
+			items, truncated, fetchErr := FetchAll(page.Items, page.HasNext, page.NextPage, client, invitationScanCap)
+
+			invited := filterInvitedMeetings(items, me.ID, me.Emails)
+			more := false
+			if maxResults := ClampMaxResults(req); len(invited) > maxResults {
+				invited = invited[:maxResults]
+				more = true
+			}
+			nameCache := NewPersonNameCache(client)
+			invitations := make([]map[string]interface{}, 0, len(invited))
+			for _, m := range invited {
+				hostName := m.HostDisplayName
+				if hostName == "" {
+					hostName = nameCache.Resolve(m.HostUserID)
+				}
+				invitations = append(invitations, map[string]interface{}{
+					"meetingId":  m.ID,
+					"title":      m.Title,
+					"start":      m.Start,
+					"end":        m.End,
+					"hostName":   hostName,
+					"hostEmail":  m.HostEmail,
+					"webLink":    m.WebLink,
+					"rsvpStatus": "unknown",
+				})
+			}
+
+			response := map[string]interface{}{
+				"invitations": invitations,
+				"truncated":   truncated,
+				"window": map[string]string{
+					"from": from.Format(time.RFC3339),
+					"to":   to.Format(time.RFC3339),
+				},
+			}
+			AddCountToMap(response, "count", len(invitations), more || truncated || fetchErr != nil)
+			AddFetchErrorToMap(response, fetchErr)
+
+			data, _ := json.MarshalIndent(response, "", "  ")
+			return mcp.NewToolResultText(string(data)), nil
+		},
+	)
+
+	// webex_meetings_get_invite
+	s.AddTool(
+		mcp.NewTool("webex_meetings_get_invite",
+			mcp.WithDescription("Get a meeting's invite details -- title, time in the meeting's timezone, host, join link, meeting number, dial-in numbers, agenda, and invitees -- formatted for forwarding.\n"+
+				"\n"+
+				"USE THIS WHEN:\n"+
+				"- 'Send me the details for the 3pm so I can forward it'\n"+
+				"- 'What's the dial-in for my next meeting?'\n"+
+				"\n"+
+				"RESPONSE: Markdown by default, ready to paste into a message or email (e.g. via webex_messages_create with markdown). Pass format='json' for structured fields instead.\n"+
+				"\n"+
+				"NOTE: The invite includes the meeting password when Webex returns one. Check with the user before sharing it outside their organization."),
+			mcp.WithString("meetingId", mcp.Required(), mcp.Description("The ID of the meeting. Get this from webex_meetings_list (use meetingType='scheduledMeeting' for upcoming occurrences).")),
+			mcp.WithString("format", mcp.Description("Output format: 'markdown' (default) or 'json'.")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			client, err := resolver(ctx)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Auth error: %v", err)), nil
+			}
+
+			meetingID, err := req.RequireString("meetingId")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			format := req.GetString("format", "markdown")
+			if format != "markdown" && format != "json" {
+				return mcp.NewToolResultError("format must be 'markdown' or 'json'"), nil
+			}
+
+			meeting, err := client.Meetings().Get(meetingID)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to get meeting: %v", err)), nil
+			}
+
+			invitees := meeting.Invitees
+			inviteesNote := ""
+			if len(invitees) == 0 {
+				list, truncated, iErr := listMeetingInvitees(client, meeting.ID)
+				if iErr != nil {
+					log.Printf("Enrichment: failed to list invitees for meeting %s: %v", meeting.ID, iErr)
+				}
+				invitees = list
+				inviteesNote = inviteeListNote(truncated, iErr)
+			}
+
+			invite := buildMeetingInvite(meeting, invitees)
+			invite.InviteesNote = inviteesNote
+			if invite.Host == "" && meeting.HostUserID != "" {
+				invite.Host = resolvePersonName(client, meeting.HostUserID)
+			}
+
+			if format == "json" {
+				data, _ := json.MarshalIndent(invite, "", "  ")
+				return mcp.NewToolResultText(string(data)), nil
+			}
+			return mcp.NewToolResultText(renderMeetingInviteMarkdown(invite)), nil
+		},
+	)
+
+	// webex_meetings_reschedule
+	s.AddTool(
+		mcp.NewTool("webex_meetings_reschedule",
+			mcp.WithDescription("Move a meeting to a new time while keeping its duration and timezone. Fetches the meeting, shifts start and end together, and saves the change.\n"+
+				"\n"+
+				"USE THIS WHEN:\n"+
+				"- 'Push my 2pm back by 30 minutes' → offsetMinutes=30\n"+
+				"- 'Move the standup 15 minutes earlier' → offsetMinutes=-15\n"+
+				"- 'Move the review to Thursday at 10am' → newStart='2026-02-12T15:00:00Z' (convert the user's local time to UTC)\n"+
+				"\n"+
+				"Pass exactly one of offsetMinutes or newStart. The new start must be in the future.\n"+
+				"\n"+
+				"RESPONSE: before and after times (UTC and human-readable in the meeting's timezone), plus the updated meeting.\n"+
+				"\n"+
+				"NOTE: For recurring meetings, pass a specific occurrence ID (meetingType='scheduledMeeting' in webex_meetings_list) to move just that occurrence.\n"+
+				"\n"+
+				"IMPORTANT: Confirm the new time with the user before rescheduling. Invitees will be notified."),
+			mcp.WithString("meetingId", mcp.Required(), mcp.Description("The ID of the meeting to move. Get this from webex_meetings_list.")),
+			mcp.WithNumber("offsetMinutes", mcp.Description("Minutes to shift the meeting by: positive moves it later, negative earlier.")),
+			mcp.WithString("newStart", mcp.Description("New start time in UTC format (e.g. '2026-02-06T14:30:00Z'). The end moves to keep the same duration.")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			client, err := resolver(ctx)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Auth error: %v", err)), nil
+			}
+
+			meetingID, err := req.RequireString("meetingId")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			offsetMinutes := req.GetInt("offsetMinutes", 0)
+			newStart := req.GetString("newStart", "")
+			if (offsetMinutes == 0) == (newStart == "") {
+				return mcp.NewToolResultError("Pass exactly one of offsetMinutes (non-zero) or newStart"), nil
+			}
+			if newStart != "" {
+				if newStart, err = validateAndConvertISO8601(newStart, "newStart"); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+			}
+
+			meeting, err := client.Meetings().Get(meetingID)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to get meeting: %v", err)), nil
+			}
+
+			start, end, err := computeReschedule(meeting.Start, meeting.End, offsetMinutes, newStart, time.Now())
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Cannot reschedule: %v", err)), nil
+			}
+
+			patch := map[string]interface{}{
+				"start": start.Format(time.RFC3339),
+				"end":   end.Format(time.RFC3339),
+			}
+			if meeting.Timezone != "" {
+				patch["timezone"] = meeting.Timezone
+			}
+
+			result, err := client.Meetings().Patch(meetingID, patch)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to reschedule meeting: %v", err)), nil
+			}
+
+			beforeWhen, tz := formatMeetingWhen(meeting.Start, meeting.End, meeting.Timezone)
+			afterWhen, _ := formatMeetingWhen(result.Start, result.End, meeting.Timezone)
+
+			response := map[string]interface{}{
+				"before": map[string]interface{}{
+					"start": meeting.Start,
+					"end":   meeting.End,
+					"when":  beforeWhen,
+				},
+				"after": map[string]interface{}{
+					"start": result.Start,
+					"end":   result.End,
+					"when":  afterWhen,
+				},
+				"timezone": tz,
+				"meeting":  result,
+			}
+
+			data, _ := json.MarshalIndent(response, "", "  ")
+			return mcp.NewToolResultText(string(data)), nil
+		},
+	)
+
+	// webex_meetings_find_conflicts
+	s.AddTool(
+		mcp.NewTool("webex_meetings_find_conflicts",
+			mcp.WithDescription("Find double-bookings: list the user's scheduled meetings in a time window and report every pair that overlaps.\n"+
+				"\n"+
+				"USE THIS WHEN:\n"+
+				"- 'Do I have any double-bookings tomorrow?'\n"+
+				"- 'Is anything clashing with my 3pm?'\n"+
+				"- Before accepting or scheduling a meeting, to check the slot is free.\n"+
+				"\n"+
+				"RESPONSE:\n"+
+				"- hasConflicts: true if any meetings overlap.\n"+
+				"- conflicts: Each overlapping pair (meetingId, title, start, end for both) with overlapMinutes, earliest first.\n"+
+				"- meetingCount: How many meetings were checked in the window.\n"+
+				"\n"+
+				"NOTE: Back-to-back meetings (one ends exactly when the next starts) are not conflicts. Cancelled occurrences are ignored."),
+			mcp.WithString("from", mcp.Required(), mcp.Description("Start of the window (UTC format: '2026-02-06T00:00:00Z').")),
+			mcp.WithString("to", mcp.Required(), mcp.Description("End of the window (UTC format: '2026-02-07T00:00:00Z'). The window may be at most 30 days.")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			client, err := resolver(ctx)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Auth error: %v", err)), nil
+			}
+
+			fromStr, err := req.RequireString("from")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			toStr, err := req.RequireString("to")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if fromStr, err = validateAndConvertISO8601(fromStr, "from"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if toStr, err = validateAndConvertISO8601(toStr, "to"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			from, _ := time.Parse(time.RFC3339, fromStr)
+			to, _ := time.Parse(time.RFC3339, toStr)
+			if !to.After(from) {
+				return mcp.NewToolResultError("'to' must be after 'from'"), nil
+			}
+			if to.Sub(from) > maxInvitationWindow {
+				return mcp.NewToolResultError("The window between 'from' and 'to' may be at most 30 days"), nil
+			}
+
+			page, err := client.Meetings().List(&meetings.ListOptions{
+				MeetingType: "scheduledMeeting",
+				From:        fromStr,
+				To:          toStr,
+				Max:         CatalogPageSize,
+			})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to list meetings: %v", err)), nil
+			}
+			items, truncated, fetchErr := FetchAll(page.Items, page.HasNext, page.NextPage, client, conflictScanCap)
+
+			conflicts := findMeetingConflicts(items)
+
+			response := map[string]interface{}{
+				"hasConflicts": len(conflicts) > 0,
+				"conflicts":    conflicts,
+				"meetingCount": len(items),
+				"truncated":    truncated,
+				"window": map[string]string{
+					"from": fromStr,
+					"to":   toStr,
+				},
+			}
+			AddFetchErrorToMap(response, fetchErr)
+
+			data, _ := json.MarshalIndent(response, "", "  ")
+			return mcp.NewToolResultText(string(data)), nil
+		},
+	)
+
+	// webex_meetings_get_chat
+	s.AddTool(
+		mcp.NewTool("webex_meetings_get_chat",
+			mcp.WithDescription("Get the in-meeting chat of an ended Webex meeting: what participants typed in the meeting's chat panel, with sender and time. "+
+				"This is separate from space messages and from the spoken transcript.\n"+
+				"\n"+
+				"USE THIS WHEN:\n"+
+				"- 'What was posted in the chat during the call?'\n"+
+				"- Writing a meeting recap -- combine with webex_transcripts_download for what was said.\n"+
+				"\n"+
+				"NOTE: You need the meeting instance ID (webex_meetings_list with meetingType='meeting'), not the series ID. "+
+				"Chat is only kept for ended meetings and is normally available to the host only. "+
+				"Meetings with no chat return an empty result rather than an error.\n"+
+				"\n"+
+				"FORMATS:\n"+
+				"- 'txt' (default): '[time] Sender: text' lines, oldest first; private messages name their recipients.\n"+
+				"- 'json': The chat messages with id, chatTime, text, type (public/private), sender, and receivers."),
+			mcp.WithString("meetingId", mcp.Required(), mcp.Description("The meeting instance ID (not the series ID). Get this from webex_meetings_list with meetingType='meeting'.")),
+			mcp.WithString("format", mcp.Description("Output format: 'txt' (default) or 'json'.")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			client, err := resolver(ctx)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Auth error: %v", err)), nil
+			}
+
+			meetingID, err := req.RequireString("meetingId")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			format := req.GetString("format", "txt")
+			if format != "txt" && format != "json" {
+				return mcp.NewToolResultError("format must be 'txt' or 'json'"), nil
+			}
+
+			params := url.Values{}
+			params.Set("meetingId", meetingID)
+			params.Set("max", strconv.Itoa(CatalogPageSize))
+			chats := []meetingChat{}
+			truncated := false
+			var fetchErr error
+			page, err := FetchPage(client, client.Core().BaseURL.String()+"/meetings/postMeetingChats?"+params.Encode())
+			switch {
+			case webexsdk.IsNotFound(err):
+				// No chat was recorded for this meeting
+			case err != nil:
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to get meeting chat: %v", err)), nil
+			default:
+				items, uErr := UnmarshalPageItems[meetingChat](page)
+				if uErr != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("Failed to parse meeting chat: %v", uErr)), nil
+				}
+				chats, truncated, fetchErr = FetchAll(items, page.HasNext, page.NextPage, client, meetingChatCap)
+			}
+
+			if format == "json" {
+				response := map[string]interface{}{
+					"meetingId": meetingID,
+					"chats":     chats,
+				}
+				AddCountToMap(response, "count", len(chats), truncated || fetchErr != nil)
+				AddFetchErrorToMap(response, fetchErr)
+				if len(chats) == 0 {
+					response["message"] = "No in-meeting chat is available for this meeting."
+				}
+				data, _ := json.MarshalIndent(response, "", "  ")
+				return mcp.NewToolResultText(string(data)), nil
+			}
+
+			if len(chats) == 0 {
+				return mcp.NewToolResultText("No in-meeting chat is available for this meeting."), nil
+			}
+			content := renderMeetingChat(chats)
+			if fetchErr != nil {
+				content += fmt.Sprintf("\n\n[Chat incomplete: only %d messages could be read (%v). Retry for the rest.]", len(chats), fetchErr)
+			} else if truncated {
+				content += fmt.Sprintf("\n\n[Chat truncated after %d messages]", len(chats))
+			}
+			return mcp.NewToolResultText(content), nil
+		},
+	)
+
+	// webex_meetings_attendance
+	s.AddTool(
+		mcp.NewTool("webex_meetings_attendance",
+			mcp.WithDescription("Report attendance across the occurrences of a recurring meeting: who joined which occurrence, how many of them each person attended, and their total minutes.\n"+
+				"\n"+
+				"USE THIS WHEN:\n"+
+				"- 'Who has been skipping the weekly standup?'\n"+
+				"- 'How many of the last sprint reviews did <person> attend?'\n"+
+				"- 'Give me an attendance report for my 1:1 series this quarter.'\n"+
+				"\n"+
+				"To find the meetingSeriesId: use webex_meetings_list without meetingType (series are the default) or take meetingSeriesId from any occurrence.\n"+
+				"\n"+
+				"RESPONSE:\n"+
+				"- occurrences: The held meetings of the series in the window, oldest first, with meetingId, start, end, and participantCount.\n"+
+				"- people: One row per person with name, email, invited (on the series invitee list), attended, of (occurrences counted), totalMinutes, "+
+				"and present (one true/false per occurrence, in the same order: the attendance matrix). Lowest attendance first; invitees who never joined have attended=0.\n"+
+				"- skippedOccurrences: Occurrences whose participants could not be read in full (a failed page, or more than 1000 participants), with the reason. They are not counted in 'of'.\n"+
+				"- truncated: true if not every occurrence in the window is reported. If more than 50 occurrences were found, only the most recent 50 are reported. "+
+				"If the window held more than 500 meetings (scanTruncated=true), only the first 500 Webex listed were examined, which are not necessarily the most recent -- narrow from/to for a complete report.\n"+
+				"\n"+
+				"NOTE: Participant lists are normally available to the host only. People are matched across occurrences by email, or by display name when no email is given."),
+			mcp.WithString("meetingSeriesId", mcp.Required(), mcp.Description("The ID of the meeting series. Get this from webex_meetings_list (meetingType='meetingSeries', the default).")),
+			mcp.WithString("from", mcp.Description("Start of the window (UTC format: '2026-01-01T00:00:00Z'). Default: 90 days before 'to'.")),
+			mcp.WithString("to", mcp.Description("End of the window (UTC format: '2026-03-31T23:59:59Z'). Default: now. The window may be at most 366 days.")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			client, err := resolver(ctx)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Auth error: %v", err)), nil
+			}
+
+			seriesID, err := req.RequireString("meetingSeriesId")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			seriesID = strings.TrimSpace(seriesID)
+
+			to := time.Now().UTC()
+			if v := req.GetString("to", ""); v != "" {
+				converted, err := validateAndConvertISO8601(v, "to")
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				to, _ = time.Parse(time.RFC3339, converted)
+			}
+			from := to.Add(-defaultAttendanceWindow)
+			if v := req.GetString("from", ""); v != "" {
+				converted, err := validateAndConvertISO8601(v, "from")
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				from, _ = time.Parse(time.RFC3339, converted)
+			}
+			if !to.After(from) {
+				return mcp.NewToolResultError("'to' must be after 'from'"), nil
+			}
+			if to.Sub(from) > maxAttendanceWindow {
+				return mcp.NewToolResultError("The window between 'from' and 'to' may be at most 366 days"), nil
+			}
+			fromStr, toStr := from.Format(time.RFC3339), to.Format(time.RFC3339)
+
+			// The SDK's list options have no meetingSeriesId, so the held meetings are listed directly
+			params := url.Values{}
+			params.Set("meetingType", "meeting")
+			params.Set("meetingSeriesId", seriesID)
+			params.Set("from", fromStr)
+			params.Set("to", toStr)
+			params.Set("max", strconv.Itoa(CatalogPageSize))
+			page, err := FetchPage(client, client.Core().BaseURL.String()+"/meetings?"+params.Encode())
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to list meetings: %v", err)), nil
+			}
+			items, err := UnmarshalPageItems[meetings.Meeting](page)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to parse meetings: %v", err)), nil
+			}
+			items, scanTruncated, fetchErr := FetchAll(items, page.HasNext, page.NextPage, client, attendanceScanCap)
+
+			var held []meetings.Meeting
+			for _, m := range items {
+				if m.MeetingSeriesID == seriesID {
+					held = append(held, m)
+				}
+			}
+			sort.SliceStable(held, func(i, j int) bool { return held[i].Start > held[j].Start })
+			truncated := scanTruncated || len(held) > attendanceOccurrenceCap
+			if len(held) > attendanceOccurrenceCap {
+				countBudgetHit(client)
+				held = held[:attendanceOccurrenceCap]
+			}
+
+			// Read each occurrence's participants with bounded concurrency
+			results := make([]occurrenceParticipants, len(held))
+			errs := make([]error, len(held))
+			sem := make(chan struct{}, attendanceConcurrency)
+			var wg sync.WaitGroup
+			for i, m := range held {
+				wg.Add(1)
+				go func(i int, m meetings.Meeting) {
+					defer wg.Done()
+					sem <- struct{}{}
+					defer func() { <-sem }()
+
+					pPage, pErr := client.Meetings().ListParticipants(&meetings.ParticipantListOptions{
+						MeetingID: m.ID,
+						Max:       CatalogPageSize,
+					})
+					if pErr != nil {
+						errs[i] = pErr
+						return
+					}
+					participants, pTruncated, pErr := FetchAll(pPage.Items, pPage.HasNext, pPage.NextPage, client, attendanceParticipantCap)
+					// Counting a partial list would understate attendance
+					if pErr != nil {
+						errs[i] = pErr
+						return
+					}
+					if pTruncated {
+						errs[i] = fmt.Errorf("more than %d participants; too many to count", attendanceParticipantCap)
+						return
+					}
+					results[i] = occurrenceParticipants{meeting: m, participants: participants}
+				}(i, m)
+			}
+			wg.Wait()
+
+			var counted []occurrenceParticipants
+			skipped := make([]map[string]string, 0)
+			for i, m := range held {
+				if errs[i] != nil {
+					log.Printf("[meetings] attendance: failed to list participants of %s: %v", m.ID, errs[i])
+					skipped = append(skipped, map[string]string{
+						"meetingId": m.ID,
+						"start":     m.Start,
+						"error":     errs[i].Error(),
+					})
+					continue
+				}
+				counted = append(counted, results[i])
+			}
+
+			invitees, inviteesTruncated, iErr := listMeetingInvitees(client, seriesID)
+			if iErr != nil {
+				log.Printf("Enrichment: failed to list invitees of series %s: %v", seriesID, iErr)
+			}
+			countEnrichment(client)
+
+			occurrences, people := buildAttendance(counted, invitees)
+			resolveAttendanceNames(client, people)
+
+			response := map[string]interface{}{
+				"meetingSeriesId":    seriesID,
+				"occurrences":        occurrences,
+				"people":             people,
+				"skippedOccurrences": skipped,
+				"truncated":          truncated,
+				"scanTruncated":      scanTruncated,
+				"window": map[string]string{
+					"from": fromStr,
+					"to":   toStr,
+				},
+			}
+			AddFetchErrorToMap(response, fetchErr)
+			if scanTruncated {
+				response["scanNote"] = fmt.Sprintf("The window held more than %d meetings and only the first %d listed were examined; they may not be the most recent. Narrow from/to for a complete report.", attendanceScanCap, attendanceScanCap)
+			}
+			if iErr != nil && len(invitees) == 0 {
+				response["inviteesNote"] = "The series invitee list could not be read, so only people who joined at least once are listed."
+			} else if note := inviteeListNote(inviteesTruncated, iErr); note != "" {
+				response["inviteesNote"] = note + " Missing invitees who never joined are not listed."
+			}
+			if len(held) == 0 {
+				response["message"] = "No held occurrences of this series were found in the window."
+			}
+
+			data, _ := json.MarshalIndent(response, "", "  ")
+			return mcp.NewToolResultText(string(data)), nil
+		},
+	)
 }
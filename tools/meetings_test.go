@@ -0,0 +1,505 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	webex "github.com/WebexCommunity/webex-go-sdk/v2"
+	"github.com/WebexCommunity/webex-go-sdk/v2/meetings"
+	"github.com/WebexCommunity/webex-go-sdk/v2/webexsdk"
+	"github.com/tejzpr/webex-go-mcp/auth"
+)
+
+func TestMatchMeetingSite(t *testing.T) {
+	sites := []meetingSite{
+		{SiteURL: "corp.webex.com", Default: true},
+		{SiteURL: "training.webex.com"},
+	}
+	tests := []struct {
+		requested string
+		want      string
+		ok        bool
+	}{
+		{"corp.webex.com", "corp.webex.com", true},
+		{"https://Training.webex.com/", "training.webex.com", true},
+		{"other.webex.com", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := matchMeetingSite(sites, tt.requested)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("matchMeetingSite(%q) = (%q, %v), want (%q, %v)", tt.requested, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestMeetingsCreateSiteLookupFails(t *testing.T) {
+	created := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/meetingPreferences/sites":
+			http.Error(w, `{"message":"server busy"}`, http.StatusInternalServerError)
+		case "/meetings":
+			created = true
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"id":"m1"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	register := func(s ToolRegistrar, r auth.ClientResolver) { RegisterMeetingTools(s, r, nil) }
+	result := callTool(t, register, srv.URL, "webex_meetings_create", map[string]interface{}{
+		"title":   "Planning",
+		"start":   "2026-03-02T10:00:00Z",
+		"end":     "2026-03-02T10:30:00Z",
+		"siteUrl": "typo.webex.com",
+	})
+	// An unvalidated siteUrl must not reach the create call
+	if !result.IsError || created || !strings.Contains(resultText(result), "Webex sites") {
+		t.Errorf("result = %q, created = %v; want a site lookup error and no meeting created", resultText(result), created)
+	}
+}
+
+func TestIsMeetingLive(t *testing.T) {
+	tests := []struct {
+		state string
+		want  bool
+	}{
+		{"inProgress", true},
+		{"INPROGRESS", true},
+		{"active", false},
+		{"scheduled", false},
+		{"ended", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := isMeetingLive(tt.state); got != tt.want {
+			t.Errorf("isMeetingLive(%q) = %v, want %v", tt.state, got, tt.want)
+		}
+	}
+}
+
+func TestCoHostsFromInvitees(t *testing.T) {
+	invitees := []meetings.Invitee{
+		{Email: "alice@example.com", DisplayName: "Alice", CoHost: true},
+		{Email: "bob@example.com"},
+		{Email: "carol@example.com", CoHost: true},
+	}
+	got := coHostsFromInvitees(invitees)
+	want := []meetingCoHost{
+		{Email: "alice@example.com", DisplayName: "Alice"},
+		{Email: "carol@example.com"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("coHostsFromInvitees() = %v, want %v", got, want)
+	}
+}
+
+func TestListMeetingInviteesPages(t *testing.T) {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("cursor") {
+		case "":
+			w.Header().Set("Link", "<"+srv.URL+"/meetingInvitees?cursor=2>; rel=\"next\"")
+			w.Write([]byte(`{"items":[{"email":"alice@example.com"}]}`))
+		case "2":
+			w.Header().Set("Link", "<"+srv.URL+"/meetingInvitees?cursor=3>; rel=\"next\"")
+			w.Write([]byte(`{"items":[{"email":"bob@example.com","coHost":true}]}`))
+		default:
+			http.Error(w, `{"message":"server busy"}`, http.StatusBadRequest)
+		}
+	}))
+	defer srv.Close()
+	client, err := webex.NewClient("test-token", &webexsdk.Config{BaseURL: srv.URL})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	// Invitees past the first page are read, and the failed third page is reported
+	invitees, _, err := listMeetingInvitees(client, "m1")
+	if err == nil || len(invitees) != 2 || invitees[1].Email != "bob@example.com" {
+		t.Errorf("listMeetingInvitees() = %+v, err=%v; want both pages and an error for the third", invitees, err)
+	}
+}
+
+func TestSummarizeMeetingSecurity(t *testing.T) {
+	security := summarizeMeetingSecurity(&meetings.Meeting{
+		EnabledJoinBeforeHost:       true,
+		JoinBeforeHostMinutes:       5,
+		UnlockedMeetingJoinSecurity: "allowJoinWithLobby",
+	})
+
+	jbh := security["joinBeforeHost"].(map[string]interface{})
+	if jbh["enabled"] != true || jbh["minutesBeforeStart"] != 5 {
+		t.Errorf("joinBeforeHost = %v, want enabled with 5 minutes", jbh)
+	}
+	lock := security["automaticLock"].(map[string]interface{})
+	if _, ok := lock["minutesAfterStart"]; ok {
+		t.Errorf("automaticLock = %v, want no minutes when disabled", lock)
+	}
+	waiting, ok := security["waitingRoom"].(map[string]interface{})
+	if !ok || waiting["enabled"] != true {
+		t.Errorf("waitingRoom = %v, want enabled", security["waitingRoom"])
+	}
+
+	if _, ok := summarizeMeetingSecurity(&meetings.Meeting{})["waitingRoom"]; ok {
+		t.Error("waitingRoom should be omitted when unlockedMeetingJoinSecurity is absent")
+	}
+}
+
+func TestFilterInvitedMeetings(t *testing.T) {
+	items := []meetings.Meeting{
+		{ID: "m3", Start: "2026-02-08T10:00:00Z", HostUserID: "other", HostEmail: "bob@example.com"},
+		{ID: "m1", Start: "2026-02-06T10:00:00Z", HostUserID: "me", HostEmail: "me@example.com"},
+		{ID: "m2", Start: "2026-02-07T10:00:00Z", HostUserID: "", HostEmail: "Me@Example.com"},
+		{ID: "m4", Start: "2026-02-06T09:00:00Z", HostUserID: "another", HostEmail: "carol@example.com"},
+	}
+	got := filterInvitedMeetings(items, "me", []string{"me@example.com"})
+	var ids []string
+	for _, m := range got {
+		ids = append(ids, m.ID)
+	}
+	want := []string{"m4", "m3"}
+	if !reflect.DeepEqual(ids, want) {
+		t.Errorf("filterInvitedMeetings() ids = %v, want %v", ids, want)
+	}
+}
+
+func TestMeetingsListInvitationsFiltersBeforeBudget(t *testing.T) {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/people/me":
+			w.Write([]byte(`{"id":"me","emails":["me@example.com"]}`))
+		case r.URL.Path == "/meetings" && r.URL.Query().Get("cursor") == "3":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"message":"server busy"}`))
+		case r.URL.Path == "/meetings" && r.URL.Query().Get("cursor") == "2":
+			w.Header().Set("Link", "<"+srv.URL+"/meetings?cursor=3>; rel=\"next\"")
+			w.Write([]byte(`{"items":[{"id":"m3","start":"2026-03-03T10:00:00Z","hostUserId":"bob","hostDisplayName":"Bob"}]}`))
+		case r.URL.Path == "/meetings":
+			// The user's own meetings come first and must not use up maxResults.
+			w.Header().Set("Link", "<"+srv.URL+"/meetings?cursor=2>; rel=\"next\"")
+			w.Write([]byte(`{"items":[{"id":"m1","start":"2026-03-01T10:00:00Z","hostUserId":"me"},{"id":"m2","start":"2026-03-02T10:00:00Z","hostEmail":"ME@example.com"}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	register := func(s ToolRegistrar, r auth.ClientResolver) { RegisterMeetingTools(s, r, nil) }
+	result := callTool(t, register, srv.URL, "webex_meetings_list_invitations", map[string]interface{}{
+		"from":       "2026-03-01T00:00:00Z",
+		"to":         "2026-03-08T00:00:00Z",
+		"maxResults": float64(1),
+	})
+	if result.IsError {
+		t.Fatalf("list_invitations failed: %s", resultText(result))
+	}
+	var got struct {
+		Invitations       []map[string]interface{} `json:"invitations"`
+		Count             int                      `json:"count"`
+		CountIsLowerBound bool                     `json:"countIsLowerBound"`
+		Partial           bool                     `json:"partial"`
+		FetchError        string                   `json:"fetchError"`
+	}
+	if err := json.Unmarshal([]byte(resultText(result)), &got); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if len(got.Invitations) != 1 || got.Invitations[0]["meetingId"] != "m3" {
+		t.Errorf("invitations = %v, want only m3", got.Invitations)
+	}
+	if !got.CountIsLowerBound || !got.Partial || got.FetchError == "" {
+		t.Errorf("count=%d lowerBound=%v partial=%v fetchError=%q; want a flagged partial count", got.Count, got.CountIsLowerBound, got.Partial, got.FetchError)
+	}
+}
+
+func TestFormatMeetingWhen(t *testing.T) {
+	tests := []struct {
+		start, end, tz string
+		want, wantTZ   string
+	}{
+		{"2026-02-06T19:00:00Z", "2026-02-06T19:30:00Z", "America/New_York", "Friday, February 6, 2026, 2:00 PM - 2:30 PM EST", "America/New_York"},
+		{"2026-02-06T23:00:00Z", "2026-02-07T01:00:00Z", "", "Friday, February 6, 2026, 11:00 PM - Saturday, February 7, 2026, 1:00 AM UTC", "UTC"},
+		{"not-a-time", "", "UTC", "not-a-time", "UTC"},
+	}
+	for _, tt := range tests {
+		got, gotTZ := formatMeetingWhen(tt.start, tt.end, tt.tz)
+		if got != tt.want || gotTZ != tt.wantTZ {
+			t.Errorf("formatMeetingWhen(%q, %q, %q) = (%q, %q), want (%q, %q)", tt.start, tt.end, tt.tz, got, gotTZ, tt.want, tt.wantTZ)
+		}
+	}
+}
+
+func TestRenderMeetingInviteMarkdown(t *testing.T) {
+	invite := buildMeetingInvite(&meetings.Meeting{
+		Title:           "Weekly Sync",
+		Start:           "2026-02-06T14:00:00Z",
+		End:             "2026-02-06T14:30:00Z",
+		HostDisplayName: "Alice",
+		HostEmail:       "alice@example.com",
+		WebLink:         "https://example.webex.com/meet/abc",
+		MeetingNumber:   "123 456 789",
+		Agenda:          "Status updates",
+		Telephony: &meetings.Telephony{
+			AccessCode:    "1234567",
+			CallInNumbers: []meetings.CallInNumber{{Label: "US Toll", CallInNumber: "+1-555-0100"}},
+		},
+	}, []meetings.Invitee{{Email: "bob@example.com", DisplayName: "Bob"}})
+
+	md := renderMeetingInviteMarkdown(invite)
+	for _, want := range []string{
+		"## Weekly Sync",
+		"**When:** Friday, February 6, 2026, 2:00 PM - 2:30 PM UTC (UTC)",
+		"**Host:** Alice (alice@example.com)",
+		"- **Join link:** https://example.webex.com/meet/abc",
+		"- US Toll: +1-555-0100",
+		"- **Access code:** 1234567",
+		"### Agenda\n\nStatus updates",
+		"- Bob <bob@example.com>",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown missing %q:\n%s", want, md)
+		}
+	}
+	if strings.Contains(md, "Password") {
+		t.Errorf("markdown should omit empty password:\n%s", md)
+	}
+}
+
+func TestComputeReschedule(t *testing.T) {
+	now := time.Date(2026, 2, 6, 12, 0, 0, 0, time.UTC)
+	start, end := "2026-02-06T14:00:00-05:00", "2026-02-06T14:30:00-05:00"
+
+	tests := []struct {
+		name      string
+		offset    int
+		newStart  string
+		wantStart string
+		wantEnd   string
+		wantErr   bool
+	}{
+		{"push back 30 minutes", 30, "", "2026-02-06T14:30:00-05:00", "2026-02-06T15:00:00-05:00", false},
+		{"pull in 15 minutes", -15, "", "2026-02-06T13:45:00-05:00", "2026-02-06T14:15:00-05:00", false},
+		{"explicit new start keeps duration", 0, "2026-02-12T15:00:00Z", "2026-02-12T10:00:00-05:00", "2026-02-12T10:30:00-05:00", false},
+		{"new start in the past", 0, "2026-02-06T11:00:00Z", "", "", true},
+		{"offset into the past", -600, "", "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotStart, gotEnd, err := computeReschedule(start, end, tt.offset, tt.newStart, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if s := gotStart.Format(time.RFC3339); s != tt.wantStart {
+				t.Errorf("start = %s, want %s", s, tt.wantStart)
+			}
+			if e := gotEnd.Format(time.RFC3339); e != tt.wantEnd {
+				t.Errorf("end = %s, want %s", e, tt.wantEnd)
+			}
+		})
+	}
+
+	if _, _, err := computeReschedule("", end, 30, "", now); err == nil {
+		t.Error("expected error for meeting without a start time")
+	}
+}
+
+func TestFindMeetingConflicts(t *testing.T) {
+	items := []meetings.Meeting{
+		{ID: "standup", Title: "Standup", Start: "2026-02-06T09:00:00Z", End: "2026-02-06T09:30:00Z"},
+		{ID: "review", Title: "Review", Start: "2026-02-06T09:15:00Z", End: "2026-02-06T10:00:00Z"},
+		{ID: "1on1", Title: "1:1", Start: "2026-02-06T10:00:00Z", End: "2026-02-06T10:30:00Z"},
+		{ID: "allhands", Title: "All hands", Start: "2026-02-06T09:00:00Z", End: "2026-02-06T11:00:00Z"},
+		{ID: "cancelled", Title: "Cancelled", Start: "2026-02-06T09:00:00Z", End: "2026-02-06T10:00:00Z", State: "cancelled"},
+	}
+
+	got := findMeetingConflicts(items)
+
+	type pair struct {
+		first, second string
+		minutes       int
+	}
+	want := []pair{
+		{"standup", "allhands", 30},
+		{"standup", "review", 15},
+		{"allhands", "review", 45},
+		{"allhands", "1on1", 30},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d conflicts, want %d: %+v", len(got), len(want), got)
+	}
+	for i, w := range want {
+		if got[i].First.MeetingID != w.first || got[i].Second.MeetingID != w.second || got[i].OverlapMinutes != w.minutes {
+			t.Errorf("conflict[%d] = %s/%s %dm, want %s/%s %dm", i, got[i].First.MeetingID, got[i].Second.MeetingID, got[i].OverlapMinutes, w.first, w.second, w.minutes)
+		}
+	}
+}
+
+func TestMergeInvitees(t *testing.T) {
+	got := mergeInvitees(
+		[]string{" alice@example.com", "", "Bob@example.com"},
+		[]string{"bob@example.com", "carol@example.com", "ALICE@example.com"},
+	)
+	want := []meetings.Invitee{
+		{Email: "alice@example.com"},
+		{Email: "Bob@example.com"},
+		{Email: "carol@example.com"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("mergeInvitees() = %+v, want %+v", got, want)
+	}
+	if got := mergeInvitees(nil, nil); len(got) != 0 {
+		t.Errorf("mergeInvitees(nil, nil) = %+v, want empty", got)
+	}
+}
+
+func TestRoomInviteeEmailsPageError(t *testing.T) {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("cursor") != "" {
+			http.Error(w, `{"message":"server busy"}`, http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Link", "<"+srv.URL+"/memberships?cursor=2>; rel=\"next\"")
+		w.Write([]byte(`{"items":[{"personEmail":"host@example.com"},{"personEmail":"alice@example.com"},{"personEmail":"helper@webex.bot"}]}`))
+	}))
+	defer srv.Close()
+	client, err := webex.NewClient("test-token", &webexsdk.Config{BaseURL: srv.URL})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	// The second page fails: that is an error, not a room over the invitee cap
+	emails, _, truncated, err := roomInviteeEmails(client, "room-1", "host@example.com")
+	if err == nil {
+		t.Fatalf("roomInviteeEmails() = %v, truncated=%v; want an error for the failed page", emails, truncated)
+	}
+}
+
+func TestRenderMeetingChat(t *testing.T) {
+	chats := []meetingChat{
+		{ChatTime: "2026-03-02T10:05:00Z", Text: "Slides: https://example.com/deck", Type: "public", Sender: meetingChatPerson{DisplayName: "Bob"}},
+		{ChatTime: "2026-03-02T10:01:00Z", Text: " Can everyone hear me? ", Type: "public", Sender: meetingChatPerson{DisplayName: "Alice"}},
+		{ChatTime: "2026-03-02T10:03:00Z", Text: "running late", Type: "private", Sender: meetingChatPerson{Email: "carol@example.com"},
+			Receivers: []meetingChatPerson{{DisplayName: "Alice"}}},
+		{ChatTime: "2026-03-02T10:04:00Z", Text: "  ", Sender: meetingChatPerson{DisplayName: "Dan"}},
+	}
+	want := "[2026-03-02T10:01:00Z] Alice: Can everyone hear me?\n" +
+		"[2026-03-02T10:03:00Z] carol@example.com (privately to Alice): running late\n" +
+		"[2026-03-02T10:05:00Z] Bob: Slides: https://example.com/deck"
+	if got := renderMeetingChat(chats); got != want {
+		t.Errorf("renderMeetingChat() =\n%s\nwant\n%s", got, want)
+	}
+	if chats[0].Sender.DisplayName != "Bob" {
+		t.Error("renderMeetingChat reordered its input")
+	}
+}
+
+func TestBuildAttendance(t *testing.T) {
+	held := []occurrenceParticipants{
+		{
+			meeting: meetings.Meeting{ID: "m2", Start: "2026-03-09T10:00:00Z", End: "2026-03-09T10:30:00Z"},
+			participants: []meetings.Participant{
+				{Email: "Alice@example.com", DisplayName: "Alice", JoinedTime: "2026-03-09T10:00:00Z", LeftTime: "2026-03-09T10:30:00Z"},
+				// Rejoined after a drop: two records, one attendance, minutes summed
+				{Email: "bob@example.com", JoinedTime: "2026-03-09T10:00:00Z", LeftTime: "2026-03-09T10:10:00Z"},
+				{Email: "bob@example.com", JoinedTime: "2026-03-09T10:12:00Z"},
+			},
+		},
+		{
+			meeting: meetings.Meeting{ID: "m1", Start: "2026-03-02T10:00:00Z", End: "2026-03-02T10:30:00Z"},
+			participants: []meetings.Participant{
+				{Email: "alice@example.com", DisplayName: "Alice", JoinedTime: "2026-03-02T10:05:00Z", LeftTime: "2026-03-02T10:30:00Z"},
+				{DisplayName: "Dial-in User", JoinedTime: "2026-03-02T10:00:00Z", LeftTime: "2026-03-02T10:01:00Z"},
+			},
+		},
+	}
+	invitees := []meetings.Invitee{
+		{Email: "alice@example.com", DisplayName: "Alice"},
+		{Email: "carol@example.com", DisplayName: "Carol"},
+	}
+
+	occurrences, people := buildAttendance(held, invitees)
+
+	if len(occurrences) != 2 || occurrences[0].MeetingID != "m1" || occurrences[1].MeetingID != "m2" {
+		t.Fatalf("occurrences = %+v, want m1 then m2", occurrences)
+	}
+	if occurrences[0].ParticipantCount != 2 || occurrences[1].ParticipantCount != 2 {
+		t.Errorf("participant counts = %d, %d; want 2, 2", occurrences[0].ParticipantCount, occurrences[1].ParticipantCount)
+	}
+
+	want := []attendanceRow{
+		{Name: "Carol", Email: "carol@example.com", Invited: true, Attended: 0, Of: 2, TotalMinutes: 0, Present: []bool{false, false}},
+		{Name: "Dial-in User", Attended: 1, Of: 2, TotalMinutes: 1, Present: []bool{true, false}},
+		{Email: "bob@example.com", Attended: 1, Of: 2, TotalMinutes: 28, Present: []bool{false, true}},
+		{Name: "Alice", Email: "alice@example.com", Invited: true, Attended: 2, Of: 2, TotalMinutes: 55, Present: []bool{true, true}},
+	}
+	if !reflect.DeepEqual(people, want) {
+		t.Errorf("buildAttendance() people =\n%+v\nwant\n%+v", people, want)
+	}
+	if held[0].meeting.ID != "m2" {
+		t.Error("buildAttendance reordered its input")
+	}
+}
+
+func TestMeetingsAttendanceSkipsTruncatedParticipants(t *testing.T) {
+	var participants strings.Builder
+	participants.WriteString(`{"items":[`)
+	for i := 0; i <= attendanceParticipantCap; i++ {
+		if i > 0 {
+			participants.WriteString(",")
+		}
+		fmt.Fprintf(&participants, `{"email":"p%d@example.com","displayName":"P%d"}`, i, i)
+	}
+	participants.WriteString(`]}`)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/meetings":
+			w.Write([]byte(`{"items":[{"id":"occ1","meetingSeriesId":"s1","start":"2026-03-02T10:00:00Z","end":"2026-03-02T10:30:00Z"}]}`))
+		case "/meetingParticipants":
+			w.Write([]byte(participants.String()))
+		case "/meetingInvitees":
+			w.Write([]byte(`{"items":[]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	register := func(s ToolRegistrar, r auth.ClientResolver) { RegisterMeetingTools(s, r, nil) }
+	result := callTool(t, register, srv.URL, "webex_meetings_attendance", map[string]interface{}{
+		"meetingSeriesId": "s1",
+		"from":            "2026-03-01T00:00:00Z",
+		"to":              "2026-03-08T00:00:00Z",
+	})
+	if result.IsError {
+		t.Fatalf("attendance failed: %s", resultText(result))
+	}
+	var got struct {
+		Occurrences []interface{}       `json:"occurrences"`
+		Skipped     []map[string]string `json:"skippedOccurrences"`
+	}
+	if err := json.Unmarshal([]byte(resultText(result)), &got); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	// Counting only the first 1000 participants would understate attendance.
+	if len(got.Occurrences) != 0 || len(got.Skipped) != 1 || got.Skipped[0]["meetingId"] != "occ1" {
+		t.Errorf("occurrences=%v skipped=%v; want occ1 skipped", got.Occurrences, got.Skipped)
+	}
+}
@@ -5,31 +5,40 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"log"
 	"mime"
+	"net/url"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 
+	webex "github.com/WebexCommunity/webex-go-sdk/v2"
+	"github.com/WebexCommunity/webex-go-sdk/v2/memberships"
 	"github.com/WebexCommunity/webex-go-sdk/v2/messages"
+	"github.com/WebexCommunity/webex-go-sdk/v2/rooms"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/tejzpr/webex-go-mcp/auth"
 )
 
 // RegisterMessageTools registers all message-related MCP tools.
-func RegisterMessageTools(s ToolRegistrar, resolver auth.ClientResolver) {
+// prefs may be nil; when set, webex_messages_list falls back to the user's stored default room.
+func RegisterMessageTools(s ToolRegistrar, resolver auth.ClientResolver, prefs auth.PreferenceStore) {
 	// webex_messages_list
 	s.AddTool(
 		mcp.NewTool("webex_messages_list",
-			mcp.WithDescription("List messages in a Webex room/space. Requires a roomId.\n"+
+			mcp.WithDescription("List messages in a Webex room/space. Requires a roomId, unless the user saved a defaultRoomId preference.\n"+
 				"\n"+
 				"HOW TO GET A ROOM ID:\n"+
 				"- To read messages from a group space: use webex_rooms_list to find it by name.\n"+
 				"- To read a 1:1 conversation with someone: use webex_rooms_list with type='direct' to list all 1:1 rooms. The room title for 1:1 rooms is the other person's display name.\n"+
 				"- If you already have a roomId from a previous response, use it directly.\n"+
 				"\n"+
-				"RESPONSE: Enriched with room title, sender display names (resolved from IDs), and file attachment metadata (filename, size, content-type) for each message."+
+				"RESPONSE: Enriched with room title, sender display names (resolved from IDs), and file attachment metadata (filename, size, content-type) for each message. Messages carrying Adaptive Cards include an 'attachments' array with the card JSON and its input elements (not in compact mode)."+
 				PaginationDescription),
-			mcp.WithString("roomId", mcp.Required(), mcp.Description("The ID of the room/space to list messages from. Get this from webex_rooms_list, or from a previous API response.")),
+			mcp.WithString("roomId", mcp.Description("The ID of the room/space to list messages from. Get this from webex_rooms_list, or from a previous API response. Omit to use the user's saved defaultRoomId preference.")),
 			mcp.WithString("mentionedPeople", mcp.Description("Filter to only messages that mention specific people. Use the special value 'me' to find messages that mention the authenticated user. Otherwise pass a personId.")),
 			mcp.WithString("before", mcp.Description("List messages sent before this date/time (ISO 8601 format, e.g. '2026-02-01T00:00:00Z'). Useful for searching messages in a date range.")),
 			mcp.WithNumber("maxResults", mcp.Description(MaxResultsParamDescription)),
@@ -42,9 +51,12 @@ func RegisterMessageTools(s ToolRegistrar, resolver auth.ClientResolver) {
 				return mcp.NewToolResultError(fmt.Sprintf("Auth error: %v", err)), nil
 			}
 
-			roomID, err := req.RequireString("roomId")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
+			roomID := req.GetString("roomId", "")
+			if roomID == "" {
+				roomID = lookupPreference(prefs, client, PrefDefaultRoomID)
+			}
+			if roomID == "" {
+				return mcp.NewToolResultError("roomId is required (no defaultRoomId preference is saved)"), nil
 			}
 
 			nextPageUrl := req.GetString("nextPageUrl", "")
@@ -140,6 +152,10 @@ func RegisterMessageTools(s ToolRegistrar, resolver auth.ClientResolver) {
 							em["files"] = fileInfos
 						}
 					}
+
+					if atts := summarizeAttachments(msg.Attachments); len(atts) > 0 {
+						em["attachments"] = atts
+					}
 				}
 
 				enrichedMessages = append(enrichedMessages, em)
@@ -161,22 +177,34 @@ func RegisterMessageTools(s ToolRegistrar, resolver auth.ClientResolver) {
 				"→ Use toPersonEmail with their email address. That's it. ONE call. Do NOT look up rooms, people, or IDs first.\n"+
 				"\n"+
 				"WHEN THE USER SAYS 'send a message to <room name>' or 'post in <space name>':\n"+
-				"→ Use webex_rooms_list to find the roomId, then use roomId here.\n"+
+				"→ Pass the space's title as roomName. It is resolved to a roomId for you; if several spaces share the title you get the candidates back and must pick a roomId.\n"+
 				"\n"+
 				"QUICK REFERENCE:\n"+
 				"- Have an email? → toPersonEmail (direct DM, no lookup needed)\n"+
-				"- Have a room/space name? → find roomId via webex_rooms_list first, then roomId\n"+
+				"- Have a room/space name? → roomName (exact title, case-insensitive)\n"+
 				"- Have a personId from a previous call? → toPersonId\n"+
 				"- Have a roomId from a previous call? → roomId\n"+
 				"\n"+
 				"To send files/attachments, use webex_messages_send_attachment instead.\n"+
 				"\n"+
+				"MENTIONS: To @mention people in a group space, pass their emails in 'mentions' (requires roomId). Each email is resolved to a personId and the mention markup is added to the start of the message for you. "+
+				"People who are not members of the room are still mentioned, but a warning is returned.\n"+
+				"\n"+
+				"MARKDOWN: Webex clients render markdown. When you send only markdown, a plain-text copy (formatting stripped) is also sent as a fallback for clients that can't render it, unless the server disables this. "+
+				"Put formatted content in markdown, not text: markdown syntax in text is shown literally (depending on server settings it is instead detected and sent as markdown; either way a warning says so). "+
+				"Set plainText=true when the destination must receive plain text only; formatting is then stripped.\n"+
+				"\n"+
+				"NOTE: text and markdown are trimmed. Content that is empty, whitespace-only, formatting-only, or just a mention with no body is rejected -- always include some visible text.\n"+
+				"\n"+
 				"IMPORTANT: Always confirm with the user before sending, unless they explicitly said not to."),
 			mcp.WithString("roomId", mcp.Description("Room/space ID. Use ONLY when sending to a group space or when you already have a roomId. Do NOT look up a room just to DM someone -- use toPersonEmail instead.")),
+			mcp.WithString("roomName", mcp.Description(roomNameParamDescription)),
 			mcp.WithString("toPersonId", mcp.Description("Person ID for a direct 1:1 message. Use only if you already have it from a previous API response.")),
 			mcp.WithString("toPersonEmail", mcp.Description("Email address for a direct 1:1 message (e.g. 'alice@example.com'). USE THIS when the user provides an email. No room lookup or person lookup needed -- Webex handles everything.")),
 			mcp.WithString("text", mcp.Description("Plain text message content.")),
 			mcp.WithString("markdown", mcp.Description("Rich text using Webex markdown (bold, italic, links, code blocks, lists). Use this when formatting is desired.")),
+			mcp.WithString("mentions", mcp.Description("Comma-separated emails of people to @mention (e.g. 'alice@example.com,bob@example.com'). Requires roomId. The mentions are prepended to the message -- do not hand-write mention markup for them.")),
+			mcp.WithBoolean("plainText", mcp.Description("Send plain text only: markdown formatting in text or markdown is stripped and no markdown is sent. Cannot be combined with mentions. Default: false.")),
 		),
 		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			client, err := resolver(ctx)
@@ -188,15 +216,56 @@ func RegisterMessageTools(s ToolRegistrar, resolver auth.ClientResolver) {
 				RoomID:        req.GetString("roomId", ""),
 				ToPersonID:    req.GetString("toPersonId", ""),
 				ToPersonEmail: req.GetString("toPersonEmail", ""),
-				Text:          req.GetString("text", ""),
-				Markdown:      req.GetString("markdown", ""),
+				Text:          strings.TrimSpace(req.GetString("text", "")),
+				Markdown:      strings.TrimSpace(req.GetString("markdown", "")),
 			}
 
-			if msg.RoomID == "" && msg.ToPersonID == "" && msg.ToPersonEmail == "" {
-				return mcp.NewToolResultError("One of roomId, toPersonId, or toPersonEmail is required"), nil
+			if err := resolveMessageDestination(client, req, msg); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if err := validateMessageContent(msg.Text, msg.Markdown); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Invalid message content: %v", err)), nil
+			}
+
+			var targets []mentionTarget
+			var warnings []string
+			mentions := strings.TrimSpace(req.GetString("mentions", ""))
+
+			if req.GetBool("plainText", false) {
+				if mentions != "" {
+					return mcp.NewToolResultError("mentions cannot be combined with plainText; @mentions need markdown"), nil
+				}
+				body := msg.Text
+				if body == "" {
+					body = msg.Markdown
+				}
+				msg.Text, msg.Markdown = markdownToText(body), ""
+			} else if note := autodetectMarkdown(markdownAutodetect, &msg.Text, &msg.Markdown); note != "" {
+				warnings = append(warnings, note)
+			}
+
+			// Resolve mentions and inject the markup before sending
+			if mentions != "" {
+				if msg.RoomID == "" {
+					return mcp.NewToolResultError("mentions require roomId; @mentions only work in group spaces"), nil
+				}
+				var mentionWarnings []string
+				targets, mentionWarnings, err = resolveMentions(client, msg.RoomID, mentions)
+				warnings = append(warnings, mentionWarnings...)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("Invalid mentions: %v", err)), nil
+				}
+				body := msg.Markdown
+				if body == "" {
+					body = msg.Text
+				}
+				msg.Markdown = prependMentions(targets, body)
 			}
-			if msg.Text == "" && msg.Markdown == "" {
-				return mcp.NewToolResultError("Either text or markdown content is required"), nil
+
+			// Webex renders markdown in its own clients; the text copy is a fallback
+			// for clients that cannot, and for notifications and search.
+			if markdownTextFallback && msg.Text == "" && msg.Markdown != "" {
+				msg.Text = markdownToText(msg.Markdown)
 			}
 
 			result, err := client.Messages().Create(msg)
@@ -204,7 +273,21 @@ func RegisterMessageTools(s ToolRegistrar, resolver auth.ClientResolver) {
 				return mcp.NewToolResultError(fmt.Sprintf("Failed to create message: %v", err)), nil
 			}
 
-			data, _ := json.MarshalIndent(result, "", "  ")
+			if len(targets) == 0 && len(warnings) == 0 {
+				data, _ := json.MarshalIndent(result, "", "  ")
+				return mcp.NewToolResultText(string(data)), nil
+			}
+
+			response := map[string]interface{}{
+				"message": result,
+			}
+			if len(targets) > 0 {
+				response["mentioned"] = targets
+			}
+			if len(warnings) > 0 {
+				response["warnings"] = warnings
+			}
+			data, _ := json.MarshalIndent(response, "", "  ")
 			return mcp.NewToolResultText(string(data)), nil
 		},
 	)
@@ -214,7 +297,7 @@ func RegisterMessageTools(s ToolRegistrar, resolver auth.ClientResolver) {
 		mcp.NewTool("webex_messages_send_attachment",
 			mcp.WithDescription("Send a message with a file attachment to a person or room in Webex.\n"+
 				"\n"+
-				"DESTINATION: Same as webex_messages_create -- use toPersonEmail for DMs (email is enough, no lookup needed), or roomId for group spaces.\n"+
+				"DESTINATION: Same as webex_messages_create -- use toPersonEmail for DMs (email is enough, no lookup needed), roomId for group spaces, or roomName with the space's title.\n"+
 				"\n"+
 				"HOW TO ATTACH A FILE (provide exactly one approach):\n"+
 				"\n"+
@@ -232,6 +315,7 @@ func RegisterMessageTools(s ToolRegistrar, resolver auth.ClientResolver) {
 				"\n"+
 				"IMPORTANT: Always confirm with the user before sending."),
 			mcp.WithString("roomId", mcp.Description("Room/space ID. Use when sending to a group space or when you already have a roomId.")),
+			mcp.WithString("roomName", mcp.Description(roomNameParamDescription)),
 			mcp.WithString("toPersonId", mcp.Description("Person ID for a direct 1:1 message. Use only if you already have it.")),
 			mcp.WithString("toPersonEmail", mcp.Description("Email address for a direct 1:1 message (e.g. 'alice@example.com'). No lookup needed.")),
 			mcp.WithString("localFilePath", mcp.Description("BEST option. Absolute path to a file on the local filesystem (e.g. '/tmp/report.pdf', '/Users/me/chart.png'). The MCP server reads the file and uploads it directly to Webex. Use this when the file exists on disk — it avoids base64 encoding and LLM token limits. Provide ONLY this, OR fileBase64+fileName, OR fileUrl.")),
@@ -251,12 +335,12 @@ func RegisterMessageTools(s ToolRegistrar, resolver auth.ClientResolver) {
 				RoomID:        req.GetString("roomId", ""),
 				ToPersonID:    req.GetString("toPersonId", ""),
 				ToPersonEmail: req.GetString("toPersonEmail", ""),
-				Text:          req.GetString("text", ""),
-				Markdown:      req.GetString("markdown", ""),
+				Text:          strings.TrimSpace(req.GetString("text", "")),
+				Markdown:      strings.TrimSpace(req.GetString("markdown", "")),
 			}
 
-			if msg.RoomID == "" && msg.ToPersonID == "" && msg.ToPersonEmail == "" {
-				return mcp.NewToolResultError("One of roomId, toPersonId, or toPersonEmail is required"), nil
+			if err := resolveMessageDestination(client, req, msg); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
 			}
 
 			localFilePath := req.GetString("localFilePath", "")
@@ -327,7 +411,7 @@ func RegisterMessageTools(s ToolRegistrar, resolver auth.ClientResolver) {
 				"Adaptive Cards are rich, interactive UI cards that can contain text, images, buttons, inputs, and more. "+
 				"They are rendered natively in Webex clients.\n"+
 				"\n"+
-				"DESTINATION: Same as webex_messages_create -- use toPersonEmail for DMs (email is enough, no lookup needed), or roomId for group spaces.\n"+
+				"DESTINATION: Same as webex_messages_create -- use toPersonEmail for DMs (email is enough, no lookup needed), roomId for group spaces, or roomName with the space's title.\n"+
 				"\n"+
 				"CARD FORMAT: Provide the card body as a JSON string in 'cardJson'. The JSON must follow the Adaptive Card schema "+
 				"(see https://adaptivecards.io/explorer/). At minimum it should have:\n"+
@@ -346,6 +430,7 @@ func RegisterMessageTools(s ToolRegistrar, resolver auth.ClientResolver) {
 				"\n"+
 				"IMPORTANT: Always confirm with the user before sending."),
 			mcp.WithString("roomId", mcp.Description("Room/space ID. Use when sending to a group space or when you already have a roomId.")),
+			mcp.WithString("roomName", mcp.Description(roomNameParamDescription)),
 			mcp.WithString("toPersonId", mcp.Description("Person ID for a direct 1:1 message. Use only if you already have it.")),
 			mcp.WithString("toPersonEmail", mcp.Description("Email address for a direct 1:1 message (e.g. 'alice@example.com'). No lookup needed.")),
 			mcp.WithString("cardJson", mcp.Required(), mcp.Description("The Adaptive Card body as a JSON string. Must be a valid Adaptive Card object with at least {\"type\": \"AdaptiveCard\", \"version\": \"1.3\", \"body\": [...]}. See https://adaptivecards.io/explorer/ for the full schema.")),
@@ -363,8 +448,8 @@ func RegisterMessageTools(s ToolRegistrar, resolver auth.ClientResolver) {
 				ToPersonEmail: req.GetString("toPersonEmail", ""),
 			}
 
-			if msg.RoomID == "" && msg.ToPersonID == "" && msg.ToPersonEmail == "" {
-				return mcp.NewToolResultError("One of roomId, toPersonId, or toPersonEmail is required"), nil
+			if err := resolveMessageDestination(client, req, msg); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
 			}
 
 			cardJSON, err := req.RequireString("cardJson")
@@ -403,10 +488,13 @@ func RegisterMessageTools(s ToolRegistrar, resolver auth.ClientResolver) {
 				"RESPONSE: Enriched with:\n"+
 				"- sender: Display name and email of who sent the message.\n"+
 				"- room: Title and type of the room the message is in.\n"+
-				"- files: For any file attachments -- text-based files (txt, json, xml, csv, etc.) have their content included inline (up to 100KB). Binary files (pdf, images, etc.) include metadata (filename, size, content-type) so you can describe them.\n"+
+				"- files: For any file attachments -- text-based files (txt, json, xml, csv, etc.) have their content included inline (up to 100KB). Binary files (pdf, images, etc.) include metadata (filename, size, content-type) so you can describe them. "+
+				"Set includeBinaryContent=true to also get small binary files: images are returned as image content you can view, other files as base64 in contentBase64. Files over the server's size limit get a note instead.\n"+
+				"- attachments: For Adaptive Card messages (polls, forms, bot cards) -- the full card JSON plus a list of its input elements (id, type, label, choices) so you can understand what the card asks for.\n"+
 				"\n"+
 				"TIP: If the user asks 'what did someone send me' or 'what files were shared', use webex_messages_list first to find recent messages, then use this tool on specific messages that have attachments to get the file contents."),
 			mcp.WithString("messageId", mcp.Required(), mcp.Description("The ID of the message to retrieve. Get this from webex_messages_list results or from webhook notification data.")),
+			mcp.WithBoolean("includeBinaryContent", mcp.Description("Also return the contents of binary attachments (e.g. a shared screenshot) up to the server's size limit, which applies to all files of the message together (default 1MB). Default: false.")),
 		),
 		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			client, err := resolver(ctx)
@@ -431,6 +519,7 @@ func RegisterMessageTools(s ToolRegistrar, resolver auth.ClientResolver) {
 
 			// Enrich: sender
 			if result.PersonID != "" {
+				countEnrichment(client)
 				if person, pErr := client.People().Get(result.PersonID); pErr == nil {
 					response["sender"] = map[string]interface{}{
 						"id":          person.ID,
@@ -445,12 +534,36 @@ func RegisterMessageTools(s ToolRegistrar, resolver auth.ClientResolver) {
 				response["room"] = roomInfo
 			}
 
+			includeBinary := req.GetBool("includeBinaryContent", false)
+			if includeBinary && maxBinaryContentSize <= 0 {
+				return mcp.NewToolResultError("includeBinaryContent is disabled on this server"), nil
+			}
+
 			// Enrich: files with content for text, metadata for binary
+			var images []mcp.Content
 			if len(result.Files) > 0 {
 				fileInfos := make([]*FileInfo, 0, len(result.Files))
+				budget := maxBinaryContentSize
 				for _, fileURL := range result.Files {
-					if fi := resolveFileContent(client, fileURL); fi != nil {
-						fileInfos = append(fileInfos, fi)
+					fi := resolveFileContent(client, fileURL)
+					if fi == nil {
+						continue
+					}
+					fileInfos = append(fileInfos, fi)
+					if !includeBinary || isTextContentType(fi.ContentType) {
+						continue
+					}
+					data := loadBinaryContent(client, fi, budget)
+					if data == nil {
+						continue
+					}
+					budget -= int64(len(data))
+					encoded := base64.StdEncoding.EncodeToString(data)
+					if strings.HasPrefix(strings.ToLower(fi.ContentType), "image/") {
+						images = append(images, mcp.NewImageContent(encoded, fi.ContentType))
+						fi.Note = "included as image content"
+					} else {
+						fi.ContentBase64 = encoded
 					}
 				}
 				if len(fileInfos) > 0 {
@@ -458,6 +571,81 @@ func RegisterMessageTools(s ToolRegistrar, resolver auth.ClientResolver) {
 				}
 			}
 
+			// Enrich: card attachments with their input elements
+			if atts := summarizeAttachments(result.Attachments); len(atts) > 0 {
+				response["attachments"] = atts
+			}
+
+			data, _ := json.MarshalIndent(response, "", "  ")
+			toolResult := mcp.NewToolResultText(string(data))
+			toolResult.Content = append(toolResult.Content, images...)
+			return toolResult, nil
+		},
+	)
+
+	// webex_messages_get_thread
+	s.AddTool(
+		mcp.NewTool("webex_messages_get_thread",
+			mcp.WithDescription("Get a whole message thread -- the root message plus all replies, oldest first, with sender names -- from the ID of ANY message in it (root or reply).\n"+
+				"\n"+
+				"USE THIS WHEN:\n"+
+				"- 'Show me the whole thread this came from'\n"+
+				"- A message from webex_messages_list or a webhook has a parentId and you need the surrounding conversation.\n"+
+				"\n"+
+				"RESPONSE:\n"+
+				"- room: Title and type of the room.\n"+
+				"- root: The message that started the thread.\n"+
+				"- replies: The earliest replies in chronological order, up to maxResults.\n"+
+				"- replyCount, droppedReplies, truncated: droppedReplies is how many later replies were left out because of maxResults; truncated=true means some replies are missing."),
+			mcp.WithString("messageId", mcp.Required(), mcp.Description("ID of any message in the thread -- the root or one of its replies.")),
+			mcp.WithNumber("maxResults", mcp.Description("Max replies to return (default 50, max 200).")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			client, err := resolver(ctx)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Auth error: %v", err)), nil
+			}
+
+			messageID, err := req.RequireString("messageId")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			root, err := client.Messages().Get(messageID)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to get message: %v", err)), nil
+			}
+			if root.ParentID != "" {
+				if root, err = client.Messages().Get(root.ParentID); err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("Failed to get thread root message: %v", err)), nil
+				}
+			}
+
+			replies, dropped, scanTruncated, err := listThreadReplies(client, root.RoomID, root.ID, ClampMaxResults(req))
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to list thread replies: %v", err)), nil
+			}
+
+			nameCache := NewPersonNameCache(client)
+			replySummaries := make([]map[string]interface{}, 0, len(replies))
+			for _, msg := range replies {
+				replySummaries = append(replySummaries, threadMessageSummary(msg, nameCache))
+			}
+
+			response := map[string]interface{}{
+				"root":           threadMessageSummary(*root, nameCache),
+				"replies":        replySummaries,
+				"replyCount":     len(replySummaries),
+				"droppedReplies": dropped,
+				"truncated":      dropped > 0 || scanTruncated,
+			}
+			if scanTruncated {
+				response["warning"] = fmt.Sprintf("The thread has more than %d replies; only the newest %d were read, so the earliest replies are missing.", threadReplyScanCap, threadReplyScanCap)
+			}
+			if roomInfo := resolveRoomInfo(client, root.RoomID); roomInfo != nil {
+				response["room"] = roomInfo
+			}
+
 			data, _ := json.MarshalIndent(response, "", "  ")
 			return mcp.NewToolResultText(string(data)), nil
 		},
@@ -492,6 +680,251 @@ func RegisterMessageTools(s ToolRegistrar, resolver auth.ClientResolver) {
 	)
 }
 
+// mentionPattern matches Webex mention markup, e.g. <@personEmail:a@b.com|Alice> or <@all>.
+var mentionPattern = regexp.MustCompile(`<@(?:personEmail|personId|groupMention|all)[^>]*>`)
+
+// markdownSyntaxChars are characters that render as formatting only, not visible content.
+const markdownSyntaxChars = "*_~`#>-=|[]() \t\r\n"
+
+// validateMessageContent checks that at least one of text/markdown (already trimmed) has
+// visible content. Markdown made only of formatting syntax, and messages that are only
+// mentions with no body, are rejected with a clear error instead of an opaque Webex failure.
+func validateMessageContent(text, markdown string) error {
+	if text == "" && markdown == "" {
+		return fmt.Errorf("either text or markdown content is required (whitespace-only content is not allowed)")
+	}
+
+	body := text
+	if markdown != "" {
+		if strings.Trim(markdown, markdownSyntaxChars) == "" {
+			return fmt.Errorf("the markdown renders as empty (it contains only formatting characters); provide some visible text")
+		}
+		body = markdown
+	}
+
+	if mentionPattern.MatchString(body) && strings.TrimSpace(mentionPattern.ReplaceAllString(body, "")) == "" {
+		return fmt.Errorf("the message contains only a mention with no body, which Webex rejects; add some text after the mention (e.g. '<@personEmail:alice@example.com> please take a look')")
+	}
+	return nil
+}
+
+// threadReplyScanCap bounds how many replies webex_messages_get_thread reads to find the earliest ones.
+const threadReplyScanCap = 1000
+
+// listThreadReplies returns the earliest limit replies to parentID in roomID, oldest
+// first. Webex lists newest first, so it reads the whole thread (up to
+// threadReplyScanCap) and drops the latest; dropped is how many it left out.
+// scanTruncated reports whether the thread was too long to read to the start.
+func listThreadReplies(client *webex.WebexClient, roomID, parentID string, limit int) (replies []messages.Message, dropped int, scanTruncated bool, err error) {
+	params := url.Values{}
+	params.Set("roomId", roomID)
+	params.Set("parentId", parentID)
+	params.Set("max", strconv.Itoa(CatalogPageSize))
+
+	page, err := FetchPage(client, client.Core().BaseURL.String()+"/messages?"+params.Encode())
+	if err != nil {
+		return nil, 0, false, err
+	}
+	items, err := UnmarshalPageItems[messages.Message](page)
+	if err != nil {
+		return nil, 0, false, err
+	}
+
+	replies, scanTruncated, err = FetchAll(items, page.HasNext, page.NextPage, client, threadReplyScanCap)
+	if err != nil {
+		return nil, 0, false, err
+	}
+	sortMessagesChronologically(replies)
+	if len(replies) > limit {
+		countBudgetHit(client)
+		dropped = len(replies) - limit
+		replies = replies[:limit]
+	}
+	return replies, dropped, scanTruncated, nil
+}
+
+// sortMessagesChronologically orders messages oldest first. Webex lists newest first.
+func sortMessagesChronologically(msgs []messages.Message) {
+	sort.SliceStable(msgs, func(i, j int) bool {
+		a, b := msgs[i].Created, msgs[j].Created
+		if a == nil || b == nil {
+			return a == nil && b != nil
+		}
+		return a.Before(*b)
+	})
+}
+
+// threadMessageSummary returns the fields of a thread message worth showing, with the sender name resolved.
+func threadMessageSummary(msg messages.Message, nameCache *PersonNameCache) map[string]interface{} {
+	summary := map[string]interface{}{
+		"id":          msg.ID,
+		"text":        msg.Text,
+		"personId":    msg.PersonID,
+		"senderName":  nameCache.Resolve(msg.PersonID),
+		"personEmail": msg.PersonEmail,
+		"created":     msg.Created,
+	}
+	if msg.Markdown != "" {
+		summary["markdown"] = msg.Markdown
+	}
+	if msg.Updated != nil {
+		summary["updated"] = msg.Updated
+	}
+	if len(msg.Files) > 0 {
+		summary["files"] = msg.Files
+	}
+	return summary
+}
+
+// roomNameParamDescription documents the roomName parameter shared by the message send tools.
+const roomNameParamDescription = "Title of the group space to send to (e.g. 'Project Falcon'), matched exactly but case-insensitively. " +
+	"Use instead of roomId when the user names a space. If several spaces share the title, the call fails with the candidates -- ask the user which one and pass its roomId. " +
+	"If you are in more spaces than the lookup can check, it also refuses a single match and returns it as a candidate to confirm."
+
+// roomNameScanCap is the maximum number of rooms scanned when resolving a roomName.
+const roomNameScanCap = 2000
+
+// resolveMessageDestination fills msg.RoomID from the roomName parameter, if given, and
+// checks that the message has a destination. All the message send tools use it so
+// they accept the same destinations.
+func resolveMessageDestination(client *webex.WebexClient, req mcp.CallToolRequest, msg *messages.Message) error {
+	if name := strings.TrimSpace(req.GetString("roomName", "")); name != "" {
+		if msg.RoomID != "" {
+			return fmt.Errorf("pass either roomId or roomName, not both")
+		}
+		roomID, err := resolveRoomName(client, name)
+		if err != nil {
+			return err
+		}
+		msg.RoomID = roomID
+	}
+	if msg.RoomID == "" && msg.ToPersonID == "" && msg.ToPersonEmail == "" {
+		return fmt.Errorf("One of roomId, roomName, toPersonId, or toPersonEmail is required")
+	}
+	return nil
+}
+
+// resolveRoomName looks up the group room titled name among the rooms the user belongs to.
+func resolveRoomName(client *webex.WebexClient, name string) (string, error) {
+	page, err := client.Rooms().List(&rooms.ListOptions{Type: "group", Max: CatalogPageSize})
+	if err != nil {
+		return "", fmt.Errorf("Failed to list rooms to resolve roomName: %v", err)
+	}
+	roomItems, truncated, err := FetchAll(page.Items, page.HasNext, page.NextPage, client, roomNameScanCap)
+	if err != nil {
+		return "", fmt.Errorf("Failed to list rooms to resolve roomName: %v", err)
+	}
+	return matchRoomTitle(roomItems, name, truncated)
+}
+
+// matchRoomTitle returns the ID of the only room whose title equals name, ignoring
+// case and surrounding whitespace. It refuses to guess when no room or several rooms match,
+// and when truncated (roomItems is not every room) it never auto-selects, since a match
+// that looks unique may not be.
+func matchRoomTitle(roomItems []rooms.Room, name string, truncated bool) (string, error) {
+	want := strings.ToLower(strings.TrimSpace(name))
+	var exact []rooms.Room
+	var similar []string
+	for _, r := range roomItems {
+		title := strings.ToLower(strings.TrimSpace(r.Title))
+		if title == want {
+			exact = append(exact, r)
+		} else if strings.Contains(title, want) && len(similar) < 5 {
+			similar = append(similar, fmt.Sprintf("%q", r.Title))
+		}
+	}
+
+	switch len(exact) {
+	case 1:
+		if truncated {
+			return "", fmt.Errorf("found a space titled %q (roomId %s), but only your first %d spaces were checked, so it may not be the only one. Confirm with the user and pass its roomId", exact[0].Title, exact[0].ID, len(roomItems))
+		}
+		return exact[0].ID, nil
+	case 0:
+		if truncated {
+			return "", fmt.Errorf("no space titled %q among your first %d spaces; use webex_rooms_catalog to find the roomId", name, len(roomItems))
+		}
+		if len(similar) > 0 {
+			return "", fmt.Errorf("no space is titled %q; similar titles: %s. Pass the exact title as roomName, or a roomId", name, strings.Join(similar, ", "))
+		}
+		return "", fmt.Errorf("no space is titled %q; use webex_rooms_catalog to find the roomId", name)
+	default:
+		candidates := make([]string, 0, len(exact))
+		for _, r := range exact {
+			candidates = append(candidates, fmt.Sprintf("%q (roomId %s)", r.Title, r.ID))
+		}
+		return "", fmt.Errorf("%d spaces are titled %q: %s. Ask the user which one and pass its roomId", len(exact), name, strings.Join(candidates, "; "))
+	}
+}
+
+// maxMentionsPerMessage is the maximum number of people webex_messages_create will @mention at once.
+const maxMentionsPerMessage = 25
+
+// mentionTarget is a person resolved from an email for @mention markup.
+type mentionTarget struct {
+	Email       string `json:"email"`
+	PersonID    string `json:"personId"`
+	DisplayName string `json:"displayName,omitempty"`
+}
+
+// resolveMentions resolves comma-separated emails to people for @mentions. Emails that
+// are malformed or do not match a Webex user are an error; people who are not members
+// of roomID are returned with a warning, since Webex still accepts the mention.
+func resolveMentions(client *webex.WebexClient, roomID, raw string) ([]mentionTarget, []string, error) {
+	emails, malformed := normalizeEmailList(raw)
+	if len(malformed) > 0 {
+		return nil, nil, fmt.Errorf("malformed email address(es): %s", strings.Join(malformed, ", "))
+	}
+	if len(emails) > maxMentionsPerMessage {
+		return nil, nil, fmt.Errorf("too many mentions (%d); at most %d people can be mentioned per message", len(emails), maxMentionsPerMessage)
+	}
+
+	targets := make([]mentionTarget, 0, len(emails))
+	for i, r := range lookupPeopleByEmail(client, emails) {
+		switch {
+		case r.err != nil:
+			return nil, nil, fmt.Errorf("failed to look up %s: %v", emails[i], r.err)
+		case r.person == nil:
+			return nil, nil, fmt.Errorf("no Webex user found for %s", emails[i])
+		}
+		targets = append(targets, mentionTarget{
+			Email:       emails[i],
+			PersonID:    r.person.ID,
+			DisplayName: r.person.DisplayName,
+		})
+	}
+
+	var warnings []string
+	for _, t := range targets {
+		page, err := client.Memberships().List(&memberships.ListOptions{RoomID: roomID, PersonID: t.PersonID})
+		switch {
+		case err != nil:
+			log.Printf("[messages] failed to check room membership for %s: %v", t.Email, err)
+		case len(page.Items) == 0:
+			warnings = append(warnings, fmt.Sprintf("%s is not a member of this room; they were mentioned but will not see the message", t.Email))
+		}
+	}
+
+	return targets, warnings, nil
+}
+
+// prependMentions returns body prefixed with Webex mention markup for each target,
+// e.g. "<@personId:abc|Alice>, <@personId:def|Bob> body".
+func prependMentions(targets []mentionTarget, body string) string {
+	if len(targets) == 0 {
+		return body
+	}
+	tags := make([]string, 0, len(targets))
+	for _, t := range targets {
+		name := t.DisplayName
+		if name == "" {
+			name = t.Email
+		}
+		tags = append(tags, fmt.Sprintf("<@personId:%s|%s>", t.PersonID, name))
+	}
+	return strings.Join(tags, ", ") + " " + body
+}
+
 // resolveLocalFileURLs recursively walks a parsed JSON tree (from an Adaptive Card)
 // and replaces any "url" values that are local file paths with base64 data URIs.
 // Local paths start with "/" or "~/". HTTP(S) URLs and data: URIs are left as-is.
@@ -0,0 +1,138 @@
+package tools
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	webex "github.com/WebexCommunity/webex-go-sdk/v2"
+	"github.com/WebexCommunity/webex-go-sdk/v2/messages"
+	"github.com/WebexCommunity/webex-go-sdk/v2/rooms"
+	"github.com/WebexCommunity/webex-go-sdk/v2/webexsdk"
+)
+
+func TestValidateMessageContent(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		markdown string
+		wantErr  bool
+	}{
+		{"plain text", "hello", "", false},
+		{"markdown", "", "**hello**", false},
+		{"both empty", "", "", true},
+		{"markdown syntax only", "", "** __ ~~", true},
+		{"heading marker only", "", "#", true},
+		{"mention only in markdown", "", "<@personEmail:alice@example.com|Alice>", true},
+		{"mention only in text", "<@all>", "", true},
+		{"mention with body", "", "<@personEmail:alice@example.com> please review", false},
+		{"text with markdown mention only", "fallback", "<@all>", true},
+	}
+	for _, tt := range tests {
+		err := validateMessageContent(tt.text, tt.markdown)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: validateMessageContent(%q, %q) error = %v, wantErr %v", tt.name, tt.text, tt.markdown, err, tt.wantErr)
+		}
+	}
+}
+
+func TestPrependMentions(t *testing.T) {
+	targets := []mentionTarget{
+		{Email: "alice@example.com", PersonID: "p1", DisplayName: "Alice"},
+		{Email: "bob@example.com", PersonID: "p2"},
+	}
+	got := prependMentions(targets, "please review")
+	want := "<@personId:p1|Alice>, <@personId:p2|bob@example.com> please review"
+	if got != want {
+		t.Errorf("prependMentions() = %q, want %q", got, want)
+	}
+	if got := prependMentions(nil, "hello"); got != "hello" {
+		t.Errorf("prependMentions(nil) = %q, want unchanged body", got)
+	}
+}
+
+func TestSortMessagesChronologically(t *testing.T) {
+	t1 := time.Date(2026, 2, 6, 10, 0, 0, 0, time.UTC)
+	t2 := t1.Add(time.Minute)
+	t3 := t2.Add(time.Minute)
+	msgs := []messages.Message{
+		{ID: "c", Created: &t3},
+		{ID: "a", Created: &t1},
+		{ID: "b", Created: &t2},
+	}
+	sortMessagesChronologically(msgs)
+	got := msgs[0].ID + msgs[1].ID + msgs[2].ID
+	if got != "abc" {
+		t.Errorf("order = %q, want %q", got, "abc")
+	}
+}
+
+func TestListThreadRepliesKeepsEarliest(t *testing.T) {
+	// Webex lists replies newest first, across two pages
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("cursor") == "" {
+			w.Header().Set("Link", "<"+srv.URL+"/messages?cursor=2>; rel=\"next\"")
+			w.Write([]byte(`{"items":[{"id":"r4","created":"2026-02-06T10:04:00Z"},{"id":"r3","created":"2026-02-06T10:03:00Z"}]}`))
+			return
+		}
+		w.Write([]byte(`{"items":[{"id":"r2","created":"2026-02-06T10:02:00Z"},{"id":"r1","created":"2026-02-06T10:01:00Z"}]}`))
+	}))
+	defer srv.Close()
+	client, err := webex.NewClient("test-token", &webexsdk.Config{BaseURL: srv.URL})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	replies, dropped, scanTruncated, err := listThreadReplies(client, "room-1", "root", 3)
+	if err != nil {
+		t.Fatalf("listThreadReplies: %v", err)
+	}
+	var got string
+	for _, r := range replies {
+		got += r.ID + " "
+	}
+	if got != "r1 r2 r3 " || dropped != 1 || scanTruncated {
+		t.Errorf("replies = %q, dropped = %d, scanTruncated = %v; want \"r1 r2 r3 \", 1, false", got, dropped, scanTruncated)
+	}
+}
+
+func TestMatchRoomTitle(t *testing.T) {
+	roomItems := []rooms.Room{
+		{ID: "r1", Title: "Project Falcon"},
+		{ID: "r2", Title: "Falcon Design Review"},
+		{ID: "r3", Title: "Standup"},
+		{ID: "r4", Title: "standup "},
+	}
+
+	tests := []struct {
+		name    string
+		want    string
+		wantErr string
+	}{
+		{"  project falcon ", "r1", ""},
+		{"Standup", "", "2 spaces are titled"},
+		{"Falcon", "", "similar titles"},
+		{"Nonexistent", "", "webex_rooms_catalog"},
+	}
+	for _, tt := range tests {
+		got, err := matchRoomTitle(roomItems, tt.name, false)
+		if got != tt.want {
+			t.Errorf("matchRoomTitle(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+		if tt.wantErr == "" && err != nil {
+			t.Errorf("matchRoomTitle(%q) unexpected error: %v", tt.name, err)
+		}
+		if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+			t.Errorf("matchRoomTitle(%q) error = %v, want it to mention %q", tt.name, err, tt.wantErr)
+		}
+	}
+
+	// A truncated scan never auto-selects, even for a unique exact match.
+	if got, err := matchRoomTitle(roomItems, "Project Falcon", true); got != "" || err == nil || !strings.Contains(err.Error(), "r1") {
+		t.Errorf("matchRoomTitle(truncated) = %q, %v; want an error naming the candidate roomId", got, err)
+	}
+}
@@ -0,0 +1,156 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	webex "github.com/WebexCommunity/webex-go-sdk/v2"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/tejzpr/webex-go-mcp/auth"
+)
+
+// verboseMeta controls whether tool responses carry a _meta block with call costs.
+// Set once at startup via SetVerboseMeta.
+var verboseMeta = false
+
+// SetVerboseMeta enables or disables the _meta block in tool responses.
+func SetVerboseMeta(enabled bool) {
+	verboseMeta = enabled
+}
+
+// VerboseMeta reports whether the _meta block is enabled.
+func VerboseMeta() bool {
+	return verboseMeta
+}
+
+// callStats counts the Webex API work done with one client.
+type callStats struct {
+	apiCalls        atomic.Int64
+	enrichmentCalls atomic.Int64
+	budgetHits      atomic.Int64
+}
+
+// countingTransport counts every HTTP request a Webex client makes. It is also
+// where the client's callStats live, so they go away with the client.
+type countingTransport struct {
+	next  http.RoundTripper
+	stats callStats
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.stats.apiCalls.Add(1)
+	return t.next.RoundTrip(req)
+}
+
+// InstrumentClient installs request counting on a newly created client so that
+// verbose responses can report API calls. It must run before the client is used.
+func InstrumentClient(client *webex.WebexClient) {
+	hc := client.Core().GetHTTPClient()
+	if _, ok := hc.Transport.(*countingTransport); ok {
+		return
+	}
+	next := hc.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	hc.Transport = &countingTransport{next: next}
+}
+
+// statsOf returns the counters of an instrumented client, or nil.
+func statsOf(client *webex.WebexClient) *callStats {
+	if client == nil {
+		return nil
+	}
+	if t, ok := client.Core().GetHTTPClient().Transport.(*countingTransport); ok {
+		return &t.stats
+	}
+	return nil
+}
+
+// countEnrichment records an API call made only to enrich a response.
+func countEnrichment(client *webex.WebexClient) {
+	if stats := statsOf(client); stats != nil {
+		stats.enrichmentCalls.Add(1)
+	}
+}
+
+// countBudgetHit records that a full-list scan (FetchAll or a similar bounded scan)
+// stopped at its item budget. Ordinary paging stops and timeouts are not counted:
+// the former are reported in _pagination and the latter as errors.
+func countBudgetHit(client *webex.WebexClient) {
+	if stats := statsOf(client); stats != nil {
+		stats.budgetHits.Add(1)
+	}
+}
+
+// callMeta is the _meta block added to verbose responses. Counts cover every
+// request made with the caller's Webex client while the tool ran, so calls running
+// concurrently for the same user are included.
+type callMeta struct {
+	APICalls        int64 `json:"apiCalls"`
+	EnrichmentCalls int64 `json:"enrichmentCalls"`
+	BudgetHit       bool  `json:"budgetHit"`
+	ElapsedMs       int64 `json:"elapsedMs"`
+}
+
+// MetaMiddleware adds a _meta block with API call counts, budget hits, and elapsed
+// time to JSON object responses. It does nothing unless verbose meta is enabled.
+// It satisfies server.ToolHandlerMiddleware.
+func MetaMiddleware(resolver auth.ClientResolver) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if !verboseMeta {
+				return next(ctx, req)
+			}
+			client, err := resolver(ctx)
+			stats := statsOf(client)
+			if err != nil || stats == nil {
+				return next(ctx, req)
+			}
+
+			start := time.Now()
+			apiBefore, enrichBefore, budgetBefore := stats.apiCalls.Load(), stats.enrichmentCalls.Load(), stats.budgetHits.Load()
+			result, err := next(ctx, req)
+			if err != nil || result == nil {
+				return result, err
+			}
+
+			injectMeta(result, callMeta{
+				APICalls:        stats.apiCalls.Load() - apiBefore,
+				EnrichmentCalls: stats.enrichmentCalls.Load() - enrichBefore,
+				BudgetHit:       stats.budgetHits.Load() > budgetBefore,
+				ElapsedMs:       time.Since(start).Milliseconds(),
+			})
+			return result, nil
+		}
+	}
+}
+
+// injectMeta adds meta as the first field of the result's JSON object text, keeping
+// the rest of the text as-is. Results that are not a JSON object are left unchanged.
+func injectMeta(result *mcp.CallToolResult, meta callMeta) {
+	for i, content := range result.Content {
+		text, ok := content.(mcp.TextContent)
+		if !ok {
+			continue
+		}
+		body := strings.TrimSpace(text.Text)
+		if !strings.HasPrefix(body, "{") || !json.Valid([]byte(body)) {
+			return
+		}
+		data, _ := json.MarshalIndent(meta, "  ", "  ")
+		rest := strings.TrimPrefix(body, "{")
+		if strings.TrimSpace(rest) == "}" {
+			text.Text = "{\n  \"_meta\": " + string(data) + "\n}"
+		} else {
+			text.Text = "{\n  \"_meta\": " + string(data) + "," + rest
+		}
+		result.Content[i] = text
+		return
+	}
+}
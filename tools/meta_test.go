@@ -0,0 +1,89 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	webex "github.com/WebexCommunity/webex-go-sdk/v2"
+	"github.com/WebexCommunity/webex-go-sdk/v2/webexsdk"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/tejzpr/webex-go-mcp/auth"
+)
+
+func TestInjectMeta(t *testing.T) {
+	meta := callMeta{APICalls: 3, EnrichmentCalls: 2, BudgetHit: true, ElapsedMs: 12}
+
+	result := mcp.NewToolResultText("{\n  \"items\": [],\n  \"count\": 0\n}")
+	injectMeta(result, meta)
+	var got map[string]interface{}
+	if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &got); err != nil {
+		t.Fatalf("result is not valid JSON: %v", err)
+	}
+	m, ok := got["_meta"].(map[string]interface{})
+	if !ok || m["apiCalls"] != float64(3) || m["budgetHit"] != true {
+		t.Errorf("_meta = %v", got["_meta"])
+	}
+	if got["count"] != float64(0) {
+		t.Errorf("existing fields lost: %v", got)
+	}
+
+	empty := mcp.NewToolResultText("{}")
+	injectMeta(empty, meta)
+	if !json.Valid([]byte(empty.Content[0].(mcp.TextContent).Text)) {
+		t.Errorf("empty object became invalid JSON: %s", empty.Content[0].(mcp.TextContent).Text)
+	}
+
+	plain := mcp.NewToolResultText("Message deleted successfully")
+	injectMeta(plain, meta)
+	if plain.Content[0].(mcp.TextContent).Text != "Message deleted successfully" {
+		t.Error("non-JSON text was modified")
+	}
+}
+
+func TestMetaMiddleware(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"x","displayName":"Alice"}`))
+	}))
+	defer srv.Close()
+
+	client, err := webex.NewClient("test-token", &webexsdk.Config{BaseURL: srv.URL})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	InstrumentClient(client)
+	InstrumentClient(client) // idempotent
+
+	SetVerboseMeta(true)
+	defer SetVerboseMeta(false)
+
+	handler := MetaMiddleware(auth.NewStaticClientResolver(client))(func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if _, err := client.People().Get("p1"); err != nil {
+			t.Errorf("People().Get: %v", err)
+		}
+		resolvePersonName(client, "p2")
+		resolveFileMetadata(client, srv.URL+"/contents/f1")
+		// A tool's own download (as in webex_recordings_download) is not enrichment
+		if resp, err := makeAuthenticatedRequest(client, http.MethodGet, srv.URL+"/contents/f2"); err == nil {
+			resp.Body.Close()
+		}
+		return mcp.NewToolResultText(`{"ok": true}`), nil
+	})
+
+	result, err := handler(context.Background(), mcp.CallToolRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got struct {
+		Meta callMeta `json:"_meta"`
+	}
+	if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &got); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if got.Meta.APICalls != 4 || got.Meta.EnrichmentCalls != 2 || got.Meta.BudgetHit {
+		t.Errorf("_meta = %+v, want 4 API calls, 2 enrichment calls, no budget hit", got.Meta)
+	}
+}
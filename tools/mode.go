@@ -0,0 +1,52 @@
+package tools
+
+import (
+	"encoding/json"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ServerMode is the transport the server was started with. Some tools only work
+// fully in one mode, and say so with a ModeError instead of failing deep inside a call.
+type ServerMode string
+
+const (
+	ModeSTDIO ServerMode = "stdio"
+	ModeHTTP  ServerMode = "http"
+)
+
+// modeErrorCode is the machine-readable error value of every ModeError.
+const modeErrorCode = "unsupported_mode"
+
+// ModeError is the structured result returned when a tool cannot work in the
+// active server mode or with the active kind of credentials.
+type ModeError struct {
+	Error        string     `json:"error"`
+	Tool         string     `json:"tool"`
+	RequiredMode ServerMode `json:"requiredMode"`
+	CurrentMode  ServerMode `json:"currentMode"`
+	Reason       string     `json:"reason"`
+	Hint         string     `json:"hint,omitempty"`
+	Detail       string     `json:"detail,omitempty"`
+}
+
+// newModeErrorResult returns an error tool result carrying the ModeError both as
+// structured content and as JSON text for clients that only read text.
+func newModeErrorResult(tool string, required, current ServerMode, reason, hint string) *mcp.CallToolResult {
+	return modeErrorResult(ModeError{
+		Tool:         tool,
+		RequiredMode: required,
+		CurrentMode:  current,
+		Reason:       reason,
+		Hint:         hint,
+	})
+}
+
+// modeErrorResult fills in the error code and builds the tool result.
+func modeErrorResult(me ModeError) *mcp.CallToolResult {
+	me.Error = modeErrorCode
+	data, _ := json.MarshalIndent(me, "", "  ")
+	result := mcp.NewToolResultStructured(me, string(data))
+	result.IsError = true
+	return result
+}
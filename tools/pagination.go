@@ -22,6 +22,20 @@ const (
 
 	// MaxResultsCap is the absolute upper limit for maxResults.
 	MaxResultsCap = 200
+
+	// CatalogPageSize is the page size used by catalog tools, which skip enrichment
+	// and can afford larger pages.
+	CatalogPageSize = 100
+
+	// DefaultCatalogMaxItems is the default item budget for catalog tools.
+	DefaultCatalogMaxItems = 500
+
+	// CatalogMaxItemsCap is the absolute upper limit for a catalog's item budget.
+	CatalogMaxItemsCap = 2000
+
+	// EnrichmentCountCap is how many items detail tools (e.g. webex_rooms_get) page
+	// through when enriching a response with a related list and its count.
+	EnrichmentCountCap = 1000
 )
 
 // FetchPage fetches a page directly from a next-page URL using the SDK's PageFromCursor.
@@ -91,6 +105,72 @@ func AutoPaginate[T any](
 	return items, finalHasNext, finalNextURL, nil
 }
 
+// FetchAll keeps fetching pages until none remain or the item budget is reached.
+// truncated reports whether items were left unfetched because of the budget. If a
+// page fails to load, err is set and items holds what was fetched before it, so
+// callers can fail or report the partial result rather than mistake it for a
+// budget cut.
+func FetchAll[T any](
+	initialItems []T,
+	hasNext bool,
+	nextURL string,
+	client *webex.WebexClient,
+	budget int,
+) (items []T, truncated bool, err error) {
+	items = initialItems
+
+	for len(items) < budget && hasNext && nextURL != "" {
+		page, pErr := FetchPage(client, nextURL)
+		if pErr != nil {
+			log.Printf("[FetchAll] failed to fetch page: %v", pErr)
+			return items, false, fmt.Errorf("failed to fetch page: %w", pErr)
+		}
+
+		pageItems, uErr := UnmarshalPageItems[T](page)
+		if uErr != nil {
+			log.Printf("[FetchAll] failed to unmarshal page: %v", uErr)
+			return items, false, fmt.Errorf("failed to parse page: %w", uErr)
+		}
+
+		items = append(items, pageItems...)
+		hasNext = page.HasNext
+		nextURL = page.NextPage
+	}
+
+	if len(items) > budget {
+		countBudgetHit(client)
+		return items[:budget], true, nil
+	}
+	if hasNext && nextURL != "" {
+		countBudgetHit(client)
+		return items, true, nil
+	}
+	return items, false, nil
+}
+
+// AddFetchErrorToMap marks response as partial when a FetchAll listing stopped on
+// a failed page, so the results are not mistaken for complete or budget-limited ones.
+func AddFetchErrorToMap(response map[string]interface{}, err error) {
+	if err == nil {
+		return
+	}
+	response["partial"] = true
+	response["fetchError"] = fmt.Sprintf("Results are incomplete because a page failed to load: %v. Retry the call for complete results.", err)
+}
+
+// ClampCatalogMaxItems reads the maxItems parameter and clamps it to
+// [1, CatalogMaxItemsCap], defaulting to DefaultCatalogMaxItems.
+func ClampCatalogMaxItems(req mcp.CallToolRequest) int {
+	v := req.GetInt("maxItems", DefaultCatalogMaxItems)
+	if v <= 0 {
+		return DefaultCatalogMaxItems
+	}
+	if v > CatalogMaxItemsCap {
+		return CatalogMaxItemsCap
+	}
+	return v
+}
+
 // ClampMaxResults reads the maxResults parameter from the request and clamps it
 // to [1, MaxResultsCap], defaulting to DefaultMaxResults.
 func ClampMaxResults(req mcp.CallToolRequest) int {
@@ -167,6 +247,58 @@ func AddPaginationToMap(response map[string]interface{}, itemCount int, hasNextP
 	response["_pagination"] = buildPaginationMeta(itemCount, hasNextPage, nextPageUrl)
 }
 
+// AddCountToMap sets response[key] to n. When more items exist than were counted,
+// it also adds <key>IsLowerBound and <key>Note so the count is not mistaken for a total.
+func AddCountToMap(response map[string]interface{}, key string, n int, more bool) {
+	response[key] = n
+	if more {
+		response[key+"IsLowerBound"] = true
+		response[key+"Note"] = fmt.Sprintf("at least %d (more available)", n)
+	}
+}
+
+// CatalogMeta is placed first in catalog responses so LLMs see truncation immediately.
+type CatalogMeta struct {
+	Count     int    `json:"count"`
+	Truncated bool   `json:"truncated"`
+	Partial   bool   `json:"partial,omitempty"` // a page failed to load
+	Message   string `json:"message"`
+}
+
+// CatalogResponse is the response wrapper for catalog (id/title lookup table) tools.
+type CatalogResponse struct {
+	Catalog CatalogMeta `json:"_catalog"`
+	Items   interface{} `json:"items"`
+}
+
+// FormatCatalogResponse builds the catalog JSON response. fetchErr is the error
+// FetchAll returned, if any.
+func FormatCatalogResponse(items interface{}, count int, truncated bool, fetchErr error) (string, error) {
+	meta := CatalogMeta{Count: count, Truncated: truncated, Partial: fetchErr != nil}
+	if fetchErr != nil {
+		meta.Message = fmt.Sprintf(
+			"Catalog incomplete: a page failed to load after %d items (%v). Re-call to retry.",
+			count, fetchErr,
+		)
+	} else if truncated {
+		meta.Message = fmt.Sprintf(
+			"Catalog truncated at %d items. Re-call with a higher maxItems (up to %d) for the complete list.",
+			count, CatalogMaxItemsCap,
+		)
+	} else {
+		meta.Message = fmt.Sprintf("Complete catalog: %d items.", count)
+	}
+
+	data, err := json.MarshalIndent(CatalogResponse{Catalog: meta, Items: items}, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal response: %w", err)
+	}
+	return string(data), nil
+}
+
+// CatalogMaxItemsParamDescription is the standard description for the maxItems parameter of catalog tools.
+const CatalogMaxItemsParamDescription = "Max items to include (default 500, max 2000). The response says whether the catalog was truncated."
+
 // --- Compact / field trimming ---
 
 // TrimFields strips a map down to only the listed keys. Unknown keys are dropped.
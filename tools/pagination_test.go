@@ -2,7 +2,13 @@ package tools
 
 import (
 	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+
+	webex "github.com/WebexCommunity/webex-go-sdk/v2"
+	"github.com/WebexCommunity/webex-go-sdk/v2/webexsdk"
 )
 
 // --- FormatPaginatedResponse tests (new _pagination structure) ---
@@ -140,6 +146,23 @@ func TestAddPaginationToMap_NoMore(t *testing.T) {
 	}
 }
 
+func TestAddCountToMap(t *testing.T) {
+	m := map[string]interface{}{}
+	AddCountToMap(m, "memberCount", 7, false)
+	if m["memberCount"] != 7 || len(m) != 1 {
+		t.Errorf("exact count: got %v", m)
+	}
+
+	m = map[string]interface{}{}
+	AddCountToMap(m, "roomCount", 100, true)
+	if m["roomCount"] != 100 || m["roomCountIsLowerBound"] != true {
+		t.Errorf("lower-bound count: got %v", m)
+	}
+	if m["roomCountNote"] != "at least 100 (more available)" {
+		t.Errorf("roomCountNote = %v", m["roomCountNote"])
+	}
+}
+
 // --- AutoPaginate tests ---
 
 func TestAutoPaginate_NoMorePages(t *testing.T) {
@@ -206,6 +229,96 @@ func TestAutoPaginate_TrimsOverfetch(t *testing.T) {
 	}
 }
 
+// --- FetchAll / catalog tests ---
+
+func TestFetchAll_NoMorePages(t *testing.T) {
+	items, truncated, err := FetchAll([]string{"a", "b"}, false, "", nil, 500)
+	if err != nil {
+		t.Fatalf("FetchAll: %v", err)
+	}
+	if len(items) != 2 {
+		t.Errorf("len = %d, want 2", len(items))
+	}
+	if truncated {
+		t.Error("truncated should be false")
+	}
+}
+
+func TestFetchAll_BudgetReached(t *testing.T) {
+	initial := make([]int, 120)
+	items, truncated, err := FetchAll(initial, true, "http://next", nil, 100)
+	if err != nil {
+		t.Fatalf("FetchAll: %v", err)
+	}
+	if len(items) != 100 {
+		t.Errorf("len = %d, want 100", len(items))
+	}
+	if !truncated {
+		t.Error("truncated should be true when the budget cuts the list")
+	}
+}
+
+func TestFetchAll_PageError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message":"bad cursor"}`, http.StatusBadRequest)
+	}))
+	defer srv.Close()
+	client, err := webex.NewClient("test-token", &webexsdk.Config{BaseURL: srv.URL})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	items, truncated, err := FetchAll([]string{"a", "b"}, true, srv.URL+"/rooms?cursor=x", client, 500)
+	if err == nil {
+		t.Fatal("FetchAll should return the page error")
+	}
+	if truncated {
+		t.Error("a failed page must not be reported as a budget cut")
+	}
+	if len(items) != 2 {
+		t.Errorf("len = %d, want the 2 items fetched before the failure", len(items))
+	}
+
+	response := map[string]interface{}{}
+	AddFetchErrorToMap(response, err)
+	if response["partial"] != true || response["fetchError"] == nil {
+		t.Errorf("response = %v, want partial and fetchError", response)
+	}
+}
+
+func TestFormatCatalogResponse_Partial(t *testing.T) {
+	got, err := FormatCatalogResponse([]string{"a"}, 1, false, errors.New("boom"))
+	if err != nil {
+		t.Fatalf("FormatCatalogResponse: %v", err)
+	}
+	var parsed CatalogResponse
+	if err := json.Unmarshal([]byte(got), &parsed); err != nil {
+		t.Fatalf("unmarshal result: %v", err)
+	}
+	if !parsed.Catalog.Partial || parsed.Catalog.Truncated {
+		t.Errorf("catalog meta = %+v, want partial and not truncated", parsed.Catalog)
+	}
+}
+
+func TestFormatCatalogResponse(t *testing.T) {
+	items := []map[string]string{{"id": "1", "title": "One"}}
+	got, err := FormatCatalogResponse(items, 1, true, nil)
+	if err != nil {
+		t.Fatalf("FormatCatalogResponse: %v", err)
+	}
+
+	var parsed CatalogResponse
+	if err := json.Unmarshal([]byte(got), &parsed); err != nil {
+		t.Fatalf("unmarshal result: %v", err)
+	}
+	if parsed.Catalog.Count != 1 || !parsed.Catalog.Truncated {
+		t.Errorf("catalog meta = %+v, want count=1 truncated=true", parsed.Catalog)
+	}
+	if parsed.Catalog.Message == "" {
+		t.Error("message should not be empty")
+	}
+}
+
 // --- ClampMaxResults tests ---
 
 type fakeCallToolRequest struct {
@@ -0,0 +1,413 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	webex "github.com/WebexCommunity/webex-go-sdk/v2"
+	"github.com/WebexCommunity/webex-go-sdk/v2/people"
+	"github.com/WebexCommunity/webex-go-sdk/v2/webexsdk"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/tejzpr/webex-go-mcp/auth"
+)
+
+const (
+	// peopleLookupConcurrency bounds the number of concurrent People API lookups.
+	peopleLookupConcurrency = 5
+
+	// maxEmailsPerValidation is the maximum number of emails accepted by webex_people_validate_emails.
+	maxEmailsPerValidation = 100
+)
+
+// RegisterPeopleTools registers all people-related MCP tools.
+func RegisterPeopleTools(s ToolRegistrar, resolver auth.ClientResolver) {
+	// webex_people_validate_emails
+	s.AddTool(
+		mcp.NewTool("webex_people_validate_emails",
+			mcp.WithDescription("Check which email addresses belong to real Webex users, resolving each to a personId and display name.\n"+
+				"\n"+
+				"WHEN TO USE: Before bulk operations that take a list of people -- adding several members to a room (webex_memberships_create), "+
+				"inviting people to a meeting (webex_meetings_create) -- so you can confirm everyone exists first and avoid partial failures.\n"+
+				"\n"+
+				"RESPONSE:\n"+
+				"- valid: Emails that resolved to a Webex user, with personId and displayName.\n"+
+				"- invalid: Emails that are malformed or not found, with a reason.\n"+
+				"- summary: Counts of checked, valid, and invalid emails.\n"+
+				"\n"+
+				"TIP: Ask the user how to handle invalid addresses (fix typos, skip, or invite as guests) before proceeding."),
+			mcp.WithString("emails", mcp.Required(), mcp.Description("Comma-separated list of email addresses to check (max 100), e.g. 'alice@example.com, bob@example.com'. Duplicates are ignored.")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			client, err := resolver(ctx)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Auth error: %v", err)), nil
+			}
+
+			raw, err := req.RequireString("emails")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			emails, malformed := normalizeEmailList(raw)
+			if len(emails)+len(malformed) == 0 {
+				return mcp.NewToolResultError("No email addresses provided"), nil
+			}
+			if len(emails) > maxEmailsPerValidation {
+				return mcp.NewToolResultError(fmt.Sprintf("Too many emails (%d); at most %d can be checked per call", len(emails), maxEmailsPerValidation)), nil
+			}
+
+			results := lookupPeopleByEmail(client, emails)
+
+			valid := make([]map[string]interface{}, 0, len(emails))
+			invalid := make([]map[string]interface{}, 0, len(malformed))
+			for _, email := range malformed {
+				invalid = append(invalid, map[string]interface{}{
+					"email":  email,
+					"reason": "malformed email address",
+				})
+			}
+			for i, email := range emails {
+				r := results[i]
+				switch {
+				case r.err != nil:
+					invalid = append(invalid, map[string]interface{}{
+						"email":  email,
+						"reason": fmt.Sprintf("lookup failed: %v", r.err),
+					})
+				case r.person == nil:
+					invalid = append(invalid, map[string]interface{}{
+						"email":  email,
+						"reason": "no Webex user found",
+					})
+				default:
+					valid = append(valid, map[string]interface{}{
+						"email":       email,
+						"personId":    r.person.ID,
+						"displayName": r.person.DisplayName,
+					})
+				}
+			}
+
+			response := map[string]interface{}{
+				"summary": map[string]interface{}{
+					"checked": len(emails) + len(malformed),
+					"valid":   len(valid),
+					"invalid": len(invalid),
+				},
+				"valid":   valid,
+				"invalid": invalid,
+			}
+
+			data, _ := json.MarshalIndent(response, "", "  ")
+			return mcp.NewToolResultText(string(data)), nil
+		},
+	)
+
+	// webex_people_directory_search
+	s.AddTool(
+		mcp.NewTool("webex_people_directory_search",
+			mcp.WithDescription("Search the organization's people directory by name or email and return compact contact cards.\n"+
+				"\n"+
+				"USE THIS WHEN:\n"+
+				"- 'Find everyone named Patel' → query='Patel'\n"+
+				"- 'Find Patels in engineering' → query='Patel', department='engineering'\n"+
+				"- 'Who is bob@example.com?' → query='bob@example.com'\n"+
+				"\n"+
+				"MATCHING: A query containing '@' is matched as an email address; anything else is matched against display names "+
+				"(Webex matches from the start of the first or last name, so use a name prefix like 'Pat', not a middle fragment).\n"+
+				"\n"+
+				"RESPONSE: Each item has id, displayName, email, title, department, and status when available. "+
+				"Only people your organization's directory settings let you see are returned.\n"+
+				"\n"+
+				"TIP: To confirm a known list of emails, use webex_people_validate_emails instead."+
+				PaginationDescription),
+			mcp.WithString("query", mcp.Required(), mcp.Description("Name prefix (e.g. 'Patel', 'Ali') or email address to search for. At least 2 characters.")),
+			mcp.WithString("department", mcp.Description("Only return people whose department contains this text (case-insensitive). Applied to each fetched page, so a page may contain fewer than maxResults items.")),
+			mcp.WithNumber("maxResults", mcp.Description(MaxResultsParamDescription)),
+			mcp.WithString("nextPageUrl", mcp.Description(NextPageUrlParamDescription)),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			client, err := resolver(ctx)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Auth error: %v", err)), nil
+			}
+
+			query, err := req.RequireString("query")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			query = strings.TrimSpace(query)
+
+			pageURL := req.GetString("nextPageUrl", "")
+			if pageURL == "" {
+				if len(query) < 2 {
+					return mcp.NewToolResultError("query must be at least 2 characters"), nil
+				}
+				pageURL = client.Core().BaseURL.String() + "/people?" + directorySearchParams(query, PageSize).Encode()
+			}
+
+			page, err := FetchPage(client, pageURL)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to search directory: %v", err)), nil
+			}
+			contacts, err := UnmarshalPageItems[directoryContact](page)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to parse people: %v", err)), nil
+			}
+
+			contacts, hasNextPage, nextURL, _ := AutoPaginate(contacts, page.HasNext, page.NextPage, client, ClampMaxResults(req))
+
+			cards := make([]map[string]interface{}, 0, len(contacts))
+			for _, c := range filterByDepartment(contacts, req.GetString("department", "")) {
+				cards = append(cards, c.card())
+			}
+
+			result, fErr := FormatPaginatedResponse(cards, hasNextPage, nextURL)
+			if fErr != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to format response: %v", fErr)), nil
+			}
+			return mcp.NewToolResultText(result), nil
+		},
+	)
+
+	// webex_people_org_context
+	s.AddTool(
+		mcp.NewTool("webex_people_org_context",
+			mcp.WithDescription("Get a person's place in the organization: title, department, and their manager when the directory records one. Read-only.\n"+
+				"\n"+
+				"USE THIS WHEN:\n"+
+				"- 'Who does alice@example.com report to?'\n"+
+				"- 'What team is Bob on?' -- to decide who to route a question or escalation to.\n"+
+				"\n"+
+				"RESPONSE: person (id, displayName, email, title, department, status) and manager (the same card) when available. "+
+				"Fields the directory does not provide are listed under 'unavailable' with the reason instead of failing the call -- "+
+				"many organizations do not sync org-chart data to Webex, and privacy settings can hide it. "+
+				"Direct reports are never available: the Webex People API does not expose them."),
+			mcp.WithString("personEmail", mcp.Required(), mcp.Description("Email address of the person to look up, e.g. 'alice@example.com'.")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			client, err := resolver(ctx)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Auth error: %v", err)), nil
+			}
+
+			email, err := req.RequireString("personEmail")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			addr, err := mail.ParseAddress(strings.TrimSpace(email))
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Invalid personEmail %q", email)), nil
+			}
+			email = addr.Address
+
+			page, err := FetchPage(client, client.Core().BaseURL.String()+"/people?"+directorySearchParams(email, 1).Encode())
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to look up person: %v", err)), nil
+			}
+			found, err := UnmarshalPageItems[orgContact](page)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to parse people: %v", err)), nil
+			}
+			if len(found) == 0 {
+				return mcp.NewToolResultError(fmt.Sprintf("No Webex user found for %s", email)), nil
+			}
+			person := found[0]
+
+			var manager *directoryContact
+			var managerErr error
+			if person.ManagerID != "" {
+				manager, managerErr = getDirectoryContact(client, person.ManagerID)
+			}
+
+			data, _ := json.MarshalIndent(buildOrgContext(person, manager, managerErr), "", "  ")
+			return mcp.NewToolResultText(string(data)), nil
+		},
+	)
+}
+
+// directoryContact is a person as returned by the People API, including the
+// directory fields the SDK's people.Person does not model.
+type directoryContact struct {
+	ID          string   `json:"id"`
+	DisplayName string   `json:"displayName"`
+	Emails      []string `json:"emails"`
+	Title       string   `json:"title,omitempty"`
+	Department  string   `json:"department,omitempty"`
+	Status      string   `json:"status,omitempty"`
+}
+
+// card returns the compact contact card for a directory entry, omitting empty fields.
+func (c directoryContact) card() map[string]interface{} {
+	card := map[string]interface{}{
+		"id":          c.ID,
+		"displayName": c.DisplayName,
+	}
+	if len(c.Emails) > 0 {
+		card["email"] = c.Emails[0]
+	}
+	if c.Title != "" {
+		card["title"] = c.Title
+	}
+	if c.Department != "" {
+		card["department"] = c.Department
+	}
+	if c.Status != "" {
+		card["status"] = c.Status
+	}
+	return card
+}
+
+// orgContact is a directory entry with the org-chart fields the People API returns
+// when the organization populates them.
+type orgContact struct {
+	directoryContact
+	Manager   string `json:"manager,omitempty"`
+	ManagerID string `json:"managerId,omitempty"`
+}
+
+// getDirectoryContact fetches a single person directly from the API so that
+// directory fields missing from people.Person are available.
+func getDirectoryContact(client *webex.WebexClient, personID string) (*directoryContact, error) {
+	resp, err := client.Core().Request(http.MethodGet, "people/"+url.PathEscape(personID), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	var contact directoryContact
+	if err := webexsdk.ParseResponse(resp, &contact); err != nil {
+		return nil, err
+	}
+	return &contact, nil
+}
+
+// buildOrgContext assembles the webex_people_org_context response. Anything the
+// directory does not provide is reported under "unavailable" rather than as an error.
+// managerErr is the error from fetching the manager's own record, if any.
+func buildOrgContext(person orgContact, manager *directoryContact, managerErr error) map[string]interface{} {
+	unavailable := map[string]string{
+		"directReports": "not exposed by the Webex People API",
+	}
+	if person.Title == "" {
+		unavailable["title"] = "not set in the directory"
+	}
+	if person.Department == "" {
+		unavailable["department"] = "not set in the directory"
+	}
+
+	response := map[string]interface{}{
+		"person": person.card(),
+	}
+	switch {
+	case manager != nil:
+		response["manager"] = manager.card()
+	case person.ManagerID != "" || person.Manager != "":
+		// The directory names a manager but their record could not be read;
+		// return what the person's own record says.
+		card := map[string]interface{}{}
+		if person.ManagerID != "" {
+			card["id"] = person.ManagerID
+		}
+		if person.Manager != "" {
+			card["displayName"] = person.Manager
+		}
+		response["manager"] = card
+		if managerErr != nil {
+			unavailable["managerDetails"] = fmt.Sprintf("could not read the manager's record: %v", managerErr)
+		}
+	default:
+		unavailable["manager"] = "not set in the directory, or hidden by your organization's privacy settings"
+	}
+	response["unavailable"] = unavailable
+	return response
+}
+
+// directorySearchParams builds the People API query for a directory search:
+// queries containing '@' search by email, anything else by display name.
+func directorySearchParams(query string, max int) url.Values {
+	params := url.Values{}
+	if strings.Contains(query, "@") {
+		params.Set("email", query)
+	} else {
+		params.Set("displayName", query)
+	}
+	params.Set("max", strconv.Itoa(max))
+	return params
+}
+
+// filterByDepartment keeps contacts whose department contains dept (case-insensitive).
+// An empty dept keeps everyone.
+func filterByDepartment(contacts []directoryContact, dept string) []directoryContact {
+	dept = strings.ToLower(strings.TrimSpace(dept))
+	if dept == "" {
+		return contacts
+	}
+	kept := make([]directoryContact, 0, len(contacts))
+	for _, c := range contacts {
+		if strings.Contains(strings.ToLower(c.Department), dept) {
+			kept = append(kept, c)
+		}
+	}
+	return kept
+}
+
+// personLookup is the result of resolving a single email.
+type personLookup struct {
+	person *people.Person // nil when no user matched
+	err    error
+}
+
+// lookupPeopleByEmail resolves each email via the People API with bounded concurrency.
+// Results are returned in the same order as emails.
+func lookupPeopleByEmail(client *webex.WebexClient, emails []string) []personLookup {
+	results := make([]personLookup, len(emails))
+	sem := make(chan struct{}, peopleLookupConcurrency)
+	var wg sync.WaitGroup
+
+	for i, email := range emails {
+		wg.Add(1)
+		go func(idx int, e string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			page, err := client.People().List(&people.ListOptions{Email: e})
+			if err != nil {
+				results[idx] = personLookup{err: err}
+				return
+			}
+			if len(page.Items) > 0 {
+				results[idx] = personLookup{person: &page.Items[0]}
+			}
+		}(i, email)
+	}
+
+	wg.Wait()
+	return results
+}
+
+// normalizeEmailList splits a comma-separated list into lowercase, de-duplicated
+// addresses, separating out entries that are not valid email addresses.
+func normalizeEmailList(raw string) (emails []string, malformed []string) {
+	seen := make(map[string]bool)
+	for _, entry := range parseCSV(raw) {
+		addr, err := mail.ParseAddress(entry)
+		if err != nil {
+			malformed = append(malformed, entry)
+			continue
+		}
+		email := strings.ToLower(addr.Address)
+		if seen[email] {
+			continue
+		}
+		seen[email] = true
+		emails = append(emails, email)
+	}
+	return emails, malformed
+}
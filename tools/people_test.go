@@ -0,0 +1,113 @@
+package tools
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	webex "github.com/WebexCommunity/webex-go-sdk/v2"
+	"github.com/WebexCommunity/webex-go-sdk/v2/webexsdk"
+)
+
+func TestNormalizeEmailList(t *testing.T) {
+	emails, malformed := normalizeEmailList("Alice@Example.com, bob@example.com,alice@example.com, not-an-email, , Carol <carol@example.com>")
+
+	wantEmails := []string{"alice@example.com", "bob@example.com", "carol@example.com"}
+	if !reflect.DeepEqual(emails, wantEmails) {
+		t.Errorf("emails = %v, want %v", emails, wantEmails)
+	}
+	wantMalformed := []string{"not-an-email"}
+	if !reflect.DeepEqual(malformed, wantMalformed) {
+		t.Errorf("malformed = %v, want %v", malformed, wantMalformed)
+	}
+}
+
+func TestDirectorySearchParams(t *testing.T) {
+	if got := directorySearchParams("bob@example.com", 10).Encode(); got != "email=bob%40example.com&max=10" {
+		t.Errorf("email query params = %q", got)
+	}
+	if got := directorySearchParams("Patel", 10).Encode(); got != "displayName=Patel&max=10" {
+		t.Errorf("name query params = %q", got)
+	}
+}
+
+func TestFilterByDepartment(t *testing.T) {
+	contacts := []directoryContact{
+		{ID: "1", Department: "Engineering - Platform"},
+		{ID: "2", Department: "Sales"},
+		{ID: "3"},
+	}
+	got := filterByDepartment(contacts, "engineering")
+	if len(got) != 1 || got[0].ID != "1" {
+		t.Errorf("filterByDepartment() = %v, want only ID 1", got)
+	}
+	if got := filterByDepartment(contacts, ""); len(got) != 3 {
+		t.Errorf("filterByDepartment(\"\") returned %d contacts, want 3", len(got))
+	}
+}
+
+func TestBuildOrgContext(t *testing.T) {
+	base := directoryContact{ID: "p1", DisplayName: "Alice", Emails: []string{"alice@example.com"}, Title: "Engineer", Department: "Platform"}
+
+	t.Run("manager resolved", func(t *testing.T) {
+		boss := &directoryContact{ID: "m1", DisplayName: "Bob", Title: "Director"}
+		got := buildOrgContext(orgContact{directoryContact: base, ManagerID: "m1"}, boss, nil)
+		if got["manager"].(map[string]interface{})["displayName"] != "Bob" {
+			t.Errorf("manager = %v", got["manager"])
+		}
+		unavailable := got["unavailable"].(map[string]string)
+		if _, ok := unavailable["manager"]; ok {
+			t.Error("manager reported unavailable although it was resolved")
+		}
+		if _, ok := unavailable["directReports"]; !ok {
+			t.Error("directReports should always be reported unavailable")
+		}
+	})
+
+	t.Run("manager record unreadable", func(t *testing.T) {
+		got := buildOrgContext(orgContact{directoryContact: base, ManagerID: "m1", Manager: "Bob"}, nil, errors.New("403 Forbidden"))
+		manager := got["manager"].(map[string]interface{})
+		if manager["id"] != "m1" || manager["displayName"] != "Bob" {
+			t.Errorf("manager = %v, want id and name from the person's record", manager)
+		}
+		if _, ok := got["unavailable"].(map[string]string)["managerDetails"]; !ok {
+			t.Error("expected managerDetails in unavailable")
+		}
+	})
+
+	t.Run("no org chart data", func(t *testing.T) {
+		got := buildOrgContext(orgContact{directoryContact: directoryContact{ID: "p2", DisplayName: "Carol"}}, nil, nil)
+		if _, ok := got["manager"]; ok {
+			t.Error("manager should be omitted")
+		}
+		unavailable := got["unavailable"].(map[string]string)
+		for _, field := range []string{"manager", "title", "department", "directReports"} {
+			if _, ok := unavailable[field]; !ok {
+				t.Errorf("expected %s in unavailable", field)
+			}
+		}
+	})
+}
+
+func TestGetDirectoryContactEscapesID(t *testing.T) {
+	var gotPath string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.EscapedPath()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"p1","displayName":"Alice"}`))
+	}))
+	defer srv.Close()
+	client, err := webex.NewClient("test-token", &webexsdk.Config{BaseURL: srv.URL})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	if _, err := getDirectoryContact(client, "../rooms/x?y"); err != nil {
+		t.Fatalf("getDirectoryContact: %v", err)
+	}
+	if want := "/people/..%2Frooms%2Fx%3Fy"; gotPath != want {
+		t.Errorf("request path = %q, want %q", gotPath, want)
+	}
+}
@@ -0,0 +1,201 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	webex "github.com/WebexCommunity/webex-go-sdk/v2"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/tejzpr/webex-go-mcp/auth"
+)
+
+// Preference keys understood by webex_preferences_set and consulted by other tools.
+const (
+	PrefTimezone         = "timezone"
+	PrefDefaultRoomID    = "defaultRoomId"
+	PrefTranscriptFormat = "transcriptFormat"
+)
+
+// preferenceValidators validates the value for each supported preference key.
+var preferenceValidators = map[string]func(value string) error{
+	PrefTimezone: func(value string) error {
+		if _, err := time.LoadLocation(value); err != nil {
+			return fmt.Errorf("unknown IANA timezone %q", value)
+		}
+		return nil
+	},
+	PrefDefaultRoomID: func(value string) error {
+		return nil
+	},
+	PrefTranscriptFormat: func(value string) error {
+		if value != "txt" && value != "vtt" && value != "dialogue" {
+			return fmt.Errorf("transcriptFormat must be 'txt', 'vtt', or 'dialogue', got %q", value)
+		}
+		return nil
+	},
+}
+
+// supportedPreferenceKeys returns the supported preference keys in sorted order.
+func supportedPreferenceKeys() []string {
+	keys := make([]string, 0, len(preferenceValidators))
+	for k := range preferenceValidators {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// validatePreference checks that key is supported and value is acceptable for it.
+// An empty value is always accepted and means "clear this preference".
+func validatePreference(key, value string) error {
+	validate, ok := preferenceValidators[key]
+	if !ok {
+		return fmt.Errorf("unknown preference %q; supported keys: %s", key, strings.Join(supportedPreferenceKeys(), ", "))
+	}
+	if value == "" {
+		return nil
+	}
+	return validate(value)
+}
+
+// currentUserID returns the Webex person ID of the authenticated user, used to
+// namespace preferences per user.
+func currentUserID(client *webex.WebexClient) (string, error) {
+	me, err := client.People().GetMe()
+	if err != nil {
+		return "", err
+	}
+	if me.ID == "" {
+		return "", fmt.Errorf("no user ID returned for the authenticated user")
+	}
+	return me.ID, nil
+}
+
+// lookupPreference returns the authenticated user's stored value for key, or "" if
+// preferences are disabled, unset, or cannot be read. Failures are logged.
+func lookupPreference(prefs auth.PreferenceStore, client *webex.WebexClient, key string) string {
+	if prefs == nil || client == nil {
+		return ""
+	}
+	userID, err := currentUserID(client)
+	if err != nil {
+		log.Printf("Preferences: failed to resolve current user: %v", err)
+		return ""
+	}
+	values, err := prefs.GetPreferences(userID)
+	if err != nil {
+		log.Printf("Preferences: failed to read preferences: %v", err)
+		return ""
+	}
+	return values[key]
+}
+
+// RegisterPreferenceTools registers the per-user preferences tools.
+// These are opt-in: they are only registered when a PreferenceStore is configured.
+func RegisterPreferenceTools(s ToolRegistrar, resolver auth.ClientResolver, prefs auth.PreferenceStore) {
+	// webex_preferences_get
+	s.AddTool(
+		mcp.NewTool("webex_preferences_get",
+			mcp.WithDescription("Get the current user's saved preferences (defaults remembered across sessions).\n"+
+				"\n"+
+				"SUPPORTED KEYS:\n"+
+				"- timezone: Default IANA timezone. webex_meetings_create uses it when no timezone is given.\n"+
+				"- defaultRoomId: The room the user usually means by 'my room' or 'the team space'. webex_messages_list uses it when no roomId is given.\n"+
+				"- transcriptFormat: Preferred transcript download format ('txt', 'vtt', or 'dialogue'). webex_transcripts_download uses it when no format is given.\n"+
+				"\n"+
+				"TIP: Check preferences at the start of a session so you don't have to ask the user for the same defaults again."),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			client, err := resolver(ctx)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Auth error: %v", err)), nil
+			}
+
+			userID, err := currentUserID(client)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to identify current user: %v", err)), nil
+			}
+
+			values, err := prefs.GetPreferences(userID)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to get preferences: %v", err)), nil
+			}
+
+			response := map[string]interface{}{
+				"preferences":   values,
+				"supportedKeys": supportedPreferenceKeys(),
+			}
+
+			data, _ := json.MarshalIndent(response, "", "  ")
+			return mcp.NewToolResultText(string(data)), nil
+		},
+	)
+
+	// webex_preferences_set
+	s.AddTool(
+		mcp.NewTool("webex_preferences_set",
+			mcp.WithDescription("Save or clear one of the current user's preferences. Preferences are stored per user and persist across sessions.\n"+
+				"\n"+
+				"SUPPORTED KEYS:\n"+
+				"- timezone: IANA timezone name (e.g. 'America/New_York').\n"+
+				"- defaultRoomId: A room ID from webex_rooms_list.\n"+
+				"- transcriptFormat: 'txt', 'vtt', or 'dialogue'.\n"+
+				"\n"+
+				"To clear a preference, pass an empty value.\n"+
+				"\n"+
+				"IMPORTANT: Only save a preference when the user asks you to remember it."),
+			mcp.WithString("key", mcp.Required(), mcp.Description("Preference key: 'timezone', 'defaultRoomId', or 'transcriptFormat'.")),
+			mcp.WithString("value", mcp.Description("New value. Omit or pass an empty string to clear the preference.")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			client, err := resolver(ctx)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Auth error: %v", err)), nil
+			}
+
+			key, err := req.RequireString("key")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			key = strings.TrimSpace(key)
+			value := strings.TrimSpace(req.GetString("value", ""))
+
+			if err := validatePreference(key, value); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Invalid preference: %v", err)), nil
+			}
+
+			userID, err := currentUserID(client)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to identify current user: %v", err)), nil
+			}
+
+			if value == "" {
+				err = prefs.DeletePreference(userID, key)
+			} else {
+				err = prefs.SetPreference(userID, key, value)
+			}
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to save preference: %v", err)), nil
+			}
+
+			values, err := prefs.GetPreferences(userID)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to get preferences: %v", err)), nil
+			}
+
+			response := map[string]interface{}{
+				"key":         key,
+				"cleared":     value == "",
+				"preferences": values,
+			}
+
+			data, _ := json.MarshalIndent(response, "", "  ")
+			return mcp.NewToolResultText(string(data)), nil
+		},
+	)
+}
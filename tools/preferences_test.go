@@ -0,0 +1,66 @@
+package tools
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/tejzpr/webex-go-mcp/auth"
+)
+
+func TestValidatePreference(t *testing.T) {
+	tests := []struct {
+		key, value string
+		wantErr    bool
+	}{
+		{"timezone", "America/New_York", false},
+		{"timezone", "Mars/Olympus", true},
+		{"timezone", "", false},
+		{"transcriptFormat", "vtt", false},
+		{"transcriptFormat", "dialogue", false},
+		{"transcriptFormat", "pdf", true},
+		{"defaultRoomId", "Y2lzY29zcGFyazovL3Vz", false},
+		{"favouriteColour", "blue", true},
+	}
+	for _, tt := range tests {
+		err := validatePreference(tt.key, tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("validatePreference(%q, %q) error = %v, wantErr %v", tt.key, tt.value, err, tt.wantErr)
+		}
+	}
+}
+
+func TestTranscriptsDownloadUsesFormatPreference(t *testing.T) {
+	var gotFormat string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/people/me":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"id":"me"}`))
+		case "/meetingTranscripts/t1/download":
+			gotFormat = r.URL.Query().Get("format")
+			w.Write([]byte("WEBVTT"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	store := auth.NewMemoryStore(time.Hour)
+	defer store.Close()
+	if err := store.SetPreference("me", PrefTranscriptFormat, "vtt"); err != nil {
+		t.Fatalf("SetPreference: %v", err)
+	}
+	register := func(s ToolRegistrar, r auth.ClientResolver) { RegisterTranscriptTools(s, r, store) }
+
+	result := callTool(t, register, srv.URL, "webex_transcripts_download", map[string]interface{}{"transcriptId": "t1", "meetingId": "m1"})
+	if result.IsError || gotFormat != "vtt" {
+		t.Errorf("format = %q (result %q), want the saved 'vtt' preference", gotFormat, resultText(result))
+	}
+
+	result = callTool(t, register, srv.URL, "webex_transcripts_download", map[string]interface{}{"transcriptId": "t1", "meetingId": "m1", "format": "txt"})
+	if result.IsError || gotFormat != "txt" {
+		t.Errorf("format = %q (result %q), want the explicit 'txt'", gotFormat, resultText(result))
+	}
+}
@@ -137,6 +137,7 @@ func RegisterRecordingTools(s ToolRegistrar, resolver auth.ClientResolver) {
 
 				// Enrich: meeting information if we have meeting ID
 				if recording.MeetingID != "" {
+					countEnrichment(client)
 					if meeting, mErr := client.Meetings().Get(recording.MeetingID); mErr == nil {
 						er["meeting"] = map[string]interface{}{
 							"id":              meeting.ID,
@@ -165,25 +166,13 @@ func RegisterRecordingTools(s ToolRegistrar, resolver auth.ClientResolver) {
 				// Enrich: file size in human readable format
 				if recording.SizeBytes > 0 {
 					er["sizeBytes"] = recording.SizeBytes
-					if recording.SizeBytes < 1024 {
-						er["sizeHuman"] = fmt.Sprintf("%d B", recording.SizeBytes)
-					} else if recording.SizeBytes < 1024*1024 {
-						er["sizeHuman"] = fmt.Sprintf("%.1f KB", float64(recording.SizeBytes)/1024)
-					} else {
-						er["sizeHuman"] = fmt.Sprintf("%.1f MB", float64(recording.SizeBytes)/(1024*1024))
-					}
+					er["sizeHuman"] = formatByteSize(recording.SizeBytes)
 				}
 
 				// Enrich: duration in human readable format
 				if recording.DurationSeconds > 0 {
 					er["durationSeconds"] = recording.DurationSeconds
-					if recording.DurationSeconds < 60 {
-						er["durationHuman"] = fmt.Sprintf("%d seconds", recording.DurationSeconds)
-					} else if recording.DurationSeconds < 3600 {
-						er["durationHuman"] = fmt.Sprintf("%.1f minutes", float64(recording.DurationSeconds)/60)
-					} else {
-						er["durationHuman"] = fmt.Sprintf("%.1f hours", float64(recording.DurationSeconds)/3600)
-					}
+					er["durationHuman"] = formatDuration(recording.DurationSeconds)
 				}
 
 				// Enrich: recording status
@@ -302,25 +291,13 @@ func RegisterRecordingTools(s ToolRegistrar, resolver auth.ClientResolver) {
 			// Enrich: file size in human readable format
 			if result.SizeBytes > 0 {
 				response["sizeBytes"] = result.SizeBytes
-				if result.SizeBytes < 1024 {
-					response["sizeHuman"] = fmt.Sprintf("%d B", result.SizeBytes)
-				} else if result.SizeBytes < 1024*1024 {
-					response["sizeHuman"] = fmt.Sprintf("%.1f KB", float64(result.SizeBytes)/1024)
-				} else {
-					response["sizeHuman"] = fmt.Sprintf("%.1f MB", float64(result.SizeBytes)/(1024*1024))
-				}
+				response["sizeHuman"] = formatByteSize(result.SizeBytes)
 			}
 
 			// Enrich: duration in human readable format
 			if result.DurationSeconds > 0 {
 				response["durationSeconds"] = result.DurationSeconds
-				if result.DurationSeconds < 60 {
-					response["durationHuman"] = fmt.Sprintf("%d seconds", result.DurationSeconds)
-				} else if result.DurationSeconds < 3600 {
-					response["durationHuman"] = fmt.Sprintf("%.1f minutes", float64(result.DurationSeconds)/60)
-				} else {
-					response["durationHuman"] = fmt.Sprintf("%.1f hours", float64(result.DurationSeconds)/3600)
-				}
+				response["durationHuman"] = formatDuration(result.DurationSeconds)
 			}
 
 			// Enrich: password protection
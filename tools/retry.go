@@ -0,0 +1,53 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/WebexCommunity/webex-go-sdk/v2/webexsdk"
+)
+
+// maxRetryAttempts is the number of times withRetry calls fn before giving up.
+const maxRetryAttempts = 3
+
+// retryBaseDelay is the backoff before the first retry; it doubles on each attempt.
+// A Retry-After header from Webex takes precedence.
+var retryBaseDelay = 1 * time.Second
+
+// withRetry calls fn, retrying transient Webex failures (429 rate limits and 5xx
+// server errors) with exponential backoff. Other errors are returned immediately.
+// It stops waiting and returns ctx.Err() as soon as ctx is cancelled.
+func withRetry(ctx context.Context, fn func() error) error {
+	var err error
+	for attempt := 0; attempt < maxRetryAttempts; attempt++ {
+		if cErr := ctx.Err(); cErr != nil {
+			return cErr
+		}
+		if err = fn(); err == nil || !isTransientError(err) {
+			return err
+		}
+		if attempt == maxRetryAttempts-1 {
+			break
+		}
+
+		delay := retryBaseDelay << attempt
+		var apiErr *webexsdk.APIError
+		if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+			delay = apiErr.RetryAfter
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	return err
+}
+
+// isTransientError reports whether err is worth retrying.
+func isTransientError(err error) bool {
+	return webexsdk.IsRateLimited(err) || webexsdk.IsServerError(err)
+}
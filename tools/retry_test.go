@@ -0,0 +1,79 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/WebexCommunity/webex-go-sdk/v2/webexsdk"
+)
+
+func TestWithRetry(t *testing.T) {
+	orig := retryBaseDelay
+	retryBaseDelay = time.Millisecond
+	defer func() { retryBaseDelay = orig }()
+
+	rateLimited := webexsdk.NewAPIError(&http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}, nil)
+	notFound := webexsdk.NewAPIError(&http.Response{StatusCode: http.StatusNotFound, Header: http.Header{}}, nil)
+
+	tests := []struct {
+		name      string
+		errs      []error // returned by successive calls; nil after the list is exhausted
+		wantCalls int
+		wantErr   bool
+	}{
+		{"succeeds first time", nil, 1, false},
+		{"retries rate limit then succeeds", []error{rateLimited}, 2, false},
+		{"gives up after max attempts", []error{rateLimited, rateLimited, rateLimited}, maxRetryAttempts, true},
+		{"does not retry permanent errors", []error{notFound}, 1, true},
+		{"does not retry plain errors", []error{errors.New("boom")}, 1, true},
+	}
+	for _, tt := range tests {
+		calls := 0
+		err := withRetry(context.Background(), func() error {
+			calls++
+			if calls <= len(tt.errs) {
+				return tt.errs[calls-1]
+			}
+			return nil
+		})
+		if calls != tt.wantCalls {
+			t.Errorf("%s: calls = %d, want %d", tt.name, calls, tt.wantCalls)
+		}
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: err = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestWithRetryStopsOnCancel(t *testing.T) {
+	orig := retryBaseDelay
+	retryBaseDelay = time.Hour
+	defer func() { retryBaseDelay = orig }()
+
+	rateLimited := webexsdk.NewAPIError(&http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	done := make(chan error, 1)
+	go func() {
+		done <- withRetry(ctx, func() error {
+			calls++
+			return rateLimited
+		})
+	}()
+	cancel()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("err = %v, want context.Canceled", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("withRetry kept sleeping after the context was cancelled")
+	}
+	if calls > 1 {
+		t.Errorf("calls = %d, want at most 1", calls)
+	}
+}
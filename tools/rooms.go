@@ -4,18 +4,26 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
 	"sync"
+	"time"
+	"unicode"
 
 	webex "github.com/WebexCommunity/webex-go-sdk/v2"
 	"github.com/WebexCommunity/webex-go-sdk/v2/memberships"
 	"github.com/WebexCommunity/webex-go-sdk/v2/messages"
 	"github.com/WebexCommunity/webex-go-sdk/v2/rooms"
+	"github.com/WebexCommunity/webex-go-sdk/v2/webexsdk"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/tejzpr/webex-go-mcp/auth"
 )
 
 // Compact fields for rooms list
-var roomsCompactFields = []string{"room", "teamName", "memberCount"}
+var roomsCompactFields = []string{"room", "teamName", "memberCount", "memberCountNote"}
 
 // RegisterRoomTools registers all room/space-related MCP tools.
 func RegisterRoomTools(s ToolRegistrar, resolver auth.ClientResolver) {
@@ -38,7 +46,8 @@ func RegisterRoomTools(s ToolRegistrar, resolver auth.ClientResolver) {
 				"- You do NOT need to find a room to DM someone. Use webex_messages_create with 'toPersonEmail' directly -- it's much simpler.\n"+
 				"- You only need a roomId when you want to read messages from a conversation (webex_messages_list requires it).\n"+
 				"\n"+
-				"RESPONSE: Enriched with team name, member count, and last message preview per room."+
+				"RESPONSE: Enriched with team name, member count, and last message preview per room. "+
+				"Member counts above 100 are lower bounds, flagged with memberCountIsLowerBound and a memberCountNote like 'at least 100 (more available)' -- use webex_rooms_get for the full count."+
 				PaginationDescription),
 			mcp.WithString("teamId", mcp.Description("Filter to only rooms that belong to this team. Get a teamId from webex_teams_list.")),
 			mcp.WithString("type", mcp.Description("Filter by room type. 'direct' = 1:1 conversations (room title is the other person's name). 'group' = named multi-person spaces. Omit to get both types.")),
@@ -166,8 +175,9 @@ func RegisterRoomTools(s ToolRegistrar, resolver auth.ClientResolver) {
 				"- room: Full room details (title, type, created date, lastActivity, etc.).\n"+
 				"- team: Team name and ID if the room belongs to a team.\n"+
 				"- creator: Display name of who created the room.\n"+
+				"- moderation: Whether the room is moderated (locked), whether it is announcement-only, and the moderators' emails.\n"+
 				"- members: Full list of everyone in the room with their display names, emails, and moderator status.\n"+
-				"- memberCount: Total number of members.\n"+
+				"- memberCount: Total number of members (up to 1000; beyond that memberCountNote says 'at least N (more available)').\n"+
 				"- recentMessages: The 5 most recent messages with sender names -- gives a snapshot of the current conversation.\n"+
 				"\n"+
 				"This is the best tool to use when the user asks 'who is in this room?' or 'what's happening in this space?' -- one call gets everything."),
@@ -184,14 +194,20 @@ func RegisterRoomTools(s ToolRegistrar, resolver auth.ClientResolver) {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
-			result, err := client.Rooms().Get(roomID)
+			settings, err := getRoomSettings(client, roomID)
 			if err != nil {
 				return mcp.NewToolResultError(fmt.Sprintf("Failed to get room: %v", err)), nil
 			}
+			result := &settings.Room
 
 			response := map[string]interface{}{
 				"room": result,
 			}
+			moderation := map[string]interface{}{
+				"moderated":        result.IsLocked,
+				"announcementOnly": settings.IsAnnouncementOnly,
+			}
+			response["moderation"] = moderation
 
 			if result.TeamID != "" {
 				if team, tErr := client.Teams().Get(result.TeamID); tErr == nil {
@@ -211,9 +227,22 @@ func RegisterRoomTools(s ToolRegistrar, resolver auth.ClientResolver) {
 
 			if memberPage, mErr := client.Memberships().List(&memberships.ListOptions{
 				RoomID: roomID,
+				Max:    CatalogPageSize,
 			}); mErr == nil {
-				response["members"] = memberPage.Items
-				response["memberCount"] = len(memberPage.Items)
+				members, more, fErr := FetchAll(memberPage.Items, memberPage.HasNext, memberPage.NextPage, client, EnrichmentCountCap)
+				response["members"] = members
+				AddCountToMap(response, "memberCount", len(members), more || fErr != nil)
+				if fErr != nil {
+					response["membersError"] = fmt.Sprintf("Only the first %d members could be read: %v", len(members), fErr)
+				}
+
+				moderators := []string{}
+				for _, m := range members {
+					if m.IsModerator {
+						moderators = append(moderators, m.PersonEmail)
+					}
+				}
+				moderation["moderators"] = moderators
 			}
 
 			if msgPage, mErr := client.Messages().List(&messages.ListOptions{
@@ -310,6 +339,1044 @@ func RegisterRoomTools(s ToolRegistrar, resolver auth.ClientResolver) {
 			return mcp.NewToolResultText("Room deleted successfully"), nil
 		},
 	)
+
+	// webex_rooms_watch_changes
+	s.AddTool(
+		mcp.NewTool("webex_rooms_watch_changes",
+			mcp.WithDescription("Poll a room for changes since a known message: returns NEW messages, EDITED messages, and DELETED messages compared to a prior state you supply. "+
+				"A stateless alternative to streaming for agents that cannot hold a live subscription or receive webhooks.\n"+
+				"\n"+
+				"HOW TO USE:\n"+
+				"1. First call: pass roomId and sinceMessageId (e.g. the latest message ID from webex_messages_list). Omit priorState.\n"+
+				"2. Save the 'state' object from the response.\n"+
+				"3. Later calls: pass the same roomId and sinceMessageId plus priorState = the saved 'state' (as a JSON string). The response lists what changed in between.\n"+
+				"\n"+
+				"RESPONSE:\n"+
+				"- new: Messages posted after sinceMessageId that are not in priorState.\n"+
+				"- edited: Messages in priorState whose 'updated' timestamp changed.\n"+
+				"- deleted: Message IDs in priorState that no longer exist.\n"+
+				"- state: The current {messageId: updated} map to pass as priorState next time.\n"+
+				"- partial/fetchError: Set if a page of messages failed to load; the window is incomplete and deleted detection was skipped. Retry the call.\n"+
+				"\n"+
+				"LIMITATIONS (vs. real-time streaming via webex_subscribe_room_messages):\n"+
+				"- Changes are only seen when you poll; there is no push notification.\n"+
+				"- Only messages from sinceMessageId onwards are tracked, up to maxResults (default 50, max 200). If sinceMessageId is not found within that window, deleted detection is skipped.\n"+
+				"- Edits are detected from the message 'updated' timestamp; an edit followed by a revert still shows as edited.\n"+
+				"- Each poll costs one API call per 10 messages in the window."),
+			mcp.WithString("roomId", mcp.Required(), mcp.Description("The ID of the room to watch. Get this from webex_rooms_list.")),
+			mcp.WithString("sinceMessageId", mcp.Required(), mcp.Description("The oldest message to track. Messages posted after it are reported as new; it and later messages are checked for edits/deletes.")),
+			mcp.WithString("priorState", mcp.Description("The 'state' object from the previous call, as a JSON string (e.g. '{\"<messageId>\": \"<updated or empty>\"}'). Omit on the first call.")),
+			mcp.WithNumber("maxResults", mcp.Description("Max messages to scan back from the newest (default 50, max 200). Increase if the room is busy.")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			client, err := resolver(ctx)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Auth error: %v", err)), nil
+			}
+
+			roomID, err := req.RequireString("roomId")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sinceID, err := req.RequireString("sinceMessageId")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			prior := map[string]string{}
+			if v := req.GetString("priorState", ""); v != "" {
+				if err := json.Unmarshal([]byte(v), &prior); err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("Invalid priorState: %v (expected a JSON object of messageId -> updated timestamp)", err)), nil
+				}
+			}
+
+			page, err := client.Messages().List(&messages.ListOptions{RoomID: roomID, Max: PageSize})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to list messages: %v", err)), nil
+			}
+			window, found, fetchErr := listMessagesSince(page.Items, page.HasNext, page.NextPage, client, sinceID, ClampMaxResults(req))
+
+			changes := diffMessages(prior, window, sinceID, found)
+
+			nameCache := NewPersonNameCache(client)
+			summarize := func(msgs []messages.Message) []map[string]interface{} {
+				out := make([]map[string]interface{}, 0, len(msgs))
+				for _, msg := range msgs {
+					m := map[string]interface{}{
+						"id":          msg.ID,
+						"text":        msg.Text,
+						"personEmail": msg.PersonEmail,
+						"senderName":  nameCache.Resolve(msg.PersonID),
+						"created":     msg.Created,
+					}
+					if msg.Updated != nil {
+						m["updated"] = msg.Updated
+					}
+					if msg.ParentID != "" {
+						m["parentId"] = msg.ParentID
+					}
+					out = append(out, m)
+				}
+				return out
+			}
+
+			response := map[string]interface{}{
+				"roomId":           roomID,
+				"sinceMessageId":   sinceID,
+				"sinceMessageSeen": found,
+				"scanned":          len(window),
+				"new":              summarize(changes.New),
+				"edited":           summarize(changes.Edited),
+				"deleted":          changes.Deleted,
+				"state":            changes.State,
+			}
+			AddFetchErrorToMap(response, fetchErr)
+			if fetchErr != nil {
+				response["warning"] = fmt.Sprintf("Only the newest %d messages could be read before a page failed to load, and sinceMessageId was not among them. Deleted detection was skipped; retry the call.", len(window))
+			} else if !found {
+				response["warning"] = fmt.Sprintf("sinceMessageId was not found within the newest %d messages. It may have been deleted, or the room is busier than maxResults allows. Deleted detection was skipped.", len(window))
+			}
+
+			data, _ := json.MarshalIndent(response, "", "  ")
+			return mcp.NewToolResultText(string(data)), nil
+		},
+	)
+
+	// webex_rooms_set_moderated
+	s.AddTool(
+		mcp.NewTool("webex_rooms_set_moderated",
+			mcp.WithDescription("Turn moderation on or off for a Webex group space, optionally making it announcement-only.\n"+
+				"\n"+
+				"MODES:\n"+
+				"- moderated=true: The space is locked. Only moderators can add/remove people, rename the space, or change moderators.\n"+
+				"- moderated=true, announcementOnly=true: Only moderators can post messages (broadcast channel). Requires moderation.\n"+
+				"- moderated=false: Unlocks the space. Announcement-only mode is also turned off.\n"+
+				"\n"+
+				"PERMISSIONS: Turning moderation OFF or changing announcement mode on an already-moderated space requires the authenticated user to be a moderator. "+
+				"Use webex_rooms_get to check the current moderation status and moderators. Moderators can be assigned with webex_memberships_update (isModerator=true). "+
+				"Only group spaces can be moderated -- 1:1 direct rooms cannot.\n"+
+				"\n"+
+				"IMPORTANT: Always confirm with the user before changing moderation -- it changes who can post and manage the space for all members."),
+			mcp.WithString("roomId", mcp.Required(), mcp.Description("The ID of the group space. Get this from webex_rooms_list.")),
+			mcp.WithBoolean("moderated", mcp.Required(), mcp.Description("true to lock (moderate) the space, false to unlock it.")),
+			mcp.WithBoolean("announcementOnly", mcp.Description("When moderated=true, set to true so only moderators can post. Defaults to false. Ignored when moderated=false.")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			client, err := resolver(ctx)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Auth error: %v", err)), nil
+			}
+
+			roomID, err := req.RequireString("roomId")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			moderated, err := req.RequireBool("moderated")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			announcementOnly := moderated && req.GetBool("announcementOnly", false)
+
+			current, err := getRoomSettings(client, roomID)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to get room: %v", err)), nil
+			}
+			if current.Type == "direct" {
+				return mcp.NewToolResultError("Only group spaces can be moderated; this is a 1:1 direct room"), nil
+			}
+
+			// PUT /rooms requires the title; send the moderation flags explicitly so false values are not dropped
+			body := map[string]interface{}{
+				"title":              current.Title,
+				"isLocked":           moderated,
+				"isAnnouncementOnly": announcementOnly,
+			}
+			resp, err := client.Core().Request(http.MethodPut, "rooms/"+roomID, nil, body)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to update room moderation: %v", err)), nil
+			}
+			var updated roomSettings
+			if err := webexsdk.ParseResponse(resp, &updated); err != nil {
+				if webexsdk.IsForbidden(err) {
+					return mcp.NewToolResultError(fmt.Sprintf("Failed to update room moderation: you must be a moderator of this space to change its moderation settings. "+
+						"Check the moderators with webex_rooms_get and ask one of them to make the change or to make you a moderator. (%v)", err)), nil
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to update room moderation: %v", err)), nil
+			}
+
+			response := map[string]interface{}{
+				"room": updated.Room,
+				"before": map[string]interface{}{
+					"moderated":        current.IsLocked,
+					"announcementOnly": current.IsAnnouncementOnly,
+				},
+				"after": map[string]interface{}{
+					"moderated":        updated.IsLocked,
+					"announcementOnly": updated.IsAnnouncementOnly,
+				},
+			}
+
+			data, _ := json.MarshalIndent(response, "", "  ")
+			return mcp.NewToolResultText(string(data)), nil
+		},
+	)
+
+	// webex_rooms_catalog
+	s.AddTool(
+		mcp.NewTool("webex_rooms_catalog",
+			mcp.WithDescription("Get a compact id/title lookup table of ALL rooms/spaces the user belongs to, with no enrichment. "+
+				"Much cheaper than webex_rooms_list -- use it once to map room names to IDs, then keep it as a reference for later calls instead of searching again.\n"+
+				"\n"+
+				"RESPONSE: _catalog (count, truncated, message) followed by items of {id, title, type}. "+
+				"If truncated=true, re-call with a higher maxItems or narrow with 'type'."),
+			mcp.WithString("type", mcp.Description("Filter by room type: 'direct' (1:1) or 'group'. Omit for both.")),
+			mcp.WithNumber("maxItems", mcp.Description(CatalogMaxItemsParamDescription)),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			client, err := resolver(ctx)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Auth error: %v", err)), nil
+			}
+
+			opts := &rooms.ListOptions{Max: CatalogPageSize}
+			if v := req.GetString("type", ""); v != "" {
+				opts.Type = v
+			}
+
+			page, err := client.Rooms().List(opts)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to list rooms: %v", err)), nil
+			}
+
+			roomItems, truncated, fetchErr := FetchAll(page.Items, page.HasNext, page.NextPage, client, ClampCatalogMaxItems(req))
+
+			entries := make([]map[string]string, 0, len(roomItems))
+			for _, r := range roomItems {
+				entries = append(entries, map[string]string{
+					"id":    r.ID,
+					"title": r.Title,
+					"type":  r.Type,
+				})
+			}
+
+			result, fErr := FormatCatalogResponse(entries, len(entries), truncated, fetchErr)
+			if fErr != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to format response: %v", fErr)), nil
+			}
+			return mcp.NewToolResultText(result), nil
+		},
+	)
+
+	// webex_rooms_purge_my_messages
+	s.AddTool(
+		mcp.NewTool("webex_rooms_purge_my_messages",
+			mcp.WithDescription("Permanently delete the messages YOU sent in a room/space, leaving everyone else's messages alone. "+
+				"Use this to clean up your own posts in a shared space without deleting the room.\n"+
+				"\n"+
+				"HOW TO USE SAFELY:\n"+
+				"1. Call with dryRun=true first to see how many of your messages would be deleted.\n"+
+				"2. Tell the user the room name and the count, and that deleted messages CANNOT be recovered.\n"+
+				"3. Only after the user explicitly agrees, call again with confirm=true.\n"+
+				"\n"+
+				"RESPONSE: scanned (messages examined), matched (yours), deleted, failed (messageId + error for each failure), "+
+				"and truncated=true if older messages were not scanned because of maxScan. When truncated, matched only covers the scanned window "+
+				"(flagged matchedIsLowerBound) and nextBefore is set: call again with before=nextBefore to continue with older messages.\n"+
+				"\n"+
+				"IMPORTANT: This is destructive and irreversible. Never call with confirm=true without the user's explicit confirmation in this conversation."),
+			mcp.WithString("roomId", mcp.Required(), mcp.Description("The room/space to clean up. Get this from webex_rooms_list.")),
+			mcp.WithString("before", mcp.Description("Only delete messages sent before this time (UTC format: '2026-01-01T00:00:00Z'). Omit to include all messages.")),
+			mcp.WithBoolean("dryRun", mcp.Description("When true, only count your messages; nothing is deleted. Default: false.")),
+			mcp.WithBoolean("confirm", mcp.Description("Must be true to actually delete. Set only after the user has explicitly confirmed.")),
+			mcp.WithNumber("maxScan", mcp.Description("Max messages to scan, newest first (default 1000, max 5000).")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			client, err := resolver(ctx)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Auth error: %v", err)), nil
+			}
+
+			roomID, err := req.RequireString("roomId")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			dryRun := req.GetBool("dryRun", false)
+			if !dryRun && !req.GetBool("confirm", false) {
+				return mcp.NewToolResultError("Refusing to delete without confirm=true. Run with dryRun=true, show the user the count, and get their explicit confirmation first."), nil
+			}
+
+			opts := &messages.ListOptions{RoomID: roomID, Max: CatalogPageSize}
+			if v := req.GetString("before", ""); v != "" {
+				converted, err := validateAndConvertISO8601(v, "before")
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				opts.Before = converted
+			}
+
+			maxScan := req.GetInt("maxScan", defaultPurgeScan)
+			if maxScan <= 0 {
+				maxScan = defaultPurgeScan
+			}
+			if maxScan > purgeScanCap {
+				maxScan = purgeScanCap
+			}
+
+			me, err := client.People().GetMe()
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to identify current user: %v", err)), nil
+			}
+
+			page, err := client.Messages().List(opts)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to list messages: %v", err)), nil
+			}
+			scanned, truncated, fetchErr := FetchAll(page.Items, page.HasNext, page.NextPage, client, maxScan)
+
+			var mine []messages.Message
+			for _, msg := range scanned {
+				if msg.PersonID == me.ID {
+					mine = append(mine, msg)
+				}
+			}
+
+			response := map[string]interface{}{
+				"scanned":   len(scanned),
+				"truncated": truncated,
+				"dryRun":    dryRun,
+			}
+			AddCountToMap(response, "matched", len(mine), truncated || fetchErr != nil)
+			AddFetchErrorToMap(response, fetchErr)
+			if truncated {
+				if next := purgeNextBefore(scanned); next != "" {
+					response["nextBefore"] = next
+				}
+			}
+			if dryRun {
+				data, _ := json.MarshalIndent(response, "", "  ")
+				return mcp.NewToolResultText(string(data)), nil
+			}
+
+			deleted := 0
+			failed := make([]map[string]string, 0)
+			for _, msg := range mine {
+				if ctx.Err() != nil {
+					response["cancelled"] = true
+					break
+				}
+				id := msg.ID
+				if dErr := withRetry(ctx, func() error { return deleteMessage(client, id) }); dErr != nil {
+					failed = append(failed, map[string]string{"messageId": id, "error": dErr.Error()})
+					continue
+				}
+				deleted++
+			}
+			log.Printf("[rooms] purged %d of %d own messages in room %s (%d failed)", deleted, len(mine), roomID, len(failed))
+
+			response["deleted"] = deleted
+			response["failed"] = failed
+
+			data, _ := json.MarshalIndent(response, "", "  ")
+			return mcp.NewToolResultText(string(data)), nil
+		},
+	)
+
+	// webex_rooms_membership_breakdown
+	s.AddTool(
+		mcp.NewTool("webex_rooms_membership_breakdown",
+			mcp.WithDescription("Check whether a room/space has external participants by grouping its members into internal and external by organization and email domain.\n"+
+				"\n"+
+				"USE THIS WHEN:\n"+
+				"- 'Does this space have external guests?'\n"+
+				"- Before sharing sensitive content (files, customer data, roadmaps) in a space.\n"+
+				"- Auditing who from outside the company can see a space.\n"+
+				"\n"+
+				"CLASSIFICATION: A member is internal if they belong to your Webex organization or their email domain is an internal domain "+
+				"(your own email domain, the server's configured internal domains, or the internalDomains parameter; subdomains match). Everyone else is external.\n"+
+				"\n"+
+				"RESPONSE:\n"+
+				"- hasExternalMembers: true if anyone external is in the space (with a warning message to surface to the user). "+
+				"null (unknown, with a warning) if the member list could not be read in full (truncated or a page failed) and no externals were found in the part that was read.\n"+
+				"- summary: total, internal, and external member counts.\n"+
+				"- domains: Member count per email domain, flagged internal or external.\n"+
+				"- externalMembers: Every external participant with displayName, email, domain, and moderator status.\n"+
+				"\n"+
+				"IMPORTANT: If hasExternalMembers is true, tell the user clearly before they share anything sensitive in this space."),
+			mcp.WithString("roomId", mcp.Required(), mcp.Description("The ID of the room to check. Get this from webex_rooms_list.")),
+			mcp.WithString("internalDomains", mcp.Description("Comma-separated extra email domains to treat as internal (e.g. 'example.com, example.co.uk'), in addition to your own domain and any server-configured domains.")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			client, err := resolver(ctx)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Auth error: %v", err)), nil
+			}
+
+			roomID, err := req.RequireString("roomId")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			me, err := client.People().GetMe()
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to identify current user: %v", err)), nil
+			}
+
+			domains := append([]string{}, internalDomains...)
+			for _, email := range me.Emails {
+				domains = append(domains, emailDomain(email))
+			}
+			domains = append(domains, parseCSV(req.GetString("internalDomains", ""))...)
+
+			page, err := client.Memberships().List(&memberships.ListOptions{RoomID: roomID, Max: CatalogPageSize})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to list memberships: %v", err)), nil
+			}
+			members, truncated, fetchErr := FetchAll(page.Items, page.HasNext, page.NextPage, client, membershipBreakdownCap)
+
+			breakdown := breakdownMembership(members, me.OrgID, domains)
+
+			// An incomplete member list can only prove that externals exist, never
+			// that there are none.
+			incomplete := truncated || fetchErr != nil
+			var hasExternal interface{} = breakdown.External > 0
+			if incomplete && breakdown.External == 0 {
+				hasExternal = nil
+			}
+
+			response := map[string]interface{}{
+				"hasExternalMembers": hasExternal,
+				"summary": map[string]interface{}{
+					"total":    len(members),
+					"internal": breakdown.Internal,
+					"external": breakdown.External,
+				},
+				"internalDomains": breakdown.InternalDomains,
+				"domains":         breakdown.Domains,
+				"externalMembers": breakdown.ExternalMembers,
+				"truncated":       truncated,
+			}
+			AddFetchErrorToMap(response, fetchErr)
+			if breakdown.External > 0 {
+				response["warning"] = fmt.Sprintf("This space has %d external participant(s) from outside your organization. Anything shared here is visible to them.", breakdown.External)
+			} else if incomplete {
+				response["warning"] = fmt.Sprintf("Only %d members could be checked and none were external, but the rest of the member list was not read. It is unknown whether this space has external participants; treat it as if it might.", len(members))
+			}
+			if roomInfo := resolveRoomInfo(client, roomID); roomInfo != nil {
+				response["room"] = roomInfo
+			}
+
+			data, _ := json.MarshalIndent(response, "", "  ")
+			return mcp.NewToolResultText(string(data)), nil
+		},
+	)
+
+	// webex_rooms_digest
+	s.AddTool(
+		mcp.NewTool("webex_rooms_digest",
+			mcp.WithDescription("Build a catch-up digest of a room/space since a given time: who talked, which threads were active, what was posted on its own, and how many files were shared.\n"+
+				"\n"+
+				"USE THIS WHEN:\n"+
+				"- 'Catch me up on the project space since Monday.'\n"+
+				"- 'What happened in this room while I was out?'\n"+
+				"- Preparing a weekly summary to post back into a space.\n"+
+				"\n"+
+				"RESPONSE:\n"+
+				"- summary: Message, thread, participant, and file counts for the window.\n"+
+				"- senders: Each participant with their message and file counts, most active first.\n"+
+				"- threads: Threads with activity in the window (most replies first) with the opening message, reply count, participants, and last activity. "+
+				"parentBeforeWindow=true means the thread started before 'since'.\n"+
+				"- standaloneMessages: Messages without replies, newest first, text shortened.\n"+
+				"- truncated: true if maxScan was reached before 'since' -- older messages in the window were not included.\n"+
+				"- partial/fetchError: Set if a page of messages failed to load (not a maxScan limit) -- retry the call. A partial digest is never posted.\n"+
+				"\n"+
+				"POSTING: By default nothing is sent. The recommended flow is to summarize the returned data yourself and, if the user wants it shared, "+
+				"send your summary with webex_messages_create. Set post=true only to post the built-in markdown digest as-is.\n"+
+				"\n"+
+				"IMPORTANT: Confirm with the user before posting a digest -- it is visible to everyone in the target space."),
+			mcp.WithString("roomId", mcp.Required(), mcp.Description("The room/space to digest. Get this from webex_rooms_list.")),
+			mcp.WithString("since", mcp.Required(), mcp.Description("Start of the window (UTC format: '2026-01-05T00:00:00Z'). Messages created at or after this time are included.")),
+			mcp.WithNumber("maxScan", mcp.Description(fmt.Sprintf("Max messages to scan, newest first (default %d, max %d).", defaultDigestScan, digestScanCap))),
+			mcp.WithBoolean("post", mcp.Description("When true, post the built-in markdown digest as a message. Default: false.")),
+			mcp.WithString("postToRoomId", mcp.Description("Room to post the digest in when post=true. Defaults to the digested room.")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			client, err := resolver(ctx)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Auth error: %v", err)), nil
+			}
+
+			roomID, err := req.RequireString("roomId")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sinceRaw, err := req.RequireString("since")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			converted, err := validateAndConvertISO8601(sinceRaw, "since")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			since, _ := time.Parse(time.RFC3339, converted)
+
+			maxScan := req.GetInt("maxScan", defaultDigestScan)
+			if maxScan <= 0 {
+				maxScan = defaultDigestScan
+			}
+			if maxScan > digestScanCap {
+				maxScan = digestScanCap
+			}
+
+			page, err := client.Messages().List(&messages.ListOptions{RoomID: roomID, Max: CatalogPageSize})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to list messages: %v", err)), nil
+			}
+			window, truncated, fetchErr := listMessagesAfter(page.Items, page.HasNext, page.NextPage, client, since, maxScan)
+
+			digest := buildRoomDigest(window)
+			names := NewPersonNameCache(client)
+			for i := range digest.Senders {
+				digest.Senders[i].DisplayName = names.Resolve(digest.Senders[i].PersonID)
+			}
+
+			response := map[string]interface{}{
+				"since": converted,
+				"summary": map[string]interface{}{
+					"messages":     digest.Messages,
+					"threads":      digest.ActiveThreads,
+					"participants": len(digest.Senders),
+					"files":        digest.Files,
+				},
+				"senders":            digest.Senders,
+				"threads":            digest.Threads,
+				"standaloneMessages": digest.Standalone,
+				"truncated":          truncated,
+			}
+			AddFetchErrorToMap(response, fetchErr)
+			roomInfo := resolveRoomInfo(client, roomID)
+			if roomInfo != nil {
+				response["room"] = roomInfo
+			}
+
+			if req.GetBool("post", false) && fetchErr != nil {
+				response["postSkipped"] = "The digest was not posted because it is incomplete. Retry the call to post a complete digest."
+			} else if req.GetBool("post", false) {
+				target := req.GetString("postToRoomId", roomID)
+				title := roomID
+				if roomInfo != nil && roomInfo.Title != "" {
+					title = roomInfo.Title
+				}
+				posted, pErr := client.Messages().Create(&messages.Message{
+					RoomID:   target,
+					Markdown: renderDigestMarkdown(title, since, digest),
+				})
+				if pErr != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("Failed to post digest: %v", pErr)), nil
+				}
+				response["postedMessageId"] = posted.ID
+			}
+
+			data, _ := json.MarshalIndent(response, "", "  ")
+			return mcp.NewToolResultText(string(data)), nil
+		},
+	)
+
+	// webex_rooms_promote_direct
+	s.AddTool(
+		mcp.NewTool("webex_rooms_promote_direct",
+			mcp.WithDescription("Turn a 1:1 conversation into a new group space: creates the space, adds the other person, and copies the most recent messages into it with attribution.\n"+
+				"\n"+
+				"USE THIS WHEN:\n"+
+				"- 'Turn my DM with Alice into a project space and bring the last 10 messages.'\n"+
+				"- A 1:1 discussion needs to grow into a group conversation.\n"+
+				"\n"+
+				"HOW IT WORKS: Webex cannot move history out of a direct room, so messages are COPIED, not moved. "+
+				"Each copy is posted by you and starts with the original sender's name and time. Attachments are not copied; the copy notes how many files the original had. "+
+				"The original 1:1 conversation is left unchanged.\n"+
+				"\n"+
+				"RESPONSE: room (the new space), addedMember (the other participant, or memberError if adding them failed), copied, skipped (messages with no text), "+
+				"failed (messageId + error for each copy that could not be posted).\n"+
+				"\n"+
+				"IMPORTANT: Confirm the space title and the number of messages to copy with the user before calling -- the copies are visible to everyone added to the space."),
+			mcp.WithString("roomId", mcp.Required(), mcp.Description("The ID of the direct (1:1) room to promote. Find it with webex_rooms_list type='direct'.")),
+			mcp.WithString("title", mcp.Required(), mcp.Description("Title for the new group space.")),
+			mcp.WithNumber("messageCount", mcp.Description(fmt.Sprintf("How many of the most recent messages to copy (default %d, max %d, 0 copies nothing).", defaultPromoteMessages, promoteMessagesCap))),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			client, err := resolver(ctx)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Auth error: %v", err)), nil
+			}
+
+			roomID, err := req.RequireString("roomId")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			title, err := req.RequireString("title")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			title = strings.TrimSpace(title)
+			if title == "" {
+				return mcp.NewToolResultError("title must not be empty"), nil
+			}
+			count := req.GetInt("messageCount", defaultPromoteMessages)
+			if count < 0 {
+				count = 0
+			}
+			if count > promoteMessagesCap {
+				count = promoteMessagesCap
+			}
+
+			direct, err := client.Rooms().Get(roomID)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to get room: %v", err)), nil
+			}
+			if direct.Type != "direct" {
+				return mcp.NewToolResultError(fmt.Sprintf("Room %q is a %s space, not a 1:1 conversation; only direct rooms can be promoted", direct.Title, direct.Type)), nil
+			}
+
+			me, err := client.People().GetMe()
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to identify current user: %v", err)), nil
+			}
+			memberPage, err := client.Memberships().List(&memberships.ListOptions{RoomID: roomID})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to list memberships: %v", err)), nil
+			}
+			other, ok := otherParticipant(memberPage.Items, me.ID)
+			if !ok {
+				return mcp.NewToolResultError("Could not find the other participant of this 1:1 conversation"), nil
+			}
+
+			var history []messages.Message
+			if count > 0 {
+				page, lErr := client.Messages().List(&messages.ListOptions{RoomID: roomID, Max: count})
+				if lErr != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("Failed to list messages: %v", lErr)), nil
+				}
+				history = page.Items
+				if len(history) > count {
+					history = history[:count]
+				}
+				sortMessagesChronologically(history)
+			}
+
+			room, err := client.Rooms().Create(&rooms.Room{Title: title})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to create room: %v", err)), nil
+			}
+
+			response := map[string]interface{}{
+				"room":         room,
+				"fromRoomId":   roomID,
+				"copiedNotice": "Messages were copied, not moved: each copy is posted by you with the original sender and time. The original 1:1 conversation is unchanged.",
+			}
+
+			if _, mErr := client.Memberships().Create(&memberships.Membership{RoomID: room.ID, PersonID: other.PersonID}); mErr != nil {
+				response["memberError"] = fmt.Sprintf("Failed to add %s: %v", other.PersonEmail, mErr)
+			} else {
+				response["addedMember"] = map[string]string{
+					"personId":    other.PersonID,
+					"personEmail": other.PersonEmail,
+					"displayName": other.PersonDisplayName,
+				}
+			}
+
+			names := NewPersonNameCache(client)
+			copied, skipped := 0, 0
+			failed := make([]map[string]string, 0)
+			if len(history) > 0 {
+				intro := fmt.Sprintf("_Continued from a 1:1 conversation between %s and %s. The last %d messages are copied below._", me.DisplayName, other.PersonDisplayName, len(history))
+				if iErr := withRetry(ctx, func() error {
+					_, cErr := client.Messages().Create(&messages.Message{RoomID: room.ID, Markdown: intro})
+					return cErr
+				}); iErr != nil {
+					log.Printf("[rooms] failed to post promote intro in room %s: %v", room.ID, iErr)
+				}
+			}
+			for _, msg := range history {
+				body := formatCopiedMessage(msg, names.Resolve(msg.PersonID))
+				if body == "" {
+					skipped++
+					continue
+				}
+				if cErr := withRetry(ctx, func() error {
+					_, err := client.Messages().Create(&messages.Message{RoomID: room.ID, Markdown: body})
+					return err
+				}); cErr != nil {
+					failed = append(failed, map[string]string{"messageId": msg.ID, "error": cErr.Error()})
+					continue
+				}
+				copied++
+			}
+			log.Printf("[rooms] promoted direct room %s to %s (%d copied, %d failed)", roomID, room.ID, copied, len(failed))
+
+			response["copied"] = copied
+			response["skipped"] = skipped
+			response["failed"] = failed
+
+			data, _ := json.MarshalIndent(response, "", "  ")
+			return mcp.NewToolResultText(string(data)), nil
+		},
+	)
+
+	// webex_rooms_suggest
+	s.AddTool(
+		mcp.NewTool("webex_rooms_suggest",
+			mcp.WithDescription("Suggest which room/space best fits a topic, ranked, WITHOUT sending anything. Matches the topic against room titles and a small sample of recent messages in the most recently active rooms.\n"+
+				"\n"+
+				"USE THIS WHEN:\n"+
+				"- The user says 'tell the deployment channel' or 'post this where the release team hangs out' without a room name or ID.\n"+
+				"- webex_messages_create with roomName found no exact title match.\n"+
+				"\n"+
+				"RESPONSE: candidates ranked by score, each with roomId, title, type, lastActivity, titleMatches (query words found in the title), "+
+				"messageMatches (sampled recent messages mentioning the topic), and sampleMessage (the most recent matching message, shortened). "+
+				"Title matches weigh more than message matches. 'scanned' reports how many rooms and messages were searched.\n"+
+				"\n"+
+				"IMPORTANT: This tool never sends. Confirm the room with the user (show the top candidates when scores are close), then post with webex_messages_create using the chosen roomId."),
+			mcp.WithString("query", mcp.Required(), mcp.Description("The topic or channel description, e.g. 'deployment', 'release planning', 'on-call alerts'.")),
+			mcp.WithNumber("maxResults", mcp.Description(fmt.Sprintf("Max candidates to return (default %d, max %d).", defaultSuggestResults, suggestResultsCap))),
+			mcp.WithNumber("sampleRooms", mcp.Description(fmt.Sprintf("How many of the most recently active rooms to sample messages from (default %d, max %d). Titles of up to %d rooms are always checked.", defaultSuggestSampleRooms, suggestSampleRoomsCap, suggestRoomScanCap))),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			client, err := resolver(ctx)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Auth error: %v", err)), nil
+			}
+
+			query, err := req.RequireString("query")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			terms := suggestQueryTerms(query)
+			if len(terms) == 0 {
+				return mcp.NewToolResultError(fmt.Sprintf("query %q has no searchable words; describe the topic, e.g. 'deployment'", query)), nil
+			}
+
+			maxResults := req.GetInt("maxResults", defaultSuggestResults)
+			if maxResults <= 0 {
+				maxResults = defaultSuggestResults
+			}
+			if maxResults > suggestResultsCap {
+				maxResults = suggestResultsCap
+			}
+			sampleRooms := req.GetInt("sampleRooms", defaultSuggestSampleRooms)
+			if sampleRooms < 0 {
+				sampleRooms = 0
+			}
+			if sampleRooms > suggestSampleRoomsCap {
+				sampleRooms = suggestSampleRoomsCap
+			}
+
+			page, err := client.Rooms().List(&rooms.ListOptions{SortBy: "lastactivity", Max: CatalogPageSize})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to list rooms: %v", err)), nil
+			}
+			roomItems, truncated, fetchErr := FetchAll(page.Items, page.HasNext, page.NextPage, client, suggestRoomScanCap)
+
+			if sampleRooms > len(roomItems) {
+				sampleRooms = len(roomItems)
+			}
+			samples := sampleRecentMessages(client, roomItems[:sampleRooms])
+
+			var candidates []roomSuggestion
+			sampled := 0
+			for i, r := range roomItems {
+				var texts []string
+				if i < len(samples) {
+					texts = samples[i]
+					sampled += len(texts)
+				}
+				if c, ok := scoreRoomSuggestion(r, terms, texts); ok {
+					candidates = append(candidates, c)
+				}
+			}
+			sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].Score > candidates[j].Score })
+			if len(candidates) > maxResults {
+				candidates = candidates[:maxResults]
+			}
+
+			response := map[string]interface{}{
+				"query":      query,
+				"terms":      terms,
+				"candidates": candidates,
+				"count":      len(candidates),
+				"scanned": map[string]interface{}{
+					"rooms":           len(roomItems),
+					"roomsTruncated":  truncated,
+					"roomsSampled":    sampleRooms,
+					"messagesSampled": sampled,
+				},
+				"sent": false,
+			}
+			AddFetchErrorToMap(response, fetchErr)
+			if len(candidates) == 0 {
+				response["message"] = "No room matched. Ask the user for the room name, or use webex_rooms_catalog to browse."
+			}
+
+			data, _ := json.MarshalIndent(response, "", "  ")
+			return mcp.NewToolResultText(string(data)), nil
+		},
+	)
+}
+
+const (
+	// defaultPurgeScan is how many messages webex_rooms_purge_my_messages scans by default.
+	defaultPurgeScan = 1000
+
+	// purgeScanCap is the maximum number of messages webex_rooms_purge_my_messages scans per call.
+	purgeScanCap = 5000
+)
+
+// membershipBreakdownCap is the maximum number of members webex_rooms_membership_breakdown examines.
+const membershipBreakdownCap = 5000
+
+// internalDomains are the server-configured email domains treated as internal by
+// webex_rooms_membership_breakdown. Set once at startup via SetInternalDomains.
+var internalDomains []string
+
+// SetInternalDomains configures the email domains (comma-separated) that are always
+// treated as internal when classifying room members.
+func SetInternalDomains(raw string) {
+	internalDomains = nil
+	for _, d := range parseCSV(raw) {
+		if d = normalizeDomain(d); d != "" {
+			internalDomains = append(internalDomains, d)
+		}
+	}
+}
+
+// domainCount is the number of room members with a given email domain.
+type domainCount struct {
+	Domain   string `json:"domain"`
+	Count    int    `json:"count"`
+	Internal bool   `json:"internal"`
+}
+
+// membershipBreakdown is a room's members classified as internal or external.
+type membershipBreakdown struct {
+	Internal        int
+	External        int
+	InternalDomains []string
+	Domains         []domainCount
+	ExternalMembers []map[string]interface{}
+}
+
+// breakdownMembership classifies members as internal (same org as orgID, or an email
+// domain under one of domains) or external, and counts members per email domain.
+func breakdownMembership(members []memberships.Membership, orgID string, domains []string) membershipBreakdown {
+	var b membershipBreakdown
+	seen := make(map[string]bool)
+	for _, d := range domains {
+		if d = normalizeDomain(d); d != "" && !seen[d] {
+			seen[d] = true
+			b.InternalDomains = append(b.InternalDomains, d)
+		}
+	}
+	sort.Strings(b.InternalDomains)
+
+	counts := make(map[string]*domainCount)
+	b.ExternalMembers = make([]map[string]interface{}, 0)
+	for _, m := range members {
+		domain := emailDomain(m.PersonEmail)
+		internal := (orgID != "" && m.PersonOrgID == orgID) || domainIsInternal(domain, b.InternalDomains)
+
+		dc, ok := counts[domain]
+		if !ok {
+			dc = &domainCount{Domain: domain, Internal: true}
+			counts[domain] = dc
+		}
+		dc.Count++
+
+		if internal {
+			b.Internal++
+			continue
+		}
+		dc.Internal = false
+		b.External++
+		b.ExternalMembers = append(b.ExternalMembers, map[string]interface{}{
+			"displayName": m.PersonDisplayName,
+			"email":       m.PersonEmail,
+			"domain":      domain,
+			"isModerator": m.IsModerator,
+		})
+	}
+
+	for _, dc := range counts {
+		b.Domains = append(b.Domains, *dc)
+	}
+	sort.Slice(b.Domains, func(i, j int) bool {
+		if b.Domains[i].Count != b.Domains[j].Count {
+			return b.Domains[i].Count > b.Domains[j].Count
+		}
+		return b.Domains[i].Domain < b.Domains[j].Domain
+	})
+	return b
+}
+
+// domainIsInternal reports whether domain equals or is a subdomain of one of internal.
+func domainIsInternal(domain string, internal []string) bool {
+	for _, d := range internal {
+		if domain == d || strings.HasSuffix(domain, "."+d) {
+			return true
+		}
+	}
+	return false
+}
+
+// emailDomain returns the lowercase domain part of an email address, or "" if there is none.
+func emailDomain(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return ""
+	}
+	return normalizeDomain(email[at+1:])
+}
+
+// normalizeDomain lowercases a domain and strips whitespace, a leading '@', and a trailing dot.
+func normalizeDomain(d string) string {
+	d = strings.ToLower(strings.TrimSpace(d))
+	d = strings.TrimPrefix(d, "@")
+	return strings.TrimSuffix(d, ".")
+}
+
+// deleteMessage deletes a single message, returning a typed webexsdk error on
+// failure so callers can tell rate limits and server errors apart.
+func deleteMessage(client *webex.WebexClient, messageID string) error {
+	resp, err := client.Core().Request(http.MethodDelete, "messages/"+messageID, nil, nil)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= 400 {
+		return webexsdk.ParseResponse(resp, nil)
+	}
+	_ = resp.Body.Close()
+	return nil
+}
+
+// purgeNextBefore returns the before cursor that continues a purge scan past
+// scanned (listed newest first): the oldest message's created time, rounded up
+// to the whole second the before parameter accepts. Rounding up can rescan a
+// few messages from that second but never skips one.
+func purgeNextBefore(scanned []messages.Message) string {
+	for i := len(scanned) - 1; i >= 0; i-- {
+		if created := scanned[i].Created; created != nil {
+			next := created.UTC().Truncate(time.Second)
+			if next.Before(created.UTC()) {
+				next = next.Add(time.Second)
+			}
+			return next.Format("2006-01-02T15:04:05Z")
+		}
+	}
+	return ""
+}
+
+// roomSettings extends rooms.Room with settings the SDK does not model.
+type roomSettings struct {
+	rooms.Room
+	IsAnnouncementOnly bool `json:"isAnnouncementOnly,omitempty"`
+}
+
+// getRoomSettings fetches a room directly from the API so that fields missing from
+// rooms.Room (such as isAnnouncementOnly) are available.
+func getRoomSettings(client *webex.WebexClient, roomID string) (*roomSettings, error) {
+	resp, err := client.Core().Request(http.MethodGet, "rooms/"+roomID, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	var settings roomSettings
+	if err := webexsdk.ParseResponse(resp, &settings); err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// listMessagesSince pages backwards from the first page of a room's messages (newest
+// first) until it reaches sinceID (inclusive) or maxResults messages have been scanned.
+// found reports whether sinceID was reached, i.e. whether the returned window is complete.
+// If a later page fails to load, err is set and window holds what was read before it,
+// as with FetchAll.
+func listMessagesSince(items []messages.Message, hasNext bool, nextURL string, client *webex.WebexClient, sinceID string, maxResults int) (window []messages.Message, found bool, err error) {
+	for {
+		for _, msg := range items {
+			if len(window) >= maxResults {
+				return window, false, nil
+			}
+			window = append(window, msg)
+			if msg.ID == sinceID {
+				return window, true, nil
+			}
+		}
+		if !hasNext || nextURL == "" {
+			return window, false, nil
+		}
+		next, pErr := FetchPage(client, nextURL)
+		if pErr != nil {
+			log.Printf("[WatchChanges] failed to fetch page: %v", pErr)
+			return window, false, fmt.Errorf("failed to fetch page: %w", pErr)
+		}
+		if items, err = UnmarshalPageItems[messages.Message](next); err != nil {
+			log.Printf("[WatchChanges] failed to unmarshal page: %v", err)
+			return window, false, fmt.Errorf("failed to parse page: %w", err)
+		}
+		hasNext, nextURL = next.HasNext, next.NextPage
+	}
+}
+
+// messageChanges is the result of comparing a message window with a prior state.
+type messageChanges struct {
+	New     []messages.Message
+	Edited  []messages.Message
+	Deleted []string
+	State   map[string]string // messageId -> updated timestamp ("" if never edited)
+}
+
+// diffMessages compares the current message window against a prior {messageId: updated} state.
+// Deleted IDs are only reported when the window is complete, since a missing ID could otherwise
+// simply lie beyond the scanned range.
+func diffMessages(prior map[string]string, window []messages.Message, sinceID string, complete bool) messageChanges {
+	changes := messageChanges{
+		New:     []messages.Message{},
+		Edited:  []messages.Message{},
+		Deleted: []string{},
+		State:   make(map[string]string, len(window)),
+	}
+
+	for _, msg := range window {
+		updated := ""
+		if msg.Updated != nil {
+			updated = msg.Updated.UTC().Format(time.RFC3339Nano)
+		}
+		changes.State[msg.ID] = updated
+
+		prevUpdated, known := prior[msg.ID]
+		switch {
+		case !known && msg.ID != sinceID:
+			changes.New = append(changes.New, msg)
+		case known && updated != prevUpdated:
+			changes.Edited = append(changes.Edited, msg)
+		}
+	}
+
+	for id, prevUpdated := range prior {
+		if _, ok := changes.State[id]; ok {
+			continue
+		}
+		if complete {
+			changes.Deleted = append(changes.Deleted, id)
+		} else {
+			// Not scanned this time; carry it forward rather than losing track of it
+			changes.State[id] = prevUpdated
+		}
+	}
+	sort.Strings(changes.Deleted)
+
+	// Webex lists newest first; report new messages oldest first so they read in order
+	for i, j := 0, len(changes.New)-1; i < j; i, j = i+1, j-1 {
+		changes.New[i], changes.New[j] = changes.New[j], changes.New[i]
+	}
+
+	return changes
 }
 
 const roomEnrichConcurrency = 5
@@ -334,10 +1401,12 @@ func enrichRoomsConcurrently(client *webex.WebexClient, roomItems []rooms.Room,
 				}
 			}
 
+			// One page per room keeps listing cheap; larger rooms get a lower-bound count
 			if memberPage, mErr := client.Memberships().List(&memberships.ListOptions{
 				RoomID: r.ID,
+				Max:    CatalogPageSize,
 			}); mErr == nil {
-				er["memberCount"] = len(memberPage.Items)
+				AddCountToMap(er, "memberCount", len(memberPage.Items), memberPage.HasNext)
 			}
 
 			if msgPage, mErr := client.Messages().List(&messages.ListOptions{
@@ -362,3 +1431,387 @@ func enrichRoomsConcurrently(client *webex.WebexClient, roomItems []rooms.Room,
 
 	wg.Wait()
 }
+
+const (
+	// defaultDigestScan is how many messages webex_rooms_digest scans by default.
+	defaultDigestScan = 500
+
+	// digestScanCap is the maximum number of messages webex_rooms_digest scans per call.
+	digestScanCap = 2000
+
+	// digestThreadLimit and digestStandaloneLimit bound the lists in a digest.
+	digestThreadLimit     = 20
+	digestStandaloneLimit = 30
+
+	// digestPreviewLength is the maximum length, in characters, of message text in a digest.
+	digestPreviewLength = 280
+)
+
+// digestSender is one participant's activity in a room digest.
+type digestSender struct {
+	PersonID    string `json:"personId"`
+	PersonEmail string `json:"personEmail,omitempty"`
+	DisplayName string `json:"displayName,omitempty"`
+	Messages    int    `json:"messages"`
+	Files       int    `json:"files"`
+}
+
+// digestThread is a thread with activity in the digest window.
+type digestThread struct {
+	ParentID           string     `json:"parentId"`
+	StartedBy          string     `json:"startedBy,omitempty"`
+	Text               string     `json:"text,omitempty"`
+	Replies            int        `json:"replies"`
+	Participants       []string   `json:"participants"`
+	LastActivity       *time.Time `json:"lastActivity,omitempty"`
+	ParentBeforeWindow bool       `json:"parentBeforeWindow,omitempty"`
+}
+
+// digestMessage is a shortened standalone message in a digest.
+type digestMessage struct {
+	ID          string     `json:"id"`
+	PersonEmail string     `json:"personEmail,omitempty"`
+	Text        string     `json:"text,omitempty"`
+	Files       int        `json:"files,omitempty"`
+	Created     *time.Time `json:"created,omitempty"`
+}
+
+// roomDigest groups a window of room messages by sender and thread.
+type roomDigest struct {
+	Messages      int
+	Files         int
+	ActiveThreads int // before digestThreadLimit is applied
+	Senders       []digestSender
+	Threads       []digestThread
+	Standalone    []digestMessage
+}
+
+// listMessagesAfter pages backwards from the first page of a room's messages (newest
+// first), collecting messages created at or after since, up to maxScan. truncated
+// reports whether the scan stopped at maxScan before reaching since. If a later page
+// fails to load, err is set and window holds what was read before it, as with FetchAll.
+func listMessagesAfter(items []messages.Message, hasNext bool, nextURL string, client *webex.WebexClient, since time.Time, maxScan int) (window []messages.Message, truncated bool, err error) {
+	for {
+		for _, msg := range items {
+			if msg.Created != nil && msg.Created.Before(since) {
+				return window, false, nil
+			}
+			if len(window) >= maxScan {
+				countBudgetHit(client)
+				return window, true, nil
+			}
+			window = append(window, msg)
+		}
+		if !hasNext || nextURL == "" {
+			return window, false, nil
+		}
+		next, pErr := FetchPage(client, nextURL)
+		if pErr != nil {
+			log.Printf("[Digest] failed to fetch page: %v", pErr)
+			return window, false, fmt.Errorf("failed to fetch page: %w", pErr)
+		}
+		if items, err = UnmarshalPageItems[messages.Message](next); err != nil {
+			log.Printf("[Digest] failed to unmarshal page: %v", err)
+			return window, false, fmt.Errorf("failed to parse page: %w", err)
+		}
+		hasNext, nextURL = next.HasNext, next.NextPage
+	}
+}
+
+// buildRoomDigest groups messages (newest first, as Webex lists them) into per-sender
+// counts, threads with replies, and standalone messages. Sender names are filled in by the caller.
+func buildRoomDigest(window []messages.Message) roomDigest {
+	digest := roomDigest{Messages: len(window)}
+
+	senderIdx := make(map[string]int)
+	threadIdx := make(map[string]int)
+	parents := make(map[string]messages.Message)
+	for _, msg := range window {
+		if msg.ParentID == "" {
+			parents[msg.ID] = msg
+		}
+	}
+
+	for _, msg := range window {
+		digest.Files += len(msg.Files)
+
+		i, ok := senderIdx[msg.PersonID]
+		if !ok {
+			i = len(digest.Senders)
+			senderIdx[msg.PersonID] = i
+			digest.Senders = append(digest.Senders, digestSender{PersonID: msg.PersonID, PersonEmail: msg.PersonEmail})
+		}
+		digest.Senders[i].Messages++
+		digest.Senders[i].Files += len(msg.Files)
+
+		if msg.ParentID == "" {
+			continue
+		}
+		t, ok := threadIdx[msg.ParentID]
+		if !ok {
+			t = len(digest.Threads)
+			threadIdx[msg.ParentID] = t
+			thread := digestThread{ParentID: msg.ParentID, LastActivity: msg.Created, ParentBeforeWindow: true}
+			if parent, found := parents[msg.ParentID]; found {
+				thread.StartedBy = parent.PersonEmail
+				thread.Text = truncateText(parent.Text, digestPreviewLength)
+				thread.Participants = []string{parent.PersonEmail}
+				thread.ParentBeforeWindow = false
+			}
+			digest.Threads = append(digest.Threads, thread)
+		}
+		digest.Threads[t].Replies++
+		if !slices.Contains(digest.Threads[t].Participants, msg.PersonEmail) {
+			digest.Threads[t].Participants = append(digest.Threads[t].Participants, msg.PersonEmail)
+		}
+	}
+
+	for _, msg := range window {
+		if _, isThread := threadIdx[msg.ID]; msg.ParentID != "" || isThread {
+			continue
+		}
+		digest.Standalone = append(digest.Standalone, digestMessage{
+			ID:          msg.ID,
+			PersonEmail: msg.PersonEmail,
+			Text:        truncateText(msg.Text, digestPreviewLength),
+			Files:       len(msg.Files),
+			Created:     msg.Created,
+		})
+	}
+
+	sort.SliceStable(digest.Senders, func(i, j int) bool { return digest.Senders[i].Messages > digest.Senders[j].Messages })
+	sort.SliceStable(digest.Threads, func(i, j int) bool { return digest.Threads[i].Replies > digest.Threads[j].Replies })
+	digest.ActiveThreads = len(digest.Threads)
+	if len(digest.Threads) > digestThreadLimit {
+		digest.Threads = digest.Threads[:digestThreadLimit]
+	}
+	if len(digest.Standalone) > digestStandaloneLimit {
+		digest.Standalone = digest.Standalone[:digestStandaloneLimit]
+	}
+	if digest.Senders == nil {
+		digest.Senders = []digestSender{}
+	}
+	if digest.Threads == nil {
+		digest.Threads = []digestThread{}
+	}
+	if digest.Standalone == nil {
+		digest.Standalone = []digestMessage{}
+	}
+	return digest
+}
+
+// renderDigestMarkdown formats a digest as a Webex markdown message.
+func renderDigestMarkdown(roomTitle string, since time.Time, digest roomDigest) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "**Digest for %s since %s**\n\n", roomTitle, since.UTC().Format("Mon Jan 2 15:04 UTC"))
+	fmt.Fprintf(&b, "%d messages from %d people, %d active threads, %d files shared.\n", digest.Messages, len(digest.Senders), digest.ActiveThreads, digest.Files)
+
+	if len(digest.Senders) > 0 {
+		b.WriteString("\n**Most active**\n")
+		for i, s := range digest.Senders {
+			if i == 5 {
+				break
+			}
+			name := s.DisplayName
+			if name == "" {
+				name = s.PersonEmail
+			}
+			fmt.Fprintf(&b, "- %s: %d messages\n", name, s.Messages)
+		}
+	}
+	if len(digest.Threads) > 0 {
+		b.WriteString("\n**Threads**\n")
+		for _, t := range digest.Threads {
+			text := t.Text
+			if text == "" {
+				text = "(thread started earlier)"
+			}
+			fmt.Fprintf(&b, "- %s -- %d replies\n", truncateText(text, 100), t.Replies)
+		}
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// truncateText shortens s to at most n characters, adding an ellipsis when cut.
+func truncateText(s string, n int) string {
+	s = strings.TrimSpace(s)
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return strings.TrimSpace(string(r[:n])) + "…"
+}
+
+const (
+	// defaultPromoteMessages is how many messages webex_rooms_promote_direct copies by default.
+	defaultPromoteMessages = 10
+
+	// promoteMessagesCap is the maximum number of messages webex_rooms_promote_direct copies.
+	promoteMessagesCap = 50
+)
+
+// otherParticipant returns the member of a direct room who is not myID.
+func otherParticipant(members []memberships.Membership, myID string) (memberships.Membership, bool) {
+	for _, m := range members {
+		if m.PersonID != myID {
+			return m, true
+		}
+	}
+	return memberships.Membership{}, false
+}
+
+// formatCopiedMessage renders a message for reposting in another room, prefixed with
+// the original sender and time. It returns "" when there is nothing to copy.
+func formatCopiedMessage(msg messages.Message, senderName string) string {
+	body := msg.Markdown
+	if body == "" {
+		body = msg.Text
+	}
+	body = strings.TrimSpace(body)
+	if body == "" && len(msg.Files) == 0 {
+		return ""
+	}
+
+	if senderName == "" {
+		senderName = msg.PersonEmail
+	}
+	header := "**" + senderName + "**"
+	if msg.Created != nil {
+		header += " (" + msg.Created.UTC().Format("Jan 2 15:04 UTC") + ")"
+	}
+	if len(msg.Files) > 0 {
+		note := fmt.Sprintf("_[%d attachment(s) not copied]_", len(msg.Files))
+		if body == "" {
+			body = note
+		} else {
+			body += "\n" + note
+		}
+	}
+	return header + ":\n" + body
+}
+
+const (
+	// suggestRoomScanCap is how many rooms (most recently active first) webex_rooms_suggest checks by title.
+	suggestRoomScanCap = 300
+
+	// defaultSuggestSampleRooms and suggestSampleRoomsCap bound how many rooms have their messages sampled.
+	defaultSuggestSampleRooms = 10
+	suggestSampleRoomsCap     = 25
+
+	// suggestMessagesPerRoom is how many recent messages are sampled per room.
+	suggestMessagesPerRoom = 20
+
+	// defaultSuggestResults and suggestResultsCap bound the candidates returned.
+	defaultSuggestResults = 5
+	suggestResultsCap     = 10
+
+	// suggestPreviewLength is the maximum length, in characters, of sampleMessage.
+	suggestPreviewLength = 140
+)
+
+// suggestStopWords are query words that say nothing about the topic.
+var suggestStopWords = map[string]bool{
+	"a": true, "an": true, "the": true, "to": true, "in": true, "of": true, "for": true, "and": true,
+	"or": true, "on": true, "at": true, "with": true, "about": true, "my": true, "our": true, "me": true,
+	"tell": true, "post": true, "send": true, "message": true, "ping": true, "let": true, "know": true,
+	"channel": true, "room": true, "space": true, "chat": true, "group": true, "team": true, "people": true,
+}
+
+// suggestQueryTerms lowercases the query and splits it into distinct words,
+// dropping stop words and single characters.
+func suggestQueryTerms(query string) []string {
+	words := strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var terms []string
+	for _, w := range words {
+		if len([]rune(w)) < 2 || suggestStopWords[w] || slices.Contains(terms, w) {
+			continue
+		}
+		terms = append(terms, w)
+	}
+	return terms
+}
+
+// roomSuggestion is one ranked candidate from webex_rooms_suggest.
+type roomSuggestion struct {
+	RoomID         string     `json:"roomId"`
+	Title          string     `json:"title"`
+	Type           string     `json:"type"`
+	LastActivity   *time.Time `json:"lastActivity,omitempty"`
+	Score          int        `json:"score"`
+	TitleMatches   []string   `json:"titleMatches,omitempty"`
+	MessageMatches int        `json:"messageMatches,omitempty"`
+	SampleMessage  string     `json:"sampleMessage,omitempty"`
+}
+
+// scoreRoomSuggestion scores a room against the query terms. A term that is a whole
+// word of the title scores 3 and one that is part of a word (e.g. "deploy" in
+// "Deployments") scores 2; each sampled message (newest first) mentioning any term
+// scores 1, up to 5. ok is false when nothing matched.
+func scoreRoomSuggestion(r rooms.Room, terms []string, texts []string) (roomSuggestion, bool) {
+	c := roomSuggestion{RoomID: r.ID, Title: r.Title, Type: r.Type, LastActivity: r.LastActivity}
+
+	title := strings.ToLower(r.Title)
+	titleWords := strings.FieldsFunc(title, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, term := range terms {
+		switch {
+		case slices.Contains(titleWords, term):
+			c.Score += 3
+		case strings.Contains(title, term):
+			c.Score += 2
+		default:
+			continue
+		}
+		c.TitleMatches = append(c.TitleMatches, term)
+	}
+
+	for _, text := range texts {
+		lower := strings.ToLower(text)
+		if !slices.ContainsFunc(terms, func(term string) bool { return strings.Contains(lower, term) }) {
+			continue
+		}
+		if c.MessageMatches == 0 {
+			c.SampleMessage = truncateText(text, suggestPreviewLength)
+		}
+		c.MessageMatches++
+	}
+	c.Score += min(c.MessageMatches, 5)
+
+	return c, c.Score > 0
+}
+
+// sampleRecentMessages returns the text of up to suggestMessagesPerRoom recent messages
+// for each room, newest first. Rooms whose messages cannot be listed get none.
+func sampleRecentMessages(client *webex.WebexClient, roomItems []rooms.Room) [][]string {
+	out := make([][]string, len(roomItems))
+	sem := make(chan struct{}, roomEnrichConcurrency)
+	var wg sync.WaitGroup
+
+	for i, room := range roomItems {
+		wg.Add(1)
+		go func(idx int, roomID string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			page, err := client.Messages().List(&messages.ListOptions{RoomID: roomID, Max: suggestMessagesPerRoom})
+			if err != nil {
+				log.Printf("[rooms_suggest] failed to sample messages in room %s: %v", roomID, err)
+				return
+			}
+			texts := make([]string, 0, len(page.Items))
+			for _, m := range page.Items {
+				if m.Text != "" {
+					texts = append(texts, m.Text)
+				}
+			}
+			out[idx] = texts
+		}(i, room.ID)
+	}
+
+	wg.Wait()
+	return out
+}
@@ -0,0 +1,362 @@
+package tools
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	webex "github.com/WebexCommunity/webex-go-sdk/v2"
+	"github.com/WebexCommunity/webex-go-sdk/v2/memberships"
+	"github.com/WebexCommunity/webex-go-sdk/v2/messages"
+	"github.com/WebexCommunity/webex-go-sdk/v2/rooms"
+	"github.com/WebexCommunity/webex-go-sdk/v2/webexsdk"
+)
+
+func TestDiffMessages(t *testing.T) {
+	edited := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	// Newest first, as returned by the Webex API
+	window := []messages.Message{
+		{ID: "m4"},
+		{ID: "m3", Updated: &edited},
+		{ID: "m2"},
+		{ID: "m1"},
+	}
+	prior := map[string]string{
+		"m1":   "",
+		"m2":   "",
+		"m3":   "",
+		"gone": "",
+	}
+
+	got := diffMessages(prior, window, "m1", true)
+
+	if len(got.New) != 1 || got.New[0].ID != "m4" {
+		t.Errorf("New = %+v, want [m4]", got.New)
+	}
+	if len(got.Edited) != 1 || got.Edited[0].ID != "m3" {
+		t.Errorf("Edited = %+v, want [m3]", got.Edited)
+	}
+	if len(got.Deleted) != 1 || got.Deleted[0] != "gone" {
+		t.Errorf("Deleted = %v, want [gone]", got.Deleted)
+	}
+	if got.State["m3"] != edited.Format(time.RFC3339Nano) {
+		t.Errorf("State[m3] = %q, want %q", got.State["m3"], edited.Format(time.RFC3339Nano))
+	}
+
+	// Feeding the state back in reports no changes
+	again := diffMessages(got.State, window, "m1", true)
+	if len(again.New) != 0 || len(again.Edited) != 0 || len(again.Deleted) != 0 {
+		t.Errorf("second diff should be empty, got %+v", again)
+	}
+}
+
+func TestDiffMessages_IncompleteWindow(t *testing.T) {
+	window := []messages.Message{{ID: "m9"}, {ID: "m8"}}
+	prior := map[string]string{"m1": ""}
+
+	got := diffMessages(prior, window, "m1", false)
+
+	if len(got.Deleted) != 0 {
+		t.Errorf("Deleted = %v, want none when window is incomplete", got.Deleted)
+	}
+	if _, ok := got.State["m1"]; !ok {
+		t.Error("unscanned prior entry should be carried forward in State")
+	}
+	if len(got.New) != 2 || got.New[0].ID != "m8" {
+		t.Errorf("New = %+v, want [m8 m9] oldest first", got.New)
+	}
+}
+
+func TestListMessagesSince_PageError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message":"server busy"}`, http.StatusBadRequest)
+	}))
+	defer srv.Close()
+	client, err := webex.NewClient("test-token", &webexsdk.Config{BaseURL: srv.URL})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	first := []messages.Message{{ID: "m3"}, {ID: "m2"}}
+	window, found, err := listMessagesSince(first, true, srv.URL+"/messages?cursor=2", client, "m1", 50)
+	if err == nil || found || len(window) != 2 {
+		t.Errorf("listMessagesSince() = %d msgs, found=%v, err=%v; want the 2 read messages and the page error", len(window), found, err)
+	}
+}
+
+func TestBreakdownMembership(t *testing.T) {
+	members := []memberships.Membership{
+		{PersonEmail: "alice@example.com", PersonOrgID: "org-1"},
+		{PersonEmail: "bob@eng.example.com", PersonOrgID: "org-2"},
+		{PersonEmail: "carol@subsidiary.io", PersonOrgID: "org-1"},
+		{PersonEmail: "dave@partner.com", PersonOrgID: "org-9", PersonDisplayName: "Dave", IsModerator: true},
+		{PersonEmail: "erin@partner.com", PersonOrgID: "org-9"},
+	}
+
+	got := breakdownMembership(members, "org-1", []string{"Example.com", "@example.com"})
+
+	if got.Internal != 3 || got.External != 2 {
+		t.Errorf("internal/external = %d/%d, want 3/2", got.Internal, got.External)
+	}
+	if len(got.InternalDomains) != 1 || got.InternalDomains[0] != "example.com" {
+		t.Errorf("InternalDomains = %v, want [example.com]", got.InternalDomains)
+	}
+	if len(got.Domains) == 0 || got.Domains[0] != (domainCount{Domain: "partner.com", Count: 2, Internal: false}) {
+		t.Errorf("Domains[0] = %+v, want partner.com x2 external", got.Domains)
+	}
+	if len(got.ExternalMembers) != 2 || got.ExternalMembers[0]["displayName"] != "Dave" || got.ExternalMembers[0]["isModerator"] != true {
+		t.Errorf("ExternalMembers = %v", got.ExternalMembers)
+	}
+}
+
+func TestBuildRoomDigest(t *testing.T) {
+	at := func(min int) *time.Time {
+		ts := time.Date(2026, 3, 2, 9, min, 0, 0, time.UTC)
+		return &ts
+	}
+	// Newest first, as returned by the Webex API
+	window := []messages.Message{
+		{ID: "m6", PersonID: "p1", PersonEmail: "alice@example.com", Text: "standalone", Files: []string{"f1", "f2"}, Created: at(6)},
+		{ID: "m5", PersonID: "p2", PersonEmail: "bob@example.com", ParentID: "old", Text: "late reply", Created: at(5)},
+		{ID: "m4", PersonID: "p3", PersonEmail: "carol@example.com", ParentID: "m1", Text: "reply 2", Created: at(4)},
+		{ID: "m3", PersonID: "p2", PersonEmail: "bob@example.com", ParentID: "m1", Text: "reply 1", Files: []string{"f3"}, Created: at(3)},
+		{ID: "m2", PersonID: "p1", PersonEmail: "alice@example.com", Text: "another", Created: at(2)},
+		{ID: "m1", PersonID: "p1", PersonEmail: "alice@example.com", Text: "kickoff", Created: at(1)},
+	}
+
+	d := buildRoomDigest(window)
+
+	if d.Messages != 6 || d.Files != 3 || d.ActiveThreads != 2 {
+		t.Errorf("Messages/Files/ActiveThreads = %d/%d/%d, want 6/3/2", d.Messages, d.Files, d.ActiveThreads)
+	}
+	if len(d.Senders) != 3 || d.Senders[0].PersonID != "p1" || d.Senders[0].Messages != 3 || d.Senders[0].Files != 2 {
+		t.Errorf("Senders = %+v, want alice first with 3 messages and 2 files", d.Senders)
+	}
+
+	if len(d.Threads) != 2 {
+		t.Fatalf("Threads = %+v, want 2", d.Threads)
+	}
+	kickoff := d.Threads[0]
+	if kickoff.ParentID != "m1" || kickoff.Replies != 2 || kickoff.Text != "kickoff" || kickoff.ParentBeforeWindow {
+		t.Errorf("Threads[0] = %+v, want m1 with 2 replies", kickoff)
+	}
+	if len(kickoff.Participants) != 3 {
+		t.Errorf("Threads[0].Participants = %v, want alice, carol and bob", kickoff.Participants)
+	}
+	if old := d.Threads[1]; old.ParentID != "old" || !old.ParentBeforeWindow || old.Replies != 1 {
+		t.Errorf("Threads[1] = %+v, want reply to a parent before the window", old)
+	}
+
+	if len(d.Standalone) != 2 || d.Standalone[0].ID != "m6" || d.Standalone[1].ID != "m2" {
+		t.Errorf("Standalone = %+v, want m6 and m2", d.Standalone)
+	}
+}
+
+func TestPurgeNextBefore(t *testing.T) {
+	newer := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	oldest := time.Date(2026, 3, 2, 9, 30, 0, 250*int(time.Millisecond), time.UTC)
+	whole := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+
+	if got := purgeNextBefore([]messages.Message{{Created: &newer}, {Created: &oldest}}); got != "2026-03-02T09:30:01Z" {
+		t.Errorf("purgeNextBefore(fractional) = %q, want rounded up to 09:30:01", got)
+	}
+	if got := purgeNextBefore([]messages.Message{{Created: &newer}, {Created: &whole}}); got != "2026-03-02T09:00:00Z" {
+		t.Errorf("purgeNextBefore(whole second) = %q", got)
+	}
+	if got := purgeNextBefore(nil); got != "" {
+		t.Errorf("purgeNextBefore(nil) = %q, want empty", got)
+	}
+}
+
+func TestListMessagesAfter_PageError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message":"server busy"}`, http.StatusBadRequest)
+	}))
+	defer srv.Close()
+	client, err := webex.NewClient("test-token", &webexsdk.Config{BaseURL: srv.URL})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	since := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	created := since.Add(time.Hour)
+	first := []messages.Message{{ID: "m2", Created: &created}}
+	// A failed page is an error, not a maxScan limit.
+	window, truncated, err := listMessagesAfter(first, true, srv.URL+"/messages?cursor=2", client, since, 500)
+	if err == nil || truncated || len(window) != 1 {
+		t.Errorf("listMessagesAfter() = %d msgs, truncated=%v, err=%v; want the read message and the page error", len(window), truncated, err)
+	}
+}
+
+func TestTruncateText(t *testing.T) {
+	if got := truncateText("  short  ", 10); got != "short" {
+		t.Errorf("truncateText(short) = %q", got)
+	}
+	if got := truncateText("héllo wörld", 5); got != "héllo…" {
+		t.Errorf("truncateText(long) = %q, want %q", got, "héllo…")
+	}
+}
+
+func TestFormatCopiedMessage(t *testing.T) {
+	created := time.Date(2026, 3, 2, 9, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		msg    messages.Message
+		sender string
+		want   string
+	}{
+		{"text", messages.Message{Text: "hi there", Created: &created}, "Alice", "**Alice** (Mar 2 09:30 UTC):\nhi there"},
+		{"markdown preferred", messages.Message{Text: "plain", Markdown: "**rich**"}, "Alice", "**Alice**:\n**rich**"},
+		{"email fallback", messages.Message{Text: "hi", PersonEmail: "bob@example.com"}, "", "**bob@example.com**:\nhi"},
+		{"file only", messages.Message{Files: []string{"f1", "f2"}}, "Alice", "**Alice**:\n_[2 attachment(s) not copied]_"},
+		{"text and file", messages.Message{Text: "see attached", Files: []string{"f1"}}, "Alice", "**Alice**:\nsee attached\n_[1 attachment(s) not copied]_"},
+		{"empty", messages.Message{Text: "  "}, "Alice", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatCopiedMessage(tt.msg, tt.sender); got != tt.want {
+				t.Errorf("formatCopiedMessage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOtherParticipant(t *testing.T) {
+	members := []memberships.Membership{{PersonID: "me"}, {PersonID: "alice", PersonEmail: "alice@example.com"}}
+	if m, ok := otherParticipant(members, "me"); !ok || m.PersonID != "alice" {
+		t.Errorf("otherParticipant() = %+v, %v; want alice", m, ok)
+	}
+	if _, ok := otherParticipant(members[:1], "me"); ok {
+		t.Error("otherParticipant() found someone in a room with only me")
+	}
+}
+
+func TestSuggestQueryTerms(t *testing.T) {
+	got := suggestQueryTerms("Tell the Deployment channel about deploy-status, deployment!")
+	want := []string{"deployment", "deploy", "status"}
+	if !slices.Equal(got, want) {
+		t.Errorf("suggestQueryTerms() = %v, want %v", got, want)
+	}
+	if got := suggestQueryTerms("the channel"); len(got) != 0 {
+		t.Errorf("suggestQueryTerms(stop words) = %v, want none", got)
+	}
+}
+
+func TestScoreRoomSuggestion(t *testing.T) {
+	terms := []string{"deploy", "prod"}
+	tests := []struct {
+		name        string
+		title       string
+		texts       []string
+		wantScore   int
+		wantOK      bool
+		wantMatches int
+	}{
+		{"whole word in title", "Deploy Alerts", nil, 3, true, 0},
+		{"partial word in title", "Deployments", nil, 2, true, 0},
+		{"both terms", "prod deploy", nil, 6, true, 0},
+		{"messages only", "Team Chat", []string{"deploying to prod now", "lunch?", "Deploy done"}, 2, true, 2},
+		{"message score capped", "Ops", []string{"deploy", "deploy", "deploy", "deploy", "deploy", "deploy", "deploy"}, 5, true, 7},
+		{"no match", "Random", []string{"hello"}, 0, false, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, ok := scoreRoomSuggestion(rooms.Room{ID: "r1", Title: tt.title}, terms, tt.texts)
+			if ok != tt.wantOK || c.Score != tt.wantScore || c.MessageMatches != tt.wantMatches {
+				t.Errorf("score = %d ok = %v matches = %d, want %d %v %d", c.Score, ok, c.MessageMatches, tt.wantScore, tt.wantOK, tt.wantMatches)
+			}
+		})
+	}
+
+	c, _ := scoreRoomSuggestion(rooms.Room{Title: "Team Chat"}, terms, []string{"deploying to prod now", "older deploy"})
+	if c.SampleMessage != "deploying to prod now" {
+		t.Errorf("SampleMessage = %q, want the newest match", c.SampleMessage)
+	}
+}
+
+// fakeModerationServer serves GET and PUT /rooms/r1 via newCaptureServer. PUT bodies
+// are decoded into *put; putStatus, if set, is returned for the PUT instead of the updated room.
+func fakeModerationServer(t *testing.T, put *map[string]interface{}, putStatus int) *httptest.Server {
+	t.Helper()
+	return newCaptureServer(t, "/rooms/r1", `{"id":"r1","title":"Launch Plan","type":"group","isLocked":true,"isAnnouncementOnly":true}`, put,
+		func(map[string]interface{}) (int, interface{}) {
+			if putStatus != 0 {
+				return putStatus, map[string]string{"message": "User is not a moderator of the room"}
+			}
+			return http.StatusOK, map[string]interface{}{"id": "r1", "title": "Launch Plan", "type": "group", "isLocked": false}
+		})
+}
+
+func TestRoomsSetModeratedPutBody(t *testing.T) {
+	var put map[string]interface{}
+	srv := fakeModerationServer(t, &put, 0)
+	defer srv.Close()
+
+	result := callTool(t, RegisterRoomTools, srv.URL, "webex_rooms_set_moderated", map[string]interface{}{
+		"roomId":           "r1",
+		"moderated":        false,
+		"announcementOnly": true, // ignored when unlocking
+	})
+	if result.IsError {
+		t.Fatalf("set_moderated failed: %s", resultText(result))
+	}
+	want := map[string]interface{}{"title": "Launch Plan", "isLocked": false, "isAnnouncementOnly": false}
+	if !reflect.DeepEqual(put, want) {
+		t.Errorf("PUT body = %v, want %v", put, want)
+	}
+}
+
+func TestRoomsSetModeratedForbidden(t *testing.T) {
+	var put map[string]interface{}
+	srv := fakeModerationServer(t, &put, http.StatusForbidden)
+	defer srv.Close()
+
+	result := callTool(t, RegisterRoomTools, srv.URL, "webex_rooms_set_moderated", map[string]interface{}{
+		"roomId":    "r1",
+		"moderated": false,
+	})
+	if !result.IsError || !strings.Contains(resultText(result), "you must be a moderator") {
+		t.Errorf("result = %q, want the not-a-moderator error", resultText(result))
+	}
+}
+
+func TestRoomsMembershipBreakdownIncomplete(t *testing.T) {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/people/me":
+			w.Write([]byte(`{"id":"me","orgId":"org-1","emails":["me@example.com"]}`))
+		case r.URL.Path == "/memberships" && r.URL.Query().Get("cursor") != "":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"message":"server busy"}`))
+		case r.URL.Path == "/memberships":
+			w.Header().Set("Link", "<"+srv.URL+"/memberships?cursor=2>; rel=\"next\"")
+			w.Write([]byte(`{"items":[{"personId":"me","personEmail":"me@example.com","personOrgId":"org-1"}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	result := callTool(t, RegisterRoomTools, srv.URL, "webex_rooms_membership_breakdown", map[string]interface{}{"roomId": "r1"})
+	if result.IsError {
+		t.Fatalf("membership_breakdown failed: %s", resultText(result))
+	}
+	var got map[string]interface{}
+	if err := json.Unmarshal([]byte(resultText(result)), &got); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	// Only internal members were read before the page failed: that proves nothing.
+	if v, ok := got["hasExternalMembers"]; !ok || v != nil {
+		t.Errorf("hasExternalMembers = %v (present %v), want null", v, ok)
+	}
+	if got["warning"] == nil || got["partial"] != true {
+		t.Errorf("response = %v, want a warning and partial=true", got)
+	}
+}
@@ -0,0 +1,174 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/WebexCommunity/webex-go-sdk/v2/roomtabs"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/tejzpr/webex-go-mcp/auth"
+)
+
+// RegisterRoomTabTools registers the room tab (pinned URL) MCP tools.
+func RegisterRoomTabTools(s ToolRegistrar, resolver auth.ClientResolver) {
+	// webex_room_tabs_list
+	s.AddTool(
+		mcp.NewTool("webex_room_tabs_list",
+			mcp.WithDescription("List the tabs pinned to a Webex room/space. A tab is a URL (dashboard, doc, app) shown as a tab at the top of the space.\n"+
+				"\n"+
+				"USE THIS WHEN:\n"+
+				"- 'What's pinned in this space?'\n"+
+				"- Before webex_room_tabs_create, to avoid pinning the same URL twice.\n"+
+				"- To find the tabId for webex_room_tabs_delete.\n"+
+				"\n"+
+				"RESPONSE: Each tab includes its id, displayName, contentUrl, created time, and creatorName (who pinned it), plus the room title."),
+			mcp.WithString("roomId", mcp.Required(), mcp.Description("The ID of the room whose tabs to list. Get this from webex_rooms_list.")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			client, err := resolver(ctx)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Auth error: %v", err)), nil
+			}
+
+			roomID, err := req.RequireString("roomId")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			page, err := client.RoomTabs().List(&roomtabs.ListOptions{RoomID: roomID})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to list room tabs: %v", err)), nil
+			}
+
+			names := NewPersonNameCache(client)
+			tabs := make([]map[string]interface{}, 0, len(page.Items))
+			for _, tab := range page.Items {
+				et := map[string]interface{}{
+					"id":          tab.ID,
+					"displayName": tab.DisplayName,
+					"contentUrl":  tab.ContentURL,
+					"creatorId":   tab.CreatorID,
+				}
+				if tab.Created != nil {
+					et["created"] = tab.Created
+				}
+				if name := names.Resolve(tab.CreatorID); name != "" {
+					et["creatorName"] = name
+				}
+				tabs = append(tabs, et)
+			}
+
+			response := map[string]interface{}{
+				"tabs":  tabs,
+				"count": len(tabs),
+			}
+			if roomInfo := resolveRoomInfo(client, roomID); roomInfo != nil {
+				response["room"] = roomInfo
+			}
+
+			data, _ := json.MarshalIndent(response, "", "  ")
+			return mcp.NewToolResultText(string(data)), nil
+		},
+	)
+
+	// webex_room_tabs_create
+	s.AddTool(
+		mcp.NewTool("webex_room_tabs_create",
+			mcp.WithDescription("Pin a URL as a tab in a Webex room/space, e.g. a dashboard, design doc, or project board.\n"+
+				"\n"+
+				"EXAMPLE: roomId + contentUrl='https://dashboards.example.com/release' + displayName='Release Dashboard'.\n"+
+				"\n"+
+				"NOTE: The tab is visible to everyone in the space. Use webex_room_tabs_list first to avoid duplicates.\n"+
+				"\n"+
+				"IMPORTANT: Confirm the URL and tab name with the user before pinning."),
+			mcp.WithString("roomId", mcp.Required(), mcp.Description("The ID of the room to pin the tab in. Get this from webex_rooms_list.")),
+			mcp.WithString("contentUrl", mcp.Required(), mcp.Description("The absolute https URL to show in the tab.")),
+			mcp.WithString("displayName", mcp.Required(), mcp.Description("The tab label shown in the space (e.g. 'Release Dashboard').")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			client, err := resolver(ctx)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Auth error: %v", err)), nil
+			}
+
+			roomID, err := req.RequireString("roomId")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			contentURL, err := req.RequireString("contentUrl")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			displayName, err := req.RequireString("displayName")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			contentURL = strings.TrimSpace(contentURL)
+			if err := validateTabContentURL(contentURL); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			displayName = strings.TrimSpace(displayName)
+			if displayName == "" {
+				return mcp.NewToolResultError("displayName must not be empty"), nil
+			}
+
+			tab, err := client.RoomTabs().Create(&roomtabs.RoomTab{
+				RoomID:      roomID,
+				ContentURL:  contentURL,
+				DisplayName: displayName,
+			})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to create room tab: %v", err)), nil
+			}
+
+			data, _ := json.MarshalIndent(tab, "", "  ")
+			return mcp.NewToolResultText(string(data)), nil
+		},
+	)
+
+	// webex_room_tabs_delete
+	s.AddTool(
+		mcp.NewTool("webex_room_tabs_delete",
+			mcp.WithDescription("Remove a pinned tab from a Webex room/space. The linked content itself is not affected.\n"+
+				"\n"+
+				"To find the tabId: use webex_room_tabs_list with the roomId.\n"+
+				"\n"+
+				"IMPORTANT: Confirm with the user before removing a tab -- it disappears for everyone in the space."),
+			mcp.WithString("tabId", mcp.Required(), mcp.Description("The ID of the tab to remove. Get this from webex_room_tabs_list.")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			client, err := resolver(ctx)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Auth error: %v", err)), nil
+			}
+
+			tabID, err := req.RequireString("tabId")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			if err := client.RoomTabs().Delete(tabID); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to delete room tab: %v", err)), nil
+			}
+
+			return mcp.NewToolResultText("Room tab deleted successfully"), nil
+		},
+	)
+}
+
+// validateTabContentURL checks that a tab URL is an absolute https URL, which is
+// what the Webex client can render in a tab.
+func validateTabContentURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return fmt.Errorf("contentUrl must be an absolute URL, got %q", raw)
+	}
+	if u.Scheme != "https" {
+		return fmt.Errorf("contentUrl must use https, got %q", u.Scheme)
+	}
+	return nil
+}
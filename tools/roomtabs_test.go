@@ -0,0 +1,21 @@
+package tools
+
+import "testing"
+
+func TestValidateTabContentURL(t *testing.T) {
+	tests := []struct {
+		url     string
+		wantErr bool
+	}{
+		{"https://dashboards.example.com/release", false},
+		{"http://dashboards.example.com/release", true},
+		{"dashboards.example.com/release", true},
+		{"/relative/path", true},
+		{"", true},
+	}
+	for _, tt := range tests {
+		if err := validateTabContentURL(tt.url); (err != nil) != tt.wantErr {
+			t.Errorf("validateTabContentURL(%q) error = %v, wantErr %v", tt.url, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/tejzpr/webex-go-mcp/auth"
+)
+
+// currentSession returns the caller's token record, or an error result when the
+// request was not authenticated with an OAuth Bearer token.
+func currentSession(ctx context.Context) (*auth.TokenRecord, *mcp.CallToolResult) {
+	record, ok := auth.TokenRecordFromContext(ctx)
+	if !ok {
+		return nil, mcp.NewToolResultError("Sessions are only available in HTTP mode when signed in through OAuth.")
+	}
+	return record, nil
+}
+
+// RegisterSessionTools registers the self-service tools for the caller's own
+// OAuth sessions (the MCP clients they have authorized). HTTP mode only.
+func RegisterSessionTools(s ToolRegistrar, sessions *auth.SessionManager) {
+	// webex_sessions_list
+	s.AddTool(
+		mcp.NewTool("webex_sessions_list",
+			mcp.WithDescription("List the MCP clients (sessions) the current user has authorized on this server. Each authorization issues a separate access token.\n"+
+				"\n"+
+				"USE THIS WHEN:\n"+
+				"- 'Which apps are connected to my Webex account through this server?'\n"+
+				"- Before webex_sessions_revoke, to find the sessionId.\n"+
+				"\n"+
+				"RESPONSE: Each session includes its id, clientName and clientId (the MCP client that requested it), createdAt, expiresAt (of the underlying Webex token, renewed on refresh), "+
+				"and current=true for the session making this call."),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			current, errResult := currentSession(ctx)
+			if errResult != nil {
+				return errResult, nil
+			}
+
+			list, err := sessions.List(current)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to list sessions: %v", err)), nil
+			}
+
+			items := make([]map[string]interface{}, 0, len(list))
+			for _, sess := range list {
+				item := map[string]interface{}{
+					"id":        sess.ID,
+					"createdAt": sess.CreatedAt,
+					"expiresAt": sess.ExpiresAt,
+					"current":   sess.Current,
+				}
+				if sess.ClientName != "" {
+					item["clientName"] = sess.ClientName
+				}
+				if sess.ClientID != "" {
+					item["clientId"] = sess.ClientID
+				}
+				items = append(items, item)
+			}
+
+			response := map[string]interface{}{
+				"sessions": items,
+				"count":    len(items),
+			}
+
+			data, _ := json.MarshalIndent(response, "", "  ")
+			return mcp.NewToolResultText(string(data)), nil
+		},
+	)
+
+	// webex_sessions_revoke
+	s.AddTool(
+		mcp.NewTool("webex_sessions_revoke",
+			mcp.WithDescription("Revoke one of the current user's sessions. The MCP client holding it is signed out immediately and must authorize again.\n"+
+				"\n"+
+				"To find the sessionId: use webex_sessions_list.\n"+
+				"\n"+
+				"NOTE: Revoking the session marked current=true signs out this conversation; later tool calls will fail until the client re-authorizes.\n"+
+				"\n"+
+				"IMPORTANT: Confirm with the user which client to disconnect before revoking."),
+			mcp.WithString("sessionId", mcp.Required(), mcp.Description("The ID of the session to revoke. Get this from webex_sessions_list.")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			current, errResult := currentSession(ctx)
+			if errResult != nil {
+				return errResult, nil
+			}
+
+			sessionID, err := req.RequireString("sessionId")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sessionID = strings.TrimSpace(sessionID)
+
+			revoked, err := sessions.Revoke(current, sessionID)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to revoke session: %v", err)), nil
+			}
+			if !revoked {
+				return mcp.NewToolResultError(fmt.Sprintf("No session with id %q. Use webex_sessions_list to see your sessions.", sessionID)), nil
+			}
+
+			response := map[string]interface{}{
+				"revoked":   true,
+				"sessionId": sessionID,
+				"current":   sessionID == auth.SessionID(current.OpaqueToken),
+			}
+
+			data, _ := json.MarshalIndent(response, "", "  ")
+			return mcp.NewToolResultText(string(data)), nil
+		},
+	)
+}
@@ -3,29 +3,74 @@ package tools
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
+	webex "github.com/WebexCommunity/webex-go-sdk/v2"
 	"github.com/mark3labs/mcp-go/mcp"
 	mcpserver "github.com/mark3labs/mcp-go/server"
 	"github.com/tejzpr/webex-go-mcp/auth"
 	"github.com/tejzpr/webex-go-mcp/streaming"
 )
 
-// RegisterStreamingTools registers Mercury-based streaming MCP tools.
-func RegisterStreamingTools(s ToolRegistrar, resolver auth.ClientResolver, manager *streaming.MercuryManager) {
+// mercuryReason explains what real-time streaming needs from the credentials.
+const mercuryReason = "Real-time streaming connects to Webex Mercury, which needs a Webex user access token that can register a device (spark:all scope)."
+
+// mercuryHint says how to get a working token in the given mode.
+func mercuryHint(mode ServerMode) string {
+	if mode == ModeSTDIO {
+		return "In STDIO mode WEBEX_ACCESS_TOKEN must be a personal user token with spark:all (bot and guest tokens cannot use Mercury). Otherwise run with --mode http and sign in through OAuth, or poll with webex_rooms_watch_changes."
+	}
+	return "Sign in through OAuth with a user account whose token includes spark:all, or poll with webex_rooms_watch_changes."
+}
+
+// streamingAccessToken returns the token for the Mercury connection: the per-request
+// OAuth token in HTTP mode, or the static client's token in STDIO mode.
+func streamingAccessToken(ctx context.Context, client *webex.WebexClient) string {
+	if token, ok := auth.WebexTokenFromContext(ctx); ok && token != "" {
+		return token
+	}
+	return client.Core().GetAccessToken()
+}
+
+// mercuryErrorResult reports a streaming failure. Mercury setup failures in STDIO mode
+// become a ModeError explaining the requirement instead of a raw connection error.
+func mercuryErrorResult(tool string, mode ServerMode, action string, err error) *mcp.CallToolResult {
+	var connErr *streaming.ConnectionError
+	if !errors.As(err, &connErr) {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to %s: %v", action, err))
+	}
+	if mode == ModeSTDIO {
+		return modeErrorResult(ModeError{
+			Tool:         tool,
+			RequiredMode: ModeHTTP,
+			CurrentMode:  mode,
+			Reason:       mercuryReason,
+			Hint:         mercuryHint(mode),
+			Detail:       err.Error(),
+		})
+	}
+	return mcp.NewToolResultError(fmt.Sprintf("Failed to %s: %v. %s %s", action, err, mercuryReason, mercuryHint(mode)))
+}
+
+// RegisterStreamingTools registers Mercury-based streaming MCP tools. mode is the
+// active server mode, used to explain failures that stem from the credentials it allows.
+func RegisterStreamingTools(s ToolRegistrar, resolver auth.ClientResolver, manager *streaming.MercuryManager, mode ServerMode) {
 	// subscribe_room_messages — opens a Mercury listener for a room
 	s.AddTool(
 		mcp.NewTool("webex_subscribe_room_messages",
 			mcp.WithDescription("Subscribe to real-time messages in a Webex room via Mercury WebSocket. "+
 				"Returns immediately with a subscriptionId. Events are streamed as MCP notifications. "+
-				"Use webex_unsubscribe to stop. Requires HTTP mode with OAuth authentication."),
+				"Use webex_unsubscribe to stop. Requires a Webex user token: HTTP mode with OAuth, or in STDIO mode a personal WEBEX_ACCESS_TOKEN. "+
+				"If the token cannot use streaming, the error is structured (error='unsupported_mode') with a hint."),
 			mcp.WithString("roomId",
 				mcp.Required(),
 				mcp.Description("The ID of the room to subscribe to. Messages in this room will be streamed as notifications.")),
 			mcp.WithString("eventTypes",
-				mcp.Description("Comma-separated event types to listen for. Default: 'post,share'. "+
-					"Options: post, share, acknowledge.")),
+				mcp.Description("Comma-separated event types to listen for. Default: 'post,share,update,delete'. "+
+					"Options: post, share, acknowledge, delete (a message was deleted), update (a message was edited). "+
+					"Every event includes the affected messageId (for delete/update, the deleted or edited message).")),
 		),
 		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			client, err := resolver(ctx)
@@ -39,21 +84,22 @@ func RegisterStreamingTools(s ToolRegistrar, resolver auth.ClientResolver, manag
 			}
 
 			// Parse event types
-			eventTypesStr := req.GetString("eventTypes", "post,share")
-			eventTypes := parseCSV(eventTypesStr)
-
-			// Get the access token from context (HTTP mode) or from the client (STDIO mode)
-			accessToken, ok := auth.WebexTokenFromContext(ctx)
-			if !ok || accessToken == "" {
-				accessToken = client.Core().GetAccessToken()
+			eventTypes := parseCSV(req.GetString("eventTypes", ""))
+			if len(eventTypes) == 0 {
+				eventTypes = streaming.DefaultEventTypes
 			}
+			if err := streaming.ValidateEventTypes(eventTypes); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			accessToken := streamingAccessToken(ctx, client)
 			if accessToken == "" {
-				return mcp.NewToolResultError("No access token available for Mercury connection."), nil
+				return newModeErrorResult("webex_subscribe_room_messages", ModeHTTP, mode, mercuryReason, mercuryHint(mode)), nil
 			}
 
 			sub, err := manager.Subscribe(ctx, client, accessToken, roomID, eventTypes)
 			if err != nil {
-				return mcp.NewToolResultError(fmt.Sprintf("Failed to subscribe: %v", err)), nil
+				return mercuryErrorResult("webex_subscribe_room_messages", mode, "subscribe", err), nil
 			}
 
 			result := map[string]interface{}{
@@ -100,7 +146,7 @@ func RegisterStreamingTools(s ToolRegistrar, resolver auth.ClientResolver, manag
 		mcp.NewTool("webex_wait_for_message",
 			mcp.WithDescription("Wait for the next message in a Webex room. Blocks until a message arrives or timeout. "+
 				"Simpler alternative to subscribe_room_messages for one-shot use cases. "+
-				"Requires HTTP mode with OAuth authentication."),
+				"Requires a Webex user token: HTTP mode with OAuth, or in STDIO mode a personal WEBEX_ACCESS_TOKEN."),
 			mcp.WithString("roomId",
 				mcp.Required(),
 				mcp.Description("The ID of the room to wait for a message in.")),
@@ -127,18 +173,14 @@ func RegisterStreamingTools(s ToolRegistrar, resolver auth.ClientResolver, manag
 			}
 			timeout := time.Duration(timeoutSec) * time.Second
 
-			// Get the access token from context (HTTP mode) or from the client (STDIO mode)
-			accessToken, ok := auth.WebexTokenFromContext(ctx)
-			if !ok || accessToken == "" {
-				accessToken = client.Core().GetAccessToken()
-			}
+			accessToken := streamingAccessToken(ctx, client)
 			if accessToken == "" {
-				return mcp.NewToolResultError("No access token available for Mercury connection."), nil
+				return newModeErrorResult("webex_wait_for_message", ModeHTTP, mode, mercuryReason, mercuryHint(mode)), nil
 			}
 
 			msg, err := manager.WaitForMessage(ctx, client, accessToken, roomID, timeout)
 			if err != nil {
-				return mcp.NewToolResultError(fmt.Sprintf("Error waiting for message: %v", err)), nil
+				return mercuryErrorResult("webex_wait_for_message", mode, "wait for message", err), nil
 			}
 
 			data, _ := json.MarshalIndent(msg, "", "  ")
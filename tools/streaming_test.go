@@ -0,0 +1,45 @@
+package tools
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/tejzpr/webex-go-mcp/streaming"
+)
+
+func TestMercuryErrorResult(t *testing.T) {
+	connErr := &streaming.ConnectionError{Op: "failed to connect Mercury", Err: errors.New("device registration failed: 403")}
+
+	tests := []struct {
+		name           string
+		mode           ServerMode
+		err            error
+		wantStructured bool
+		wantText       string
+	}{
+		{"stdio connection failure", ModeSTDIO, connErr, true, `"error": "unsupported_mode"`},
+		{"http connection failure", ModeHTTP, connErr, false, "spark:all"},
+		{"other failure", ModeSTDIO, errors.New("timeout waiting for message after 1s"), false, "Failed to wait for message: timeout"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := mercuryErrorResult("webex_wait_for_message", tt.mode, "wait for message", tt.err)
+			if !result.IsError {
+				t.Error("IsError = false, want true")
+			}
+			me, structured := result.StructuredContent.(ModeError)
+			if structured != tt.wantStructured {
+				t.Fatalf("structured = %v, want %v", structured, tt.wantStructured)
+			}
+			if structured && (me.RequiredMode != ModeHTTP || me.CurrentMode != ModeSTDIO || me.Detail == "") {
+				t.Errorf("ModeError = %+v", me)
+			}
+			text := result.Content[0].(mcp.TextContent).Text
+			if !strings.Contains(text, tt.wantText) {
+				t.Errorf("text = %q, want it to contain %q", text, tt.wantText)
+			}
+		})
+	}
+}
@@ -13,7 +13,7 @@ import (
 )
 
 // Compact fields for teams list
-var teamsCompactFields = []string{"team", "creatorName", "roomCount"}
+var teamsCompactFields = []string{"team", "creatorName", "roomCount", "roomCountNote"}
 
 // RegisterTeamTools registers all team-related MCP tools.
 func RegisterTeamTools(s ToolRegistrar, resolver auth.ClientResolver) {
@@ -28,7 +28,8 @@ func RegisterTeamTools(s ToolRegistrar, resolver auth.ClientResolver) {
 				"- 'What teams am I on?' -- Call this with no filters.\n"+
 				"- 'What rooms are in team X?' -- Use the teamId from this response with webex_rooms_list.\n"+
 				"\n"+
-				"RESPONSE: Enriched with creator name, room count, and a list of rooms (with titles) for each team -- so you don't need a follow-up call to see what's inside."+
+				"RESPONSE: Enriched with creator name, room count, and a list of rooms (with titles) for each team -- so you don't need a follow-up call to see what's inside. "+
+				"Room counts above 100 are lower bounds, flagged with roomCountIsLowerBound and a roomCountNote -- use webex_teams_get for the full list."+
 				PaginationDescription),
 			mcp.WithNumber("maxResults", mcp.Description(MaxResultsParamDescription)),
 			mcp.WithBoolean("compact", mcp.Description(CompactParamDescription)),
@@ -85,10 +86,12 @@ func RegisterTeamTools(s ToolRegistrar, resolver auth.ClientResolver) {
 					et["creatorName"] = name
 				}
 
+				// One page per team keeps listing cheap; larger teams get a lower-bound count
 				if roomPage, rErr := client.Rooms().List(&rooms.ListOptions{
 					TeamID: team.ID,
+					Max:    CatalogPageSize,
 				}); rErr == nil {
-					et["roomCount"] = len(roomPage.Items)
+					AddCountToMap(et, "roomCount", len(roomPage.Items), roomPage.HasNext)
 					roomSummaries := make([]map[string]interface{}, 0, len(roomPage.Items))
 					for _, r := range roomPage.Items {
 						roomSummaries = append(roomSummaries, map[string]interface{}{
@@ -158,9 +161,9 @@ func RegisterTeamTools(s ToolRegistrar, resolver auth.ClientResolver) {
 				"- team: Full team details (name, description, creation date).\n"+
 				"- creator: Display name and email of who created the team.\n"+
 				"- rooms: All rooms/spaces in this team with their titles.\n"+
-				"- roomCount: Total number of rooms.\n"+
+				"- roomCount: Total number of rooms (up to 1000; beyond that roomCountNote says 'at least N (more available)').\n"+
 				"- members: All team members with display names, emails, and moderator status.\n"+
-				"- memberCount: Total number of members.\n"+
+				"- memberCount: Total number of members (up to 1000, flagged the same way).\n"+
 				"\n"+
 				"This is the best tool when the user asks 'tell me about team X' or 'who is on team X?' -- one call gets everything."),
 			mcp.WithString("teamId", mcp.Required(), mcp.Description("The ID of the team to retrieve. Get this from webex_teams_list.")),
@@ -197,16 +200,26 @@ func RegisterTeamTools(s ToolRegistrar, resolver auth.ClientResolver) {
 
 			if roomPage, rErr := client.Rooms().List(&rooms.ListOptions{
 				TeamID: teamID,
+				Max:    CatalogPageSize,
 			}); rErr == nil {
-				response["rooms"] = roomPage.Items
-				response["roomCount"] = len(roomPage.Items)
+				teamRooms, more, fErr := FetchAll(roomPage.Items, roomPage.HasNext, roomPage.NextPage, client, EnrichmentCountCap)
+				response["rooms"] = teamRooms
+				AddCountToMap(response, "roomCount", len(teamRooms), more || fErr != nil)
+				if fErr != nil {
+					response["roomsError"] = fmt.Sprintf("Only the first %d rooms could be read: %v", len(teamRooms), fErr)
+				}
 			}
 
 			if memberPage, mErr := client.TeamMemberships().List(&teammemberships.ListOptions{
 				TeamID: teamID,
+				Max:    CatalogPageSize,
 			}); mErr == nil {
-				response["members"] = memberPage.Items
-				response["memberCount"] = len(memberPage.Items)
+				members, more, fErr := FetchAll(memberPage.Items, memberPage.HasNext, memberPage.NextPage, client, EnrichmentCountCap)
+				response["members"] = members
+				AddCountToMap(response, "memberCount", len(members), more || fErr != nil)
+				if fErr != nil {
+					response["membersError"] = fmt.Sprintf("Only the first %d members could be read: %v", len(members), fErr)
+				}
 			}
 
 			data, _ := json.MarshalIndent(response, "", "  ")
@@ -253,4 +266,43 @@ func RegisterTeamTools(s ToolRegistrar, resolver auth.ClientResolver) {
 			return mcp.NewToolResultText(string(data)), nil
 		},
 	)
+
+	// webex_teams_catalog
+	s.AddTool(
+		mcp.NewTool("webex_teams_catalog",
+			mcp.WithDescription("Get a compact id/name lookup table of ALL teams the user belongs to, with no enrichment. "+
+				"Much cheaper than webex_teams_list -- use it once to map team names to IDs and keep it as a reference for later calls.\n"+
+				"\n"+
+				"RESPONSE: _catalog (count, truncated, message) followed by items of {id, name}. "+
+				"If truncated=true, re-call with a higher maxItems."),
+			mcp.WithNumber("maxItems", mcp.Description(CatalogMaxItemsParamDescription)),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			client, err := resolver(ctx)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Auth error: %v", err)), nil
+			}
+
+			page, err := client.Teams().List(&teams.ListOptions{Max: CatalogPageSize})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to list teams: %v", err)), nil
+			}
+
+			teamItems, truncated, fetchErr := FetchAll(page.Items, page.HasNext, page.NextPage, client, ClampCatalogMaxItems(req))
+
+			entries := make([]map[string]string, 0, len(teamItems))
+			for _, t := range teamItems {
+				entries = append(entries, map[string]string{
+					"id":   t.ID,
+					"name": t.Name,
+				})
+			}
+
+			result, fErr := FormatCatalogResponse(entries, len(entries), truncated, fetchErr)
+			if fErr != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to format response: %v", fErr)), nil
+			}
+			return mcp.NewToolResultText(result), nil
+		},
+	)
 }
@@ -5,6 +5,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"slices"
+	"sort"
+	"strings"
 
 	"github.com/WebexCommunity/webex-go-sdk/v2/transcripts"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -12,7 +15,8 @@ import (
 )
 
 // RegisterTranscriptTools registers all transcript-related MCP tools.
-func RegisterTranscriptTools(s ToolRegistrar, resolver auth.ClientResolver) {
+// prefs may be nil; when set, webex_transcripts_download falls back to the user's stored transcript format.
+func RegisterTranscriptTools(s ToolRegistrar, resolver auth.ClientResolver, prefs auth.PreferenceStore) {
 	// webex_transcripts_list
 	s.AddTool(
 		mcp.NewTool("webex_transcripts_list",
@@ -173,13 +177,15 @@ func RegisterTranscriptTools(s ToolRegistrar, resolver auth.ClientResolver) {
 				"3. Pass both to this tool.\n"+
 				"\n"+
 				"FORMATS:\n"+
-				"- 'txt' (default): Plain text, easy to read. Best for summarizing or searching.\n"+
+				"- 'txt' (default, unless the user saved a transcriptFormat preference): Plain text, easy to read. Best for summarizing or searching.\n"+
 				"- 'vtt': WebVTT format with timestamps for each spoken segment. Use if the user needs timing info.\n"+
+				"- 'dialogue': Readable 'Speaker: text' lines, with consecutive turns by the same speaker merged. Best for meeting recaps. Set timestamps=true to prefix each turn with its offset into the meeting.\n"+
 				"\n"+
 				"TIP: The enriched webex_transcripts_list already includes a snippet preview (first 3 utterances). If that's enough to answer the user's question, you may not need to download the full transcript."),
 			mcp.WithString("transcriptId", mcp.Required(), mcp.Description("The transcript ID to download. This is the 'id' field from webex_transcripts_list results.")),
 			mcp.WithString("meetingId", mcp.Required(), mcp.Description("The meeting instance ID. This is the 'meetingId' field from the SAME transcript object in webex_transcripts_list results. MUST match the transcript.")),
-			mcp.WithString("format", mcp.Description("Download format: 'txt' (plain text, default) or 'vtt' (WebVTT with timestamps). Use 'txt' unless the user specifically needs timestamps.")),
+			mcp.WithString("format", mcp.Description("Download format: 'txt' (plain text, default), 'vtt' (WebVTT with timestamps), or 'dialogue' (speaker-labelled turns). Prefer 'dialogue' for summaries and recaps. Omit to use the user's saved transcriptFormat preference, if any.")),
+			mcp.WithBoolean("timestamps", mcp.Description("Only for format='dialogue': prefix each turn with its [hh:mm:ss] offset into the meeting. Default: false.")),
 		),
 		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			client, err := resolver(ctx)
@@ -196,7 +202,28 @@ func RegisterTranscriptTools(s ToolRegistrar, resolver auth.ClientResolver) {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
-			format := req.GetString("format", "txt")
+			format := req.GetString("format", "")
+			if format == "" {
+				format = lookupPreference(prefs, client, PrefTranscriptFormat)
+			}
+			if format == "" {
+				format = "txt"
+			}
+			if format == "dialogue" {
+				page, err := client.Transcripts().ListSnippets(transcriptID, &transcripts.SnippetListOptions{Max: CatalogPageSize})
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("Failed to list snippets: %v", err)), nil
+				}
+				snippets, truncated, fetchErr := FetchAll(page.Items, page.HasNext, page.NextPage, client, dialogueSnippetCap)
+
+				content := renderDialogue(snippets, req.GetBool("timestamps", false))
+				if fetchErr != nil {
+					content += fmt.Sprintf("\n\n[Transcript incomplete: only %d snippets could be read (%v). Retry for the rest.]", len(snippets), fetchErr)
+				} else if truncated {
+					content += fmt.Sprintf("\n\n[Transcript truncated after %d snippets]", len(snippets))
+				}
+				return mcp.NewToolResultText(content), nil
+			}
 
 			content, err := client.Transcripts().Download(transcriptID, format, &transcripts.DownloadOptions{MeetingID: meetingID})
 			if err != nil {
@@ -355,3 +382,54 @@ func RegisterTranscriptTools(s ToolRegistrar, resolver auth.ClientResolver) {
 		},
 	)
 }
+
+// dialogueSnippetCap is the maximum number of snippets rendered by the 'dialogue' download format.
+const dialogueSnippetCap = 10000
+
+// renderDialogue renders transcript snippets as "Speaker: text" lines in offset
+// order, merging consecutive snippets by the same speaker into one turn. With
+// timestamps, each turn is prefixed with the offset of its first snippet.
+func renderDialogue(snippets []transcripts.Snippet, timestamps bool) string {
+	// Pages may arrive out of order; merging in API order would join the wrong turns
+	snippets = slices.Clone(snippets)
+	sort.SliceStable(snippets, func(i, j int) bool {
+		return snippets[i].OffsetMillisecond < snippets[j].OffsetMillisecond
+	})
+
+	var b strings.Builder
+	lastSpeaker := ""
+	for _, s := range snippets {
+		text := strings.TrimSpace(s.Text)
+		if text == "" {
+			continue
+		}
+		speaker := s.PersonName
+		if speaker == "" {
+			speaker = s.PersonEmail
+		}
+		if speaker == "" {
+			speaker = "Unknown speaker"
+		}
+
+		if speaker == lastSpeaker {
+			b.WriteString(" ")
+			b.WriteString(text)
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteString("\n\n")
+		}
+		if timestamps {
+			b.WriteString("[" + formatOffset(s.OffsetMillisecond) + "] ")
+		}
+		b.WriteString(speaker + ": " + text)
+		lastSpeaker = speaker
+	}
+	return b.String()
+}
+
+// formatOffset formats a millisecond offset as hh:mm:ss.
+func formatOffset(ms int) string {
+	secs := ms / 1000
+	return fmt.Sprintf("%02d:%02d:%02d", secs/3600, secs/60%60, secs%60)
+}
@@ -0,0 +1,59 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/WebexCommunity/webex-go-sdk/v2/transcripts"
+)
+
+func TestRenderDialogue(t *testing.T) {
+	snippets := []transcripts.Snippet{
+		{PersonName: "Alice", Text: "Morning all.", OffsetMillisecond: 1000},
+		{PersonName: "Alice", Text: "Let's start with the release.", OffsetMillisecond: 4000},
+		{PersonName: "Bob", Text: "  It's on track.  ", OffsetMillisecond: 3725000},
+		{PersonName: "Bob", Text: "", OffsetMillisecond: 3730000},
+		{PersonEmail: "carol@example.com", Text: "Great.", OffsetMillisecond: 3740000},
+		{Text: "(inaudible)", OffsetMillisecond: 3750000},
+		{PersonName: "Alice", Text: "Thanks.", OffsetMillisecond: 3760000},
+	}
+
+	tests := []struct {
+		name       string
+		snippets   []transcripts.Snippet
+		timestamps bool
+		want       string
+	}{
+		{"empty", nil, false, ""},
+		{
+			"merged turns",
+			snippets,
+			false,
+			"Alice: Morning all. Let's start with the release.\n\n" +
+				"Bob: It's on track.\n\n" +
+				"carol@example.com: Great.\n\n" +
+				"Unknown speaker: (inaudible)\n\n" +
+				"Alice: Thanks.",
+		},
+		{
+			"with timestamps",
+			snippets[:3],
+			true,
+			"[00:00:01] Alice: Morning all. Let's start with the release.\n\n" +
+				"[01:02:05] Bob: It's on track.",
+		},
+		{
+			"out of order",
+			[]transcripts.Snippet{snippets[2], snippets[0], snippets[1]},
+			false,
+			"Alice: Morning all. Let's start with the release.\n\n" +
+				"Bob: It's on track.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := renderDialogue(tt.snippets, tt.timestamps); got != tt.want {
+				t.Errorf("renderDialogue() =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
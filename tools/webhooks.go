@@ -4,21 +4,109 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net"
+	"net/url"
+	"strings"
 
 	"github.com/WebexCommunity/webex-go-sdk/v2/webhooks"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/tejzpr/webex-go-mcp/auth"
 )
 
+// webhookScanCap is the maximum number of webhooks webex_webhooks_list scans when filtering.
+const webhookScanCap = 1000
+
+// webhookFilter holds the client-side filters of webex_webhooks_list. Empty fields match everything.
+type webhookFilter struct {
+	Resource          string
+	Event             string
+	TargetURLContains string
+	Status            string
+}
+
+// active reports whether any filter is set.
+func (f webhookFilter) active() bool {
+	return f.Resource != "" || f.Event != "" || f.TargetURLContains != "" || f.Status != ""
+}
+
+// matches reports whether the webhook passes every set filter.
+func (f webhookFilter) matches(w webhooks.Webhook) bool {
+	if f.Resource != "" && !strings.EqualFold(w.Resource, f.Resource) {
+		return false
+	}
+	if f.Event != "" && !strings.EqualFold(w.Event, f.Event) {
+		return false
+	}
+	if f.Status != "" && !strings.EqualFold(w.Status, f.Status) {
+		return false
+	}
+	if f.TargetURLContains != "" && !strings.Contains(strings.ToLower(w.TargetURL), strings.ToLower(f.TargetURLContains)) {
+		return false
+	}
+	return true
+}
+
+// filterWebhooks returns the webhooks that match the filter, in their original order.
+func filterWebhooks(items []webhooks.Webhook, f webhookFilter) []webhooks.Webhook {
+	matches := make([]webhooks.Webhook, 0, len(items))
+	for _, w := range items {
+		if f.matches(w) {
+			matches = append(matches, w)
+		}
+	}
+	return matches
+}
+
+// validateWebhookTargetURL checks that a webhook target is an absolute https URL that
+// Webex can reach, i.e. not localhost or a private, loopback, or link-local address.
+func validateWebhookTargetURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return fmt.Errorf("targetUrl must be an absolute URL, got %q", raw)
+	}
+	if u.Scheme != "https" {
+		return fmt.Errorf("targetUrl must use https, got %q", u.Scheme)
+	}
+	host := u.Hostname()
+	if strings.EqualFold(host, "localhost") || strings.HasSuffix(strings.ToLower(host), ".localhost") {
+		return fmt.Errorf("targetUrl host %q is not reachable from Webex; use a public URL (e.g. a tunnel for local development)", host)
+	}
+	if ip := net.ParseIP(host); ip != nil && (ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsUnspecified()) {
+		return fmt.Errorf("targetUrl address %s is not reachable from Webex; use a public URL (e.g. a tunnel for local development)", host)
+	}
+	return nil
+}
+
+// webhookVerificationSteps explains how a receiver verifies signed webhook payloads.
+var webhookVerificationSteps = []string{
+	"Read the raw request body bytes before parsing the JSON.",
+	"Compute the HMAC-SHA1 of the raw body, keyed with the secret, and hex-encode it.",
+	"Compare it with the X-Spark-Signature header using a constant-time comparison; reject the request (e.g. 401) if they differ.",
+	"Respond with a 2xx status quickly and do slow work asynchronously; Webex may deactivate a webhook whose target keeps failing (check status with webex_webhooks_list).",
+	"The payload's data only identifies the message (data.id); fetch its content with webex_messages_get or GET /v1/messages/{id}.",
+}
+
+// webhookSecretNote accompanies a secret generated by the webhook tools.
+const webhookSecretNote = "Generated secret -- save it now. Configure it in the receiver at targetUrl and reject requests whose " +
+	"X-Spark-Signature header is not the hex HMAC-SHA1 of the raw body keyed with this secret."
+
 // RegisterWebhookTools registers all webhook-related MCP tools.
 func RegisterWebhookTools(s ToolRegistrar, resolver auth.ClientResolver) {
 	// webex_webhooks_list
 	s.AddTool(
 		mcp.NewTool("webex_webhooks_list",
 			mcp.WithDescription("List all Webex webhooks registered by the authenticated user. A webhook is a callback URL that Webex notifies when specific events happen (e.g. new message, meeting started, membership changed).\n"+
+				"\n"+
+				"FILTERS: Set any of resource, event, targetUrlContains, or status to find matching webhooks, e.g. 'all my message webhooks' (resource='messages') "+
+				"or 'webhooks pointing at the old server' (targetUrlContains='old-host.example.com'). With filters, all webhooks are scanned and every match is returned at once "+
+				"with matchCount; maxResults and nextPageUrl are ignored.\n"+
 				"\n"+
 				"RESPONSE: Each webhook shows its name, targetUrl, resource, event, filter, status (active/inactive), and creation date."+
 				PaginationDescription),
+			mcp.WithString("resource", mcp.Description("Only webhooks for this resource, e.g. 'messages', 'memberships', 'meetings' (case-insensitive).")),
+			mcp.WithString("event", mcp.Description("Only webhooks for this event, e.g. 'created', 'deleted', 'all' (case-insensitive).")),
+			mcp.WithString("targetUrlContains", mcp.Description("Only webhooks whose targetUrl contains this text (case-insensitive), e.g. a host name.")),
+			mcp.WithString("status", mcp.Description("Only webhooks with this status: 'active' or 'inactive'.")),
 			mcp.WithNumber("maxResults", mcp.Description(MaxResultsParamDescription)),
 			mcp.WithString("nextPageUrl", mcp.Description(NextPageUrlParamDescription)),
 		),
@@ -28,6 +116,30 @@ func RegisterWebhookTools(s ToolRegistrar, resolver auth.ClientResolver) {
 				return mcp.NewToolResultError(fmt.Sprintf("Auth error: %v", err)), nil
 			}
 
+			filter := webhookFilter{
+				Resource:          strings.TrimSpace(req.GetString("resource", "")),
+				Event:             strings.TrimSpace(req.GetString("event", "")),
+				TargetURLContains: strings.TrimSpace(req.GetString("targetUrlContains", "")),
+				Status:            strings.TrimSpace(req.GetString("status", "")),
+			}
+			if filter.active() {
+				page, pErr := client.Webhooks().List(&webhooks.ListOptions{Max: CatalogPageSize})
+				if pErr != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("Failed to list webhooks: %v", pErr)), nil
+				}
+				all, truncated, fetchErr := FetchAll(page.Items, page.HasNext, page.NextPage, client, webhookScanCap)
+				matches := filterWebhooks(all, filter)
+
+				response := map[string]interface{}{
+					"webhooks": matches,
+					"scanned":  len(all),
+				}
+				AddCountToMap(response, "matchCount", len(matches), truncated || fetchErr != nil)
+				AddFetchErrorToMap(response, fetchErr)
+				data, _ := json.MarshalIndent(response, "", "  ")
+				return mcp.NewToolResultText(string(data)), nil
+			}
+
 			nextPageUrl := req.GetString("nextPageUrl", "")
 			maxResults := ClampMaxResults(req)
 
@@ -82,6 +194,9 @@ func RegisterWebhookTools(s ToolRegistrar, resolver auth.ClientResolver) {
 				"- New recording available: resource='recordings', event='created'\n"+
 				"- New transcript available: resource='meetingTranscripts', event='created'\n"+
 				"\n"+
+				"SECURITY: Strongly recommended -- set generateSecret=true (unless the user already has a secret). Without a secret, payloads are unsigned and anyone who learns the targetUrl can send fake events. "+
+				"The generated secret is returned once in this response; tell the user to configure it in their receiver to verify the X-Spark-Signature header.\n"+
+				"\n"+
 				"IMPORTANT: The targetUrl must be a publicly accessible HTTPS URL that can receive POST requests."),
 			mcp.WithString("name", mcp.Required(), mcp.Description("A friendly name for this webhook (e.g. 'New messages in Project Alpha', 'Meeting notifications').")),
 			mcp.WithString("targetUrl", mcp.Required(), mcp.Description("The HTTPS URL where Webex will POST event notifications. Must be publicly accessible.")),
@@ -89,6 +204,7 @@ func RegisterWebhookTools(s ToolRegistrar, resolver auth.ClientResolver) {
 			mcp.WithString("event", mcp.Required(), mcp.Description("The event type to trigger on. Options depend on resource: 'created', 'updated', 'deleted' (for messages/memberships/rooms), 'started', 'ended' (for meetings), 'joined', 'left' (for meetingParticipants).")),
 			mcp.WithString("filter", mcp.Description("Optional filter to narrow events. Examples: 'roomId=ROOM_ID' (only events in that room), 'mentionedPeople=me' (only messages mentioning you), 'personEmail=alice@example.com' (only events involving that person).")),
 			mcp.WithString("secret", mcp.Description("Optional secret string. Webex uses it to sign the webhook payload (HMAC-SHA1 in X-Spark-Signature header) so your server can verify the request is authentic.")),
+			mcp.WithBoolean("generateSecret", mcp.Description("Generate a cryptographically random secret, sign the webhook with it, and return it once in the response. Recommended. Cannot be combined with secret. Default: false.")),
 		),
 		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			client, err := resolver(ctx)
@@ -113,13 +229,24 @@ func RegisterWebhookTools(s ToolRegistrar, resolver auth.ClientResolver) {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
+			secret := req.GetString("secret", "")
+			generateSecret := req.GetBool("generateSecret", false)
+			if generateSecret {
+				if secret != "" {
+					return mcp.NewToolResultError("Pass either secret or generateSecret=true, not both"), nil
+				}
+				if secret, err = auth.GenerateWebhookSecret(); err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("Failed to generate secret: %v", err)), nil
+				}
+			}
+
 			webhook := &webhooks.Webhook{
 				Name:      name,
 				TargetURL: targetURL,
 				Resource:  resource,
 				Event:     event,
 				Filter:    req.GetString("filter", ""),
-				Secret:    req.GetString("secret", ""),
+				Secret:    secret,
 			}
 
 			result, err := client.Webhooks().Create(webhook)
@@ -127,7 +254,18 @@ func RegisterWebhookTools(s ToolRegistrar, resolver auth.ClientResolver) {
 				return mcp.NewToolResultError(fmt.Sprintf("Failed to create webhook: %v", err)), nil
 			}
 
-			data, _ := json.MarshalIndent(result, "", "  ")
+			if !generateSecret {
+				data, _ := json.MarshalIndent(result, "", "  ")
+				return mcp.NewToolResultText(string(data)), nil
+			}
+			result.Secret = "" // returned once, below
+
+			response := map[string]interface{}{
+				"webhook":    result,
+				"secret":     secret,
+				"secretNote": webhookSecretNote,
+			}
+			data, _ := json.MarshalIndent(response, "", "  ")
 			return mcp.NewToolResultText(string(data)), nil
 		},
 	)
@@ -207,6 +345,153 @@ func RegisterWebhookTools(s ToolRegistrar, resolver auth.ClientResolver) {
 		},
 	)
 
+	// webex_webhooks_rotate_secret
+	s.AddTool(
+		mcp.NewTool("webex_webhooks_rotate_secret",
+			mcp.WithDescription("Replace a webhook's signing secret with a new cryptographically random one and return it once. Name, targetUrl, and status are kept.\n"+
+				"\n"+
+				"USE THIS WHEN:\n"+
+				"- Rotating secrets periodically.\n"+
+				"- A secret may have leaked.\n"+
+				"- Adding a secret to a webhook that was created without one.\n"+
+				"\n"+
+				"IMPORTANT: From this call on, Webex signs payloads with the new secret only. The receiver at targetUrl must be updated with the new secret at the same time, "+
+				"or it will reject (or, if it does not verify, silently trust) events in between. Tell the user this, and confirm before rotating."),
+			mcp.WithString("webhookId", mcp.Required(), mcp.Description("The ID of the webhook. Get this from webex_webhooks_list.")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			client, err := resolver(ctx)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Auth error: %v", err)), nil
+			}
+
+			webhookID, err := req.RequireString("webhookId")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			existing, err := client.Webhooks().Get(webhookID)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to get webhook: %v", err)), nil
+			}
+
+			secret, err := auth.GenerateWebhookSecret()
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to generate secret: %v", err)), nil
+			}
+
+			update := webhooks.NewUpdateWebhook(existing.Name, existing.TargetURL, secret, existing.Status)
+			result, err := client.Webhooks().Update(webhookID, update)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to update webhook: %v", err)), nil
+			}
+			result.Secret = "" // returned once, below
+
+			response := map[string]interface{}{
+				"webhook":           result,
+				"secret":            secret,
+				"hadPreviousSecret": existing.Secret != "",
+				"secretNote":        webhookSecretNote + " The previous secret is no longer used; update the receiver now.",
+			}
+			data, _ := json.MarshalIndent(response, "", "  ")
+			return mcp.NewToolResultText(string(data)), nil
+		},
+	)
+
+	// webex_notifications_setup
+	s.AddTool(
+		mcp.NewTool("webex_notifications_setup",
+			mcp.WithDescription("Set up notifications for new messages in one call: validates the target URL, generates a signing secret, creates the right webhook, "+
+				"and returns a ready-to-use configuration with the secret and the exact steps to verify signatures.\n"+
+				"\n"+
+				"USE THIS WHEN:\n"+
+				"- 'Notify my server when someone posts in <room>' → roomId\n"+
+				"- 'Send me an event whenever I'm @mentioned' → mentionedMe=true\n"+
+				"Prefer this over webex_webhooks_create for these cases; use webex_webhooks_create for other resources or events.\n"+
+				"\n"+
+				"RESPONSE: webhook (as created), configuration (targetUrl, resource, event, filter, secret), and verification steps for the receiver. "+
+				"The secret is shown only in this response.\n"+
+				"\n"+
+				"IMPORTANT: The targetUrl must be a public HTTPS URL. Confirm with the user before creating the webhook."),
+			mcp.WithString("targetUrl", mcp.Required(), mcp.Description("Public HTTPS URL of the receiver Webex will POST events to.")),
+			mcp.WithString("roomId", mcp.Description("Notify about every new message in this room. Provide this or mentionedMe.")),
+			mcp.WithBoolean("mentionedMe", mcp.Description("Notify about every new message that @mentions you, in any room. Provide this or roomId.")),
+			mcp.WithString("name", mcp.Description("Name for the webhook. Default: derived from the room title or 'Messages mentioning me'.")),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			client, err := resolver(ctx)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Auth error: %v", err)), nil
+			}
+
+			targetURL, err := req.RequireString("targetUrl")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			targetURL = strings.TrimSpace(targetURL)
+			if err := validateWebhookTargetURL(targetURL); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			roomID := strings.TrimSpace(req.GetString("roomId", ""))
+			mentionedMe := req.GetBool("mentionedMe", false)
+			if (roomID == "") == !mentionedMe {
+				return mcp.NewToolResultError("Provide exactly one of roomId or mentionedMe=true"), nil
+			}
+
+			name := strings.TrimSpace(req.GetString("name", ""))
+			var filter string
+			if roomID != "" {
+				room := resolveRoomInfo(client, roomID)
+				if room == nil {
+					return mcp.NewToolResultError(fmt.Sprintf("Room %s was not found or is not accessible", roomID)), nil
+				}
+				filter = "roomId=" + roomID
+				if name == "" {
+					name = "New messages in " + room.Title
+				}
+			} else {
+				filter = "mentionedPeople=me"
+				if name == "" {
+					name = "Messages mentioning me"
+				}
+			}
+
+			secret, err := auth.GenerateWebhookSecret()
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to generate secret: %v", err)), nil
+			}
+
+			result, err := client.Webhooks().Create(&webhooks.Webhook{
+				Name:      name,
+				TargetURL: targetURL,
+				Resource:  "messages",
+				Event:     "created",
+				Filter:    filter,
+				Secret:    secret,
+			})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to create webhook: %v", err)), nil
+			}
+			result.Secret = "" // returned once, in configuration
+
+			response := map[string]interface{}{
+				"webhook": result,
+				"configuration": map[string]interface{}{
+					"targetUrl": targetURL,
+					"resource":  "messages",
+					"event":     "created",
+					"filter":    filter,
+					"secret":    secret,
+				},
+				"verification": webhookVerificationSteps,
+				"secretNote":   webhookSecretNote,
+			}
+			data, _ := json.MarshalIndent(response, "", "  ")
+			return mcp.NewToolResultText(string(data)), nil
+		},
+	)
+
 	// webex_webhooks_delete
 	s.AddTool(
 		mcp.NewTool("webex_webhooks_delete",
@@ -0,0 +1,225 @@
+package tools
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/WebexCommunity/webex-go-sdk/v2/webhooks"
+)
+
+func TestFilterWebhooks(t *testing.T) {
+	items := []webhooks.Webhook{
+		{ID: "1", Resource: "messages", Event: "created", TargetURL: "https://old-host.example.com/hook", Status: "active"},
+		{ID: "2", Resource: "messages", Event: "deleted", TargetURL: "https://new-host.example.com/hook", Status: "inactive"},
+		{ID: "3", Resource: "meetings", Event: "started", TargetURL: "https://OLD-HOST.example.com/meet", Status: "active"},
+	}
+
+	tests := []struct {
+		name   string
+		filter webhookFilter
+		want   []string
+	}{
+		{"resource", webhookFilter{Resource: "Messages"}, []string{"1", "2"}},
+		{"event", webhookFilter{Event: "started"}, []string{"3"}},
+		{"target url is case-insensitive", webhookFilter{TargetURLContains: "old-host"}, []string{"1", "3"}},
+		{"status", webhookFilter{Status: "inactive"}, []string{"2"}},
+		{"combined", webhookFilter{Resource: "messages", TargetURLContains: "old-host"}, []string{"1"}},
+		{"no match", webhookFilter{Resource: "rooms"}, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !tt.filter.active() {
+				t.Fatal("filter should be active")
+			}
+			got := filterWebhooks(items, tt.filter)
+			ids := make([]string, len(got))
+			for i, w := range got {
+				ids[i] = w.ID
+			}
+			if len(ids) != len(tt.want) {
+				t.Fatalf("got %v, want %v", ids, tt.want)
+			}
+			for i := range ids {
+				if ids[i] != tt.want[i] {
+					t.Fatalf("got %v, want %v", ids, tt.want)
+				}
+			}
+		})
+	}
+
+	if (webhookFilter{}).active() {
+		t.Error("empty filter should not be active")
+	}
+}
+
+func TestValidateWebhookTargetURL(t *testing.T) {
+	tests := []struct {
+		url     string
+		wantErr bool
+	}{
+		{"https://hooks.example.com/webex", false},
+		{"https://203.0.113.10/hook", false},
+		{"http://hooks.example.com/webex", true},
+		{"hooks.example.com/webex", true},
+		{"https://localhost:8443/hook", true},
+		{"https://127.0.0.1/hook", true},
+		{"https://10.1.2.3/hook", true},
+		{"https://192.168.0.5/hook", true},
+		{"https://[::1]/hook", true},
+	}
+	for _, tt := range tests {
+		if err := validateWebhookTargetURL(tt.url); (err != nil) != tt.wantErr {
+			t.Errorf("validateWebhookTargetURL(%q) error = %v, wantErr %v", tt.url, err, tt.wantErr)
+		}
+	}
+}
+
+func TestWebhooksRotateSecret(t *testing.T) {
+	var put map[string]interface{}
+	// Webex echoes the webhook back, secret included
+	srv := newCaptureServer(t, "/webhooks/w1",
+		`{"id":"w1","name":"Alerts","targetUrl":"https://hooks.example.com/webex","resource":"messages","event":"created","status":"inactive","secret":"old-secret"}`, &put,
+		func(body map[string]interface{}) (int, interface{}) {
+			return http.StatusOK, map[string]interface{}{
+				"id": "w1", "name": body["name"], "targetUrl": body["targetUrl"], "status": body["status"], "secret": body["secret"],
+			}
+		})
+	defer srv.Close()
+
+	result := callTool(t, RegisterWebhookTools, srv.URL, "webex_webhooks_rotate_secret", map[string]interface{}{"webhookId": "w1"})
+	if result.IsError {
+		t.Fatalf("rotate_secret failed: %s", resultText(result))
+	}
+	var resp struct {
+		Secret            string `json:"secret"`
+		HadPreviousSecret bool   `json:"hadPreviousSecret"`
+	}
+	text := resultText(result)
+	if err := json.Unmarshal([]byte(text), &resp); err != nil {
+		t.Fatalf("decode result: %v", err)
+	}
+
+	secret, _ := put["secret"].(string)
+	if secret == "" || secret == "old-secret" || resp.Secret != secret || !resp.HadPreviousSecret {
+		t.Errorf("PUT secret = %q, returned secret = %q, hadPreviousSecret = %v", secret, resp.Secret, resp.HadPreviousSecret)
+	}
+	delete(put, "secret")
+	want := map[string]interface{}{"name": "Alerts", "targetUrl": "https://hooks.example.com/webex", "status": "inactive"}
+	if !reflect.DeepEqual(put, want) {
+		t.Errorf("PUT body without secret = %v, want %v", put, want)
+	}
+	if n := strings.Count(text, secret); n != 1 {
+		t.Errorf("new secret appears %d times in the response, want exactly once", n)
+	}
+}
+
+// fakeWebhookCreateServer serves POST /webhooks via newCaptureServer, decoding the
+// body into *posted and echoing it back as Webex does, secret included.
+func fakeWebhookCreateServer(t *testing.T, posted *map[string]interface{}) *httptest.Server {
+	t.Helper()
+	return newCaptureServer(t, "/webhooks", "", posted, func(body map[string]interface{}) (int, interface{}) {
+		body["id"] = "w1"
+		return http.StatusOK, body
+	})
+}
+
+func TestWebhooksCreateSecret(t *testing.T) {
+	args := func(extra map[string]interface{}) map[string]interface{} {
+		a := map[string]interface{}{
+			"name":      "Alerts",
+			"targetUrl": "https://hooks.example.com/webex",
+			"resource":  "messages",
+			"event":     "created",
+		}
+		for k, v := range extra {
+			a[k] = v
+		}
+		return a
+	}
+
+	t.Run("generated", func(t *testing.T) {
+		var posted map[string]interface{}
+		srv := fakeWebhookCreateServer(t, &posted)
+		defer srv.Close()
+
+		result := callTool(t, RegisterWebhookTools, srv.URL, "webex_webhooks_create", args(map[string]interface{}{"generateSecret": true}))
+		if result.IsError {
+			t.Fatalf("create failed: %s", resultText(result))
+		}
+		var resp struct {
+			Secret string `json:"secret"`
+		}
+		text := resultText(result)
+		json.Unmarshal([]byte(text), &resp)
+		if len(resp.Secret) != 64 || posted["secret"] != resp.Secret {
+			t.Errorf("returned secret %q, sent %v; want the same 64-char secret", resp.Secret, posted["secret"])
+		}
+		if n := strings.Count(text, resp.Secret); n != 1 {
+			t.Errorf("secret appears %d times in the response, want exactly once", n)
+		}
+	})
+
+	t.Run("caller supplied", func(t *testing.T) {
+		var posted map[string]interface{}
+		srv := fakeWebhookCreateServer(t, &posted)
+		defer srv.Close()
+
+		result := callTool(t, RegisterWebhookTools, srv.URL, "webex_webhooks_create", args(map[string]interface{}{"secret": "my-own-secret"}))
+		if result.IsError {
+			t.Fatalf("create failed: %s", resultText(result))
+		}
+		if posted["secret"] != "my-own-secret" {
+			t.Errorf("sent secret %v, want the caller's secret", posted["secret"])
+		}
+	})
+
+	t.Run("both", func(t *testing.T) {
+		var posted map[string]interface{}
+		srv := fakeWebhookCreateServer(t, &posted)
+		defer srv.Close()
+
+		result := callTool(t, RegisterWebhookTools, srv.URL, "webex_webhooks_create", args(map[string]interface{}{"secret": "my-own-secret", "generateSecret": true}))
+		if !result.IsError || posted != nil {
+			t.Errorf("result = %q, posted = %v; want an error and no webhook created", resultText(result), posted)
+		}
+	})
+}
+
+func TestNotificationsSetup(t *testing.T) {
+	var posted map[string]interface{}
+	srv := fakeWebhookCreateServer(t, &posted)
+	defer srv.Close()
+
+	result := callTool(t, RegisterWebhookTools, srv.URL, "webex_notifications_setup", map[string]interface{}{
+		"targetUrl":   "https://hooks.example.com/webex",
+		"mentionedMe": true,
+	})
+	if result.IsError {
+		t.Fatalf("notifications_setup failed: %s", resultText(result))
+	}
+
+	secret, _ := posted["secret"].(string)
+	delete(posted, "secret")
+	delete(posted, "id")
+	want := map[string]interface{}{
+		"name":      "Messages mentioning me",
+		"targetUrl": "https://hooks.example.com/webex",
+		"resource":  "messages",
+		"event":     "created",
+		"filter":    "mentionedPeople=me",
+	}
+	if !reflect.DeepEqual(posted, want) {
+		t.Errorf("POST body without secret = %v, want %v", posted, want)
+	}
+	text := resultText(result)
+	if len(secret) != 64 {
+		t.Fatalf("sent secret %q, want a generated 64-char secret", secret)
+	}
+	if n := strings.Count(text, secret); n != 1 {
+		t.Errorf("secret appears %d times in the response, want exactly once", n)
+	}
+}